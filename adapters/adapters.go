@@ -0,0 +1,57 @@
+// Package adapters binds the path parameters captured by third party routers
+// with the runtime package, so that hand written servers don't each need
+// their own glue code. It doesn't depend on the routers themselves; they're
+// adapted through the functions they export for looking parameters up:
+//
+//	// chi
+//	err := adapters.BindPathParameter(chi.URLParam, r, "simple", false, "id", &id)
+//	// gorilla/mux
+//	err := adapters.BindPathParameter(adapters.Vars(mux.Vars), r, "simple", false, "id", &id)
+package adapters
+
+import (
+	"net/http"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// PathParamFunc returns the value of the named path parameter of r, or ""
+// when there's none, as chi.URLParam does.
+type PathParamFunc func(r *http.Request, name string) string
+
+// Vars adapts a router which returns all the path parameters of a request
+// as a map, such as gorilla/mux with mux.Vars, to a PathParamFunc.
+func Vars(vars func(r *http.Request) map[string]string) PathParamFunc {
+	return func(r *http.Request, name string) string {
+		return vars(r)[name]
+	}
+}
+
+// BindPathParameter binds the named path parameter of r, which is always
+// required, as runtime.BindStyledParameterWithOptions does.
+func BindPathParameter(param PathParamFunc, r *http.Request, style string, explode bool, paramName string,
+	dest interface{}) error {
+	return runtime.BindStyledParameterWithOptions(style, paramName, param(r, paramName), dest,
+		runtime.BindStyledParameterOptions{
+			ParamLocation: runtime.ParamLocationPath,
+			Explode:       explode,
+			Required:      true,
+		})
+}
+
+// BindPathParams binds the path parameters of r to the fields of the struct
+// dst points to which have a path tag, as runtime.BindPathParamsFunc does.
+func BindPathParams(param PathParamFunc, r *http.Request, dst interface{}) error {
+	return runtime.BindPathParamsFunc(dst, func(name string) string {
+		return param(r, name)
+	})
+}
+
+// BindRequestParams binds the path, query, header and cookie parameters of
+// r to the fields of the struct dst points to which have a param tag, as
+// runtime.BindRequestParamsFunc does.
+func BindRequestParams(param PathParamFunc, r *http.Request, dst interface{}) error {
+	return runtime.BindRequestParamsFunc(r, dst, func(name string) string {
+		return param(r, name)
+	})
+}
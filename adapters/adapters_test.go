@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+type varsKey struct{}
+
+// vars and urlParam stand in for mux.Vars and chi.URLParam.
+func vars(r *http.Request) map[string]string {
+	v, _ := r.Context().Value(varsKey{}).(map[string]string)
+	return v
+}
+
+func urlParam(r *http.Request, name string) string {
+	return vars(r)[name]
+}
+
+func TestBindPathParameter(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets/7/a,b", nil)
+	r = r.WithContext(context.WithValue(r.Context(), varsKey{}, map[string]string{"id": "7", "tags": "a,b"}))
+
+	for name, param := range map[string]PathParamFunc{"vars": Vars(vars), "urlParam": urlParam} {
+		t.Run(name, func(t *testing.T) {
+			var id int
+			require.NoError(t, BindPathParameter(param, r, "simple", false, "id", &id))
+			assert.Equal(t, 7, id)
+
+			var tags []string
+			require.NoError(t, BindPathParameter(param, r, "simple", false, "tags", &tags))
+			assert.Equal(t, []string{"a", "b"}, tags)
+
+			var missing string
+			var requiredErr *runtime.RequiredParamError
+			require.ErrorAs(t, BindPathParameter(param, r, "simple", false, "name", &missing), &requiredErr)
+			assert.Equal(t, runtime.ParamLocationPath, requiredErr.Location)
+
+			var params struct {
+				ID   int      `path:"id"`
+				Tags []string `path:"tags"`
+			}
+			require.NoError(t, BindPathParams(param, r, &params))
+			assert.Equal(t, 7, params.ID)
+			assert.Equal(t, []string{"a", "b"}, params.Tags)
+
+			var requestParams struct {
+				ID int `param:"id,in=path"`
+			}
+			require.NoError(t, BindRequestParams(param, r, &requestParams))
+			assert.Equal(t, 7, requestParams.ID)
+		})
+	}
+}
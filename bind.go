@@ -13,6 +13,11 @@
 // limitations under the License.
 package runtime
 
+import (
+	"fmt"
+	"reflect"
+)
+
 // Binder is the interface implemented by types that can be bound to a query string or a parameter string
 // The input can be assumed to be a valid string.  If you define a Bind method you are responsible for all
 // data being completely bound to the type.
@@ -22,3 +27,54 @@ package runtime
 type Binder interface {
 	Bind(src string) error
 }
+
+// Validatable is the interface implemented by types which check their own
+// constraints. When a parameter or request body has been bound successfully
+// to a destination which implements it, Validate is called, and its failure
+// is returned as a *ValidationError.
+type Validatable interface {
+	Validate() error
+}
+
+// ValidationError wraps the error returned by the Validate method of a
+// destination which was otherwise bound successfully.
+type ValidationError struct {
+	// ParamName is the name of the parameter which failed validation. It is
+	// empty for request bodies.
+	ParamName string
+	Err       error
+}
+
+func (e *ValidationError) Error() string {
+	if e.ParamName == "" {
+		return fmt.Sprintf("validation failed: %s", e.Err)
+	}
+	return fmt.Sprintf("parameter '%s' failed validation: %s", e.ParamName, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// validate calls the Validate method of dest, or of the value it points to,
+// if either is Validatable. Nil pointers, such as those of absent optional
+// parameters, aren't validated.
+func validate(paramName string, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	for v.IsValid() {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil
+		}
+		if val, ok := v.Interface().(Validatable); ok {
+			if err := val.Validate(); err != nil {
+				return &ValidationError{ParamName: paramName, Err: err}
+			}
+			return nil
+		}
+		if v.Kind() != reflect.Ptr {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return nil
+}
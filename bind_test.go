@@ -0,0 +1,87 @@
+package runtime
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type evenInt int
+
+func (i evenInt) Validate() error {
+	if i%2 != 0 {
+		return errors.New("must be even")
+	}
+	return nil
+}
+
+type validatedRange struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r *validatedRange) Validate() error {
+	if r.Min > r.Max {
+		return errors.New("min must not exceed max")
+	}
+	return nil
+}
+
+func TestValidatable(t *testing.T) {
+	assertInvalid := func(t *testing.T, err error, paramName string) {
+		t.Helper()
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, paramName, validationErr.ParamName)
+	}
+
+	var n evenInt
+	require.NoError(t, BindStyledParameterWithOptions("simple", "n", "4", &n,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath}))
+	err := BindStyledParameterWithOptions("simple", "n", "3", &n,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath})
+	assertInvalid(t, err, "n")
+	assert.EqualError(t, err, "parameter 'n' failed validation: must be even")
+
+	// Optional parameters are validated when present.
+	var optional *evenInt
+	require.NoError(t, BindQueryParameter("form", true, false, "n", url.Values{}, &optional))
+	assert.Nil(t, optional)
+	assertInvalid(t, BindQueryParameter("form", true, false, "n", url.Values{"n": {"5"}}, &optional), "n")
+	assertInvalid(t, BindQueryParameterWithOptions("form", "n", url.Values{}, &optional,
+		BindQueryParameterOptions{Default: "7"}), "n")
+	assertInvalid(t, BindJSONQueryParam("n", true, url.Values{"n": {"9"}}, &n), "n")
+
+	var r validatedRange
+	require.NoError(t, UnmarshalDeepObject(&r, "r", url.Values{"r[min]": {"1"}, "r[max]": {"2"}}))
+	assertInvalid(t, UnmarshalDeepObject(&r, "r", url.Values{"r[min]": {"3"}, "r[max]": {"2"}}), "r")
+	assertInvalid(t, BindQueryParameter("deepObject", true, true, "r",
+		url.Values{"r[min]": {"3"}, "r[max]": {"2"}}, &r), "r")
+	assertInvalid(t, UnmarshalObjectForm(&r, "r", url.Values{"r": {"min,3,max,2"}}), "r")
+	assertInvalid(t, UnmarshalDottedForm(&r, "r", url.Values{"min": {"3"}, "max": {"2"}}), "r")
+
+	var form validatedForm
+	require.NoError(t, BindForm(&form, map[string][]string{"name": {"a"}}, nil, nil))
+	err = BindForm(&validatedForm{}, map[string][]string{}, nil, nil)
+	assertInvalid(t, err, "")
+	assert.EqualError(t, err, "validation failed: name is required")
+
+	// Binding errors are reported as they were, without validating.
+	err = BindStyledParameterWithOptions("simple", "n", "x", &n, BindStyledParameterOptions{})
+	var validationErr *ValidationError
+	assert.False(t, errors.As(err, &validationErr))
+}
+
+type validatedForm struct {
+	Name string `json:"name"`
+}
+
+func (f validatedForm) Validate() error {
+	if f.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
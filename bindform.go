@@ -21,18 +21,63 @@ type RequestBodyEncoding struct {
 	Style       string
 	Explode     *bool
 	Required    *bool
+	// Headers are written with the parts of a multipart field, other than
+	// Content-Type, which is given by ContentType.
+	Headers map[string]string
 }
 
 func BindMultipart(ptr interface{}, reader multipart.Reader) error {
+	return BindMultipartWithOptions(ptr, reader, BindMultipartOptions{})
+}
+
+// BindMultipartOptions defines optional arguments for
+// BindMultipartWithOptions.
+type BindMultipartOptions struct {
+	// MaxMemory is the number of bytes of the form which are held in
+	// memory, as for multipart.Reader.ReadForm, beyond which files are
+	// stored in temporary files. It's 32 MB when it's 0.
+	MaxMemory int64
+	// MaxFieldFilesSize, when it's positive, limits the total size of the
+	// files of each field, such as a field of types.Files whose part is
+	// given many times.
+	MaxFieldFilesSize int64
+}
+
+// BindMultipartWithOptions reads a multipart form and binds it to the
+// struct ptr points to, as BindForm does. Fields which are given more than
+// once bind to slices, such as types.Files. When the files of a field
+// exceed MaxFieldFilesSize, the error wraps types.ErrFileTooLarge, and the
+// temporary files of the form are removed.
+func BindMultipartWithOptions(ptr interface{}, reader multipart.Reader, opts BindMultipartOptions) error {
 	const defaultMemory = 32 << 20
-	form, err := reader.ReadForm(defaultMemory)
+	maxMemory := opts.MaxMemory
+	if maxMemory == 0 {
+		maxMemory = defaultMemory
+	}
+	form, err := reader.ReadForm(maxMemory)
 	if err != nil {
 		return err
 	}
+	if opts.MaxFieldFilesSize > 0 {
+		for name, headers := range form.File {
+			var total int64
+			for _, header := range headers {
+				total += header.Size
+			}
+			if total > opts.MaxFieldFilesSize {
+				_ = form.RemoveAll()
+				return fmt.Errorf("files of field '%s' exceed %d bytes: %w", name, opts.MaxFieldFilesSize, types.ErrFileTooLarge)
+			}
+		}
+	}
 	return BindForm(ptr, form.Value, form.File, nil)
 }
 
-func BindForm(ptr interface{}, form map[string][]string, files map[string][]*multipart.FileHeader, encodings map[string]RequestBodyEncoding) error {
+func BindForm(ptr interface{}, form map[string][]string, files map[string][]*multipart.FileHeader, encodings map[string]RequestBodyEncoding) (err error) {
+	defer recoverPanic(&err, "error binding form")
+	if err := checkDestination("", ptr, false); err != nil {
+		return err
+	}
 	ptrVal := reflect.Indirect(reflect.ValueOf(ptr))
 	if ptrVal.Kind() != reflect.Struct {
 		return errors.New("form data body should be a struct")
@@ -85,10 +130,11 @@ func BindForm(ptr interface{}, form map[string][]string, files map[string][]*mul
 		}
 	}
 
-	return nil
+	return validate("", ptr)
 }
 
-func MarshalForm(ptr interface{}, encodings map[string]RequestBodyEncoding) (url.Values, error) {
+func MarshalForm(ptr interface{}, encodings map[string]RequestBodyEncoding) (_ url.Values, err error) {
+	defer recoverPanic(&err, "error marshaling form")
 	ptrVal := reflect.Indirect(reflect.ValueOf(ptr))
 	if ptrVal.Kind() != reflect.Struct {
 		return nil, errors.New("form data body should be a struct")
@@ -116,7 +162,9 @@ func MarshalForm(ptr interface{}, encodings map[string]RequestBodyEncoding) (url
 			}
 			return nil, errors.New("unsupported encoding, only application/json is supported")
 		} else {
-			marshalFormImpl(field, result, tag)
+			if err := marshalFormImpl(field, result, tag); err != nil {
+				return nil, err
+			}
 		}
 	}
 	return result, nil
@@ -124,6 +172,12 @@ func MarshalForm(ptr interface{}, encodings map[string]RequestBodyEncoding) (url
 
 func bindFormImpl(v reflect.Value, form map[string][]string, files map[string][]*multipart.FileHeader, name string) (bool, error) {
 	var hasData bool
+	if isFormatType(v.Type()) {
+		if value := form[name]; len(value) != 0 {
+			return true, BindStringToObject(value[0], v.Addr().Interface())
+		}
+		return false, nil
+	}
 	switch v.Kind() {
 	case reflect.Interface:
 		return bindFormImpl(v.Elem(), form, files, name)
@@ -139,12 +193,13 @@ func bindFormImpl(v reflect.Value, form map[string][]string, files map[string][]
 		return ptrHasData, err
 	case reflect.Slice:
 		if files := append(files[name], files[name+"[]"]...); len(files) != 0 {
-			if _, ok := v.Interface().([]types.File); ok {
-				result := make([]types.File, len(files))
+			// Either []types.File or types.Files.
+			if v.Type().Elem() == reflect.TypeOf(types.File{}) {
+				result := reflect.MakeSlice(v.Type(), len(files), len(files))
 				for i, file := range files {
-					result[i].InitFromMultipart(file)
+					result.Index(i).Addr().Interface().(*types.File).InitFromMultipart(file)
 				}
-				v.Set(reflect.ValueOf(result))
+				v.Set(result)
 				hasData = true
 			}
 		}
@@ -286,14 +341,23 @@ func bindAdditionalProperties(additionalProperties reflect.Value, parentStruct r
 	return hasData, nil
 }
 
-func marshalFormImpl(v reflect.Value, result url.Values, name string) {
+func marshalFormImpl(v reflect.Value, result url.Values, name string) error {
+	if s, ok, err := formatValue(v); ok {
+		if err != nil {
+			return fmt.Errorf("error formatting form field '%s': %w", name, err)
+		}
+		result[name] = append(result[name], s)
+		return nil
+	}
 	switch v.Kind() {
 	case reflect.Interface, reflect.Ptr:
-		marshalFormImpl(v.Elem(), result, name)
+		return marshalFormImpl(v.Elem(), result, name)
 	case reflect.Slice:
 		for i := 0; i < v.Len(); i++ {
 			elem := v.Index(i)
-			marshalFormImpl(elem, result, fmt.Sprintf("%s[%v]", name, i))
+			if err := marshalFormImpl(elem, result, fmt.Sprintf("%s[%v]", name, i)); err != nil {
+				return err
+			}
 		}
 	case reflect.Struct:
 		for i := 0; i < v.NumField(); i++ {
@@ -302,7 +366,9 @@ func marshalFormImpl(v reflect.Value, result url.Values, name string) {
 			if field.Name == "AdditionalProperties" && tag == "-" {
 				iter := v.MapRange()
 				for iter.Next() {
-					marshalFormImpl(iter.Value(), result, fmt.Sprintf("%s[%s]", name, iter.Key().String()))
+					if err := marshalFormImpl(iter.Value(), result, fmt.Sprintf("%s[%s]", name, iter.Key().String())); err != nil {
+						return err
+					}
 				}
 				continue
 			}
@@ -310,9 +376,12 @@ func marshalFormImpl(v reflect.Value, result url.Values, name string) {
 				continue
 			}
 			tag = strings.Split(tag, ",")[0] // extract the name of the tag
-			marshalFormImpl(v.Field(i), result, fmt.Sprintf("%s[%s]", name, tag))
+			if err := marshalFormImpl(v.Field(i), result, fmt.Sprintf("%s[%s]", name, tag)); err != nil {
+				return err
+			}
 		}
 	default:
 		result[name] = append(result[name], fmt.Sprint(v.Interface()))
 	}
+	return nil
 }
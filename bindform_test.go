@@ -8,6 +8,7 @@ import (
 
 	"github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBindURLForm(t *testing.T) {
@@ -119,6 +120,29 @@ func TestBindMultipartForm(t *testing.T) {
 	assert.Equal(t, "123.pdf", (*testStruct.OptFiles)[2].Filename())
 }
 
+func TestBindMultipartFiles(t *testing.T) {
+	var upload struct {
+		Attachments types.Files `json:"attachments"`
+	}
+	files := []fileData{
+		{field: "attachments", filename: "a.pdf", content: []byte("aaaa")},
+		{field: "attachments", filename: "b.pdf", content: []byte("bbbb")},
+	}
+
+	mr, err := makeMultipartFilesReader(files)
+	require.NoError(t, err)
+	require.NoError(t, BindMultipartWithOptions(&upload, *mr, BindMultipartOptions{MaxFieldFilesSize: 8}))
+	assert.Equal(t, []string{"a.pdf", "b.pdf"}, upload.Attachments.Filenames())
+	total, _ := upload.Attachments.TotalSize()
+	assert.Equal(t, int64(8), total)
+
+	mr, err = makeMultipartFilesReader(files)
+	require.NoError(t, err)
+	err = BindMultipartWithOptions(&upload, *mr, BindMultipartOptions{MaxFieldFilesSize: 7})
+	assert.ErrorIs(t, err, types.ErrFileTooLarge)
+	assert.EqualError(t, err, "files of field 'attachments' exceed 7 bytes: file: exceeds maximum size")
+}
+
 func TestMarshalForm(t *testing.T) {
 	type testSubStruct struct {
 		Int    int    `json:"int"`
@@ -174,6 +198,14 @@ type fileData struct {
 }
 
 func makeMultipartFilesForm(files []fileData) (*multipart.Form, error) {
+	mr, err := makeMultipartFilesReader(files)
+	if err != nil {
+		return nil, err
+	}
+	return mr.ReadForm(1024)
+}
+
+func makeMultipartFilesReader(files []fileData) (*multipart.Reader, error) {
 	var buffer bytes.Buffer
 	mw := multipart.NewWriter(&buffer)
 	for _, file := range files {
@@ -190,6 +222,5 @@ func makeMultipartFilesForm(files []fileData) (*multipart.Form, error) {
 	if err != nil {
 		return nil, err
 	}
-	mr := multipart.NewReader(&buffer, mw.Boundary())
-	return mr.ReadForm(1024)
+	return multipart.NewReader(&buffer, mw.Boundary()), nil
 }
@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"net/http"
+)
+
+// BindQuery binds the query parameters of r to a new T, a struct whose
+// fields have query tags. The tag names the parameter, and may set its
+// style, which defaults to form, whether it's exploded, which defaults to
+// whether the style is form, and whether it's required:
+//
+//	type ListPetsParams struct {
+//		Limit *int      `query:"limit"`
+//		Tags  []string  `query:"tags,style=pipeDelimited,required"`
+//		Owner *[]string `query:"owner,explode=false"`
+//	}
+//
+//	params, err := runtime.BindQuery[ListPetsParams](r)
+//
+// Optional parameters are left alone when they're absent, so their fields
+// may be pointers, which stay nil, or values, which keep their zero value,
+// unless the field has a default tag, such as `default:"10"`, which is
+// bound in their place, as BindQueryParameterOptions.Default is.
+// Every field is bound, and the errors of those which fail are returned
+// together as ParamErrors. When they all bind, a Validatable T is validated.
+// The query is parsed once per request given ParseQueryOnce.
+func BindQuery[T any](r *http.Request) (T, error) {
+	var params T
+	q := requestQuery(r)
+	var b Bindings
+	if err := bindTaggedFields(&params, "query", paramTag{style: "form"}, func(p paramTag, dest interface{}) {
+		b.Query(p.style, p.name, q.values, dest, BindQueryParameterOptions{Explode: p.explode, Required: p.required, Default: p.def})
+	}); err != nil {
+		return params, err
+	}
+	q.record(&b)
+	return params, bindingsErr(&b, &params)
+}
+
+// BindHeaders binds the header parameters of r to a new T, a struct whose
+// fields have header tags, as BindQuery does for query parameters, default
+// tags included. The style defaults to simple, and isn't exploded unless the
+// tag says so:
+//
+//	type GetPetHeaders struct {
+//		RequestID string            `header:"X-Request-ID,required"`
+//		Meta      map[string]string `header:"X-Meta,explode"`
+//	}
+func BindHeaders[T any](r *http.Request) (T, error) {
+	var params T
+	var b Bindings
+	if err := bindTaggedFields(&params, "header", paramTag{style: "simple"}, func(p paramTag, dest interface{}) {
+		b.HeaderWithOptions(p.style, p.name, r.Header, dest,
+			BindHeaderParameterOptions{Explode: p.explode, Required: p.required, Default: p.def})
+	}); err != nil {
+		return params, err
+	}
+	return params, bindingsErr(&b, &params)
+}
+
+// bindingsErr returns the errors of b, or, when there are none, the error
+// validating the parameters struct params points to.
+func bindingsErr(b *Bindings, params interface{}) error {
+	if err := b.Err(); err != nil {
+		return err
+	}
+	return validate("", params)
+}
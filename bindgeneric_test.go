@@ -0,0 +1,129 @@
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type genericQueryParams struct {
+	Limit *int      `query:"limit"`
+	Tags  []string  `query:"tags,style=pipeDelimited,required"`
+	Owner *[]string `query:"owner,explode=false"`
+	Color *[]string `query:"color"`
+	Page  int       `query:"page"`
+	Sort  []string  `query:"sort"`
+	Skip  string
+}
+
+func (p genericQueryParams) Validate() error {
+	if p.Limit != nil && *p.Limit > 100 {
+		return errors.New("limit is too large")
+	}
+	return nil
+}
+
+func TestBindQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets?limit=5&tags=a|b&owner=x,y&color=red&color=blue&page=2&Skip=1", nil)
+	params, err := BindQuery[genericQueryParams](r)
+	require.NoError(t, err)
+	assert.Equal(t, 5, *params.Limit)
+	assert.Equal(t, []string{"a", "b"}, params.Tags)
+	assert.Equal(t, []string{"x", "y"}, *params.Owner)
+	assert.Equal(t, []string{"red", "blue"}, *params.Color)
+	assert.Equal(t, 2, params.Page)
+	assert.Nil(t, params.Sort)
+	assert.Empty(t, params.Skip)
+
+	// Every field is bound, and all the failures are reported.
+	r = httptest.NewRequest(http.MethodGet, "/pets?limit=ten", nil)
+	_, err = BindQuery[genericQueryParams](r)
+	var paramErrs ParamErrors
+	require.ErrorAs(t, err, &paramErrs)
+	require.Len(t, paramErrs, 2)
+	assert.Equal(t, "limit", paramErrs[0].ParamName)
+	assert.Equal(t, "tags", paramErrs[1].ParamName)
+
+	r = httptest.NewRequest(http.MethodGet, "/pets?limit=500&tags=a", nil)
+	_, err = BindQuery[genericQueryParams](r)
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.EqualError(t, err, "validation failed: limit is too large")
+
+	_, err = BindQuery[struct {
+		Limit int `query:"limit,explode=maybe"`
+	}](r)
+	assert.EqualError(t, err, "error parsing query tag of field 'Limit': invalid explode option 'maybe'")
+
+	_, err = BindQuery[int](r)
+	assert.EqualError(t, err, "binding query parameters requires a pointer to a struct, not *int")
+}
+
+func TestBindHeaders(t *testing.T) {
+	type Headers struct {
+		RequestID string            `header:"X-Request-ID,required"`
+		Meta      map[string]string `header:"X-Meta,explode"`
+		Tags      *[]string         `header:"X-Tags"`
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	r.Header.Set("X-Meta", "role=admin,level=5")
+	headers, err := BindHeaders[Headers](r)
+	require.NoError(t, err)
+	assert.Equal(t, Headers{RequestID: "abc", Meta: map[string]string{"role": "admin", "level": "5"}}, headers)
+
+	r.Header.Del("X-Request-ID")
+	_, err = BindHeaders[Headers](r)
+	var requiredErr *RequiredParamError
+	require.ErrorAs(t, err, &requiredErr)
+	assert.Equal(t, "X-Request-ID", requiredErr.ParamName)
+}
+
+func TestBindOptionalParameters(t *testing.T) {
+	q := url.Values{"limit": {"0"}, "filter": {`{"name":"Rex"}`}}
+
+	var limit types.Optional[int]
+	require.NoError(t, BindQueryParameter("form", true, false, "limit", q, &limit))
+	assert.Equal(t, types.NewOptional(0), limit)
+
+	var offset types.Optional[int]
+	require.NoError(t, BindQueryParameter("form", true, false, "offset", q, &offset))
+	assert.False(t, offset.Set)
+
+	err := BindQueryParameter("form", true, true, "offset", q, &offset)
+	var requiredErr *RequiredParamError
+	require.ErrorAs(t, err, &requiredErr)
+	assert.Equal(t, "offset", requiredErr.ParamName)
+
+	var filter types.Optional[map[string]string]
+	require.NoError(t, BindJSONQueryParam("filter", false, q, &filter))
+	assert.Equal(t, map[string]string{"name": "Rex"}, filter.Value)
+	assert.True(t, filter.Set)
+
+	header := http.Header{"X-Rate": {"3"}}
+	var rate types.Optional[int]
+	require.NoError(t, BindHeaderParameter("simple", "X-Rate", header, &rate, BindHeaderParameterOptions{}))
+	assert.Equal(t, types.NewOptional(3), rate)
+
+	r := httptest.NewRequest(http.MethodGet, "/pets?limit=0", nil)
+	params, err := BindQuery[struct {
+		Limit  types.Optional[int] `query:"limit"`
+		Offset types.Optional[int] `query:"offset"`
+	}](r)
+	require.NoError(t, err)
+	assert.Equal(t, types.NewOptional(0), params.Limit)
+	assert.False(t, params.Offset.Set)
+
+	_, err = BindQuery[struct {
+		Offset types.Optional[int] `query:"offset,required"`
+	}](r)
+	assert.ErrorAs(t, err, &requiredErr)
+}
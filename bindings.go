@@ -0,0 +1,144 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ParamError annotates an error binding a parameter with the parameter's
+// name and location.
+type ParamError struct {
+	ParamName string
+	Location  ParamLocation
+	Err       error
+}
+
+func (e *ParamError) Error() string {
+	if e.Location == ParamLocationUndefined {
+		return fmt.Sprintf("invalid parameter '%s': %s", e.ParamName, e.Err)
+	}
+	return fmt.Sprintf("invalid %s parameter '%s': %s", e.Location, e.ParamName, e.Err)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors is the set of errors returned by Bindings.Err, one for each
+// parameter which failed to bind, in the order they were bound.
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As look into each of the errors.
+func (e ParamErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Bindings binds a number of parameters, carrying on past failures, so that
+// all of the invalid parameters of a request can be reported at once:
+//
+//	var b runtime.Bindings
+//	b.Path("simple", false, "id", id, &params.ID).
+//		Query("form", "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Explode: true}).
+//		Header("simple", false, true, "X-Request-ID", r.Header, &params.XRequestID)
+//	if err := b.Err(); err != nil {
+//		...
+//	}
+//
+// The zero value is ready to use.
+type Bindings struct {
+	errs  ParamErrors
+	query consumedKeys
+}
+
+// Add records the outcome of binding a parameter by other means, such as
+// BindJSONQueryParam. Nil errors are ignored.
+func (b *Bindings) Add(paramName string, location ParamLocation, err error) *Bindings {
+	if location == ParamLocationQuery {
+		b.query.add(paramName)
+	}
+	if err != nil {
+		b.errs = append(b.errs, &ParamError{ParamName: paramName, Location: location, Err: err})
+	}
+	return b
+}
+
+// Path binds a path parameter, which is always required, as
+// BindStyledParameterWithOptions does.
+func (b *Bindings) Path(style string, explode bool, paramName string, value string, dest interface{}) *Bindings {
+	return b.Add(paramName, ParamLocationPath, BindStyledParameterWithOptions(style, paramName, value, dest,
+		BindStyledParameterOptions{
+			ParamLocation: ParamLocationPath,
+			Explode:       explode,
+			Required:      true,
+		}))
+}
+
+// Query binds a query parameter, as BindQueryParameterWithOptions does.
+func (b *Bindings) Query(style string, paramName string, queryParams url.Values, dest interface{},
+	opts BindQueryParameterOptions) *Bindings {
+	b.query.addQueryParam(style, opts.Explode, paramName, dest)
+	return b.Add(paramName, ParamLocationQuery, BindQueryParameterWithOptions(style, paramName, queryParams, dest, opts))
+}
+
+// Header binds a header parameter from the request headers, as
+// BindHeaderParameter does.
+func (b *Bindings) Header(style string, explode bool, required bool, paramName string, header http.Header,
+	dest interface{}) *Bindings {
+	return b.Add(paramName, ParamLocationHeader, BindHeaderParameter(style, paramName, header, dest,
+		BindHeaderParameterOptions{
+			Explode:  explode,
+			Required: required,
+		}))
+}
+
+// HeaderWithOptions binds a header parameter from the request headers with
+// the given options, such as a Default, as BindHeaderParameter does.
+func (b *Bindings) HeaderWithOptions(style string, paramName string, header http.Header, dest interface{},
+	opts BindHeaderParameterOptions) *Bindings {
+	return b.Add(paramName, ParamLocationHeader, BindHeaderParameter(style, paramName, header, dest, opts))
+}
+
+// Cookie binds a cookie parameter, as BindCookieParameter does.
+func (b *Bindings) Cookie(style string, explode bool, required bool, paramName string, r *http.Request,
+	dest interface{}) *Bindings {
+	return b.Add(paramName, ParamLocationCookie, BindCookieParameter(style, explode, required, paramName, r, dest))
+}
+
+// UnknownQuery returns an *UnknownParamsError listing the keys of
+// queryParams which none of the query parameters bound so far consumed, so
+// that APIs can reject misspelled parameters rather than ignore them, or nil
+// if they were all consumed:
+//
+//	b.Query("form", "limit", q, &params.Limit, runtime.BindQueryParameterOptions{Explode: true})
+//	if err := b.UnknownQuery(q); err != nil {
+//		...
+//	}
+func (b *Bindings) UnknownQuery(queryParams url.Values) error {
+	if names := b.query.unknown(queryParams); len(names) > 0 {
+		return &UnknownParamsError{Location: ParamLocationQuery, Names: names}
+	}
+	return nil
+}
+
+// Err returns the errors of all the parameters which failed to bind as
+// ParamErrors, or nil if they all bound.
+func (b *Bindings) Err() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return b.errs
+}
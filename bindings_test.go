@@ -0,0 +1,112 @@
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindings(t *testing.T) {
+	type Params struct {
+		ID        int
+		Limit     *int
+		Tags      *[]string
+		RequestID string
+		Session   *string
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?limit=5&tags=a&tags=b", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+
+	var params Params
+	var b Bindings
+	err := b.Path("simple", false, "id", "7", &params.ID).
+		Query("form", "limit", r.URL.Query(), &params.Limit, BindQueryParameterOptions{Explode: true}).
+		Query("form", "tags", r.URL.Query(), &params.Tags, BindQueryParameterOptions{Explode: true}).
+		Header("simple", false, true, "x-request-id", r.Header, &params.RequestID).
+		Cookie("form", false, false, "session", r, &params.Session).
+		Err()
+	require.NoError(t, err)
+	assert.Equal(t, 7, params.ID)
+	assert.Equal(t, 5, *params.Limit)
+	assert.Equal(t, []string{"a", "b"}, *params.Tags)
+	assert.Equal(t, "abc", params.RequestID)
+	assert.Equal(t, "s1", *params.Session)
+
+	// All the failures are reported, not just the first.
+	r = httptest.NewRequest(http.MethodGet, "/?limit=ten", nil)
+	b = Bindings{}
+	err = b.Path("simple", false, "id", "x", &params.ID).
+		Query("form", "limit", r.URL.Query(), &params.Limit, BindQueryParameterOptions{Explode: true}).
+		Header("simple", false, true, "X-Request-ID", r.Header, &params.RequestID).
+		Add("extra", ParamLocationQuery, nil).
+		Err()
+	require.Error(t, err)
+
+	var paramErrs ParamErrors
+	require.ErrorAs(t, err, &paramErrs)
+	require.Len(t, paramErrs, 3)
+	assert.Equal(t, "id", paramErrs[0].ParamName)
+	assert.Equal(t, ParamLocationPath, paramErrs[0].Location)
+	assert.Equal(t, "limit", paramErrs[1].ParamName)
+	assert.Equal(t, ParamLocationQuery, paramErrs[1].Location)
+	assert.Equal(t, "X-Request-ID", paramErrs[2].ParamName)
+	assert.Equal(t, ParamLocationHeader, paramErrs[2].Location)
+	assert.Contains(t, err.Error(), "invalid path parameter 'id'")
+	assert.Contains(t, err.Error(), "; invalid header parameter 'X-Request-ID': header parameter 'X-Request-ID' is required")
+
+	var requiredErr *RequiredParamError
+	require.True(t, errors.As(err, &requiredErr))
+	assert.Equal(t, "X-Request-ID", requiredErr.ParamName)
+}
+
+func TestBindingsUnknownQuery(t *testing.T) {
+	type Filter struct {
+		Role string `json:"role"`
+		Age  int    `json:"age,omitempty"`
+		Name string
+	}
+	var (
+		limit  *int
+		filter *Filter
+		sort   *map[string]string
+		ids    *[]int
+		meta   *string
+	)
+
+	q := url.Values{
+		"limit":     {"5"},
+		"role":      {"admin"},
+		"Name":      {"Alex"},
+		"sort[by]":  {"name"},
+		"ids":       {"1", "2"},
+		"meta":      {`"x"`},
+		"limt":      {"6"},
+		"sort":      {"x"},
+		"fitler[a]": {"b"},
+	}
+	var b Bindings
+	b.Query("form", "limit", q, &limit, BindQueryParameterOptions{Explode: true}).
+		Query("form", "filter", q, &filter, BindQueryParameterOptions{Explode: true}).
+		Query("deepObject", "sort", q, &sort, BindQueryParameterOptions{Explode: true}).
+		Query("form", "ids", q, &ids, BindQueryParameterOptions{Explode: true}).
+		Add("meta", ParamLocationQuery, BindJSONQueryParam("meta", false, q, &meta))
+	require.NoError(t, b.Err())
+
+	err := b.UnknownQuery(q)
+	var unknownErr *UnknownParamsError
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, ParamLocationQuery, unknownErr.Location)
+	assert.Equal(t, []string{"fitler[a]", "limt"}, unknownErr.Names)
+	assert.EqualError(t, err, "unknown query parameters: fitler[a], limt")
+
+	delete(q, "limt")
+	delete(q, "fitler[a]")
+	assert.NoError(t, b.UnknownQuery(q))
+}
@@ -14,12 +14,16 @@
 package runtime
 
 import (
+	"database/sql"
 	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mime"
+	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -55,6 +59,20 @@ func BindStyledParameterWithLocation(style string, explode bool, paramName strin
 	})
 }
 
+// RequiredParamError is returned when a required parameter is absent, or
+// empty, so that it can be told apart from a parameter which is malformed.
+type RequiredParamError struct {
+	ParamName string
+	Location  ParamLocation
+}
+
+func (e *RequiredParamError) Error() string {
+	if e.Location == ParamLocationUndefined {
+		return fmt.Sprintf("parameter '%s' is required", e.ParamName)
+	}
+	return fmt.Sprintf("%s parameter '%s' is required", e.Location, e.ParamName)
+}
+
 // BindStyledParameterOptions defines optional arguments for BindStyledParameterWithOptions
 type BindStyledParameterOptions struct {
 	// ParamLocation tells us where the parameter is located in the request.
@@ -63,44 +81,65 @@ type BindStyledParameterOptions struct {
 	Explode bool
 	// Whether the parameter is required in the query
 	Required bool
+	// LenientBool accepts "on"/"off", "yes"/"no" and similar spellings when
+	// binding booleans, in addition to those understood by strconv.ParseBool.
+	LenientBool bool
+	// TimeParsers are the formats accepted for time.Time values, in place of
+	// the package's TimeParsers.
+	TimeParsers []TimeParser
+	// TrimSpace trims the whitespace surrounding the value.
+	TrimSpace bool
+	// DecodeMIMEWords decodes MIME encoded-words in header values, such as
+	// =?UTF-8?B?Wm/Dqw==?=, as described by RFC 2047, which some clients
+	// use to send text which isn't ASCII. Arrays and objects are decoded
+	// once they're split up.
+	DecodeMIMEWords bool
+	// DecodePercent decodes header values percent-encoded by
+	// StyleParamWithOptions. It's off by default, since other clients send
+	// header values verbatim, and those may contain a '%' of their own, as in
+	// "50%25" or a URL. Values which aren't valid percent-encoding are taken
+	// verbatim either way. Cookie values are always decoded.
+	DecodePercent bool
+	// Default is bound in place of an empty value, such as that of a header
+	// which isn't present, so that the parameter takes the default value
+	// from its schema. It's given in the same serialized form as the value.
+	Default string
 }
 
 // BindStyledParameterWithOptions binds a parameter as described in the Path Parameters
 // section here to a Go object:
 // https://swagger.io/docs/specification/serialization/
-func BindStyledParameterWithOptions(style string, paramName string, value string, dest any, opts BindStyledParameterOptions) error {
+// Destinations which are Validatable are validated once bound.
+func BindStyledParameterWithOptions(style string, paramName string, value string, dest any, opts BindStyledParameterOptions) (err error) {
+	defer recoverPanic(&err, "error binding parameter '%s'", paramName)
+	if err := checkDestination(paramName, dest, false); err != nil {
+		return err
+	}
+	if err := bindStyledParameter(style, paramName, value, dest, opts); err != nil {
+		return err
+	}
+	return validate(paramName, dest)
+}
+
+func bindStyledParameter(style string, paramName string, value string, dest any, opts BindStyledParameterOptions) error {
+	if opts.TrimSpace {
+		value = strings.TrimSpace(value)
+	}
+	if value == "" {
+		value = opts.Default
+	}
 	if opts.Required {
 		if value == "" {
-			return fmt.Errorf("parameter '%s' is empty, can't bind its value", paramName)
+			return &RequiredParamError{ParamName: paramName, Location: opts.ParamLocation}
 		}
 	}
 
-	// Based on the location of the parameter, we need to unescape it properly.
-	var err error
-	switch opts.ParamLocation {
-	case ParamLocationQuery, ParamLocationUndefined:
-		// We unescape undefined parameter locations here for older generated code,
-		// since prior to this refactoring, they always query unescaped.
-		value, err = url.QueryUnescape(value)
+	if custom, found := lookupParamStyle(style); found {
+		value, err := opts.unescape(paramName, value)
 		if err != nil {
-			return fmt.Errorf("error unescaping query parameter '%s': %v", paramName, err)
-		}
-	case ParamLocationPath:
-		value, err = url.PathUnescape(value)
-		if err != nil {
-			return fmt.Errorf("error unescaping path parameter '%s': %v", paramName, err)
-		}
-	default:
-		// Headers and cookies aren't escaped.
-	}
-
-	// If the destination implements encoding.TextUnmarshaler we use it for binding
-	if tu, ok := dest.(encoding.TextUnmarshaler); ok {
-		if err := tu.UnmarshalText([]byte(value)); err != nil {
-			return fmt.Errorf("error unmarshaling '%s' text as %T: %s", value, dest, err)
+			return err
 		}
-
-		return nil
+		return custom.binder(paramName, value, dest, opts)
 	}
 
 	// Everything comes in by pointer, dereference it
@@ -109,29 +148,142 @@ func BindStyledParameterWithOptions(style string, paramName string, value string
 	// This is the basic type of the destination object.
 	t := v.Type()
 
-	if t.Kind() == reflect.Struct {
-		// We've got a destination object, we'll create a JSON representation
-		// of the input value, and let the json library deal with the unmarshaling
-		parts, err := splitStyledParameter(style, opts.Explode, true, paramName, value)
+	// Path, header and cookie parameters are split up before they're
+	// unescaped, so that escaped delimiters, such as %2C for a comma, are
+	// part of the values rather than delimiters. Query parameters are
+	// unescaped as a whole, since their delimiters may themselves be
+	// escaped, as spaces are.
+	var err error
+	unescapePart := opts.unescape
+	if opts.ParamLocation == ParamLocationQuery || opts.ParamLocation == ParamLocationUndefined {
+		value, err = opts.unescape(paramName, value)
 		if err != nil {
 			return err
 		}
+		unescapePart = keepValue
+	}
 
-		return bindSplitPartsToDestinationStruct(paramName, parts, opts.Explode, dest)
+	// Interfaces are bound as objects whose concrete type is chosen by a
+	// discriminator property.
+	if t.Kind() == reflect.Interface {
+		if d, found := lookupDiscriminator(t); found {
+			parts, err := splitStyledParameter(style, opts.Explode, true, paramName, value)
+			if err != nil {
+				return err
+			}
+			for i := range parts {
+				if parts[i], err = unescapePart(paramName, parts[i]); err != nil {
+					return err
+				}
+			}
+			return bindDiscriminatedObject(paramName, parts, opts.Explode, d, v)
+		}
 	}
 
-	if t.Kind() == reflect.Slice {
-		// Chop up the parameter into parts based on its style
-		parts, err := splitStyledParameter(style, opts.Explode, false, paramName, value)
+	// Structs which bind themselves, such as Binders, are primitives rather
+	// than objects, as are the elements of slices of them.
+	_, isTextUnmarshaler := dest.(encoding.TextUnmarshaler)
+	if !isTextUnmarshaler && !isFormatType(t) && (t.Kind() == reflect.Struct && isObjectDestination(t) || t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+		object := t.Kind() != reflect.Slice
+		parts, err := splitStyledParameter(style, opts.Explode, object, paramName, value)
 		if err != nil {
+			if object {
+				return err
+			}
 			return fmt.Errorf("error splitting input '%s' into parts: %s", value, err)
 		}
 
-		return bindSplitPartsToDestinationArray(parts, dest)
+		// Exploded matrix arrays may hold objects, each given as an
+		// unexploded object: ;item=id,1,name,a;item=id,2,name,b
+		if style == "matrix" && opts.Explode && !object && isObjectDestination(t.Elem()) {
+			return bindSplitPartsToDestinationStructArray(paramName, parts, dest, unescapePart)
+		}
+
+		// Exploded matrix maps may also repeat the parameter name for each
+		// entry, with its key and value: ;color=R,100;color=G,200
+		explode := opts.Explode
+		if style == "matrix" && explode && t.Kind() == reflect.Map {
+			if entries, ok := matrixMapEntries(paramName, parts); ok {
+				parts, explode = entries, false
+			}
+		}
+
+		for i := range parts {
+			if parts[i], err = unescapePart(paramName, parts[i]); err != nil {
+				return err
+			}
+		}
+		if t.Kind() == reflect.Map {
+			return bindSplitPartsToDestinationMap(paramName, parts, explode, dest, opts.bindStringOptions())
+		}
+		if object && opts.ParamLocation == ParamLocationHeader {
+			return bindSplitPartsToHeaderObject(paramName, parts, opts.Explode, dest, opts.bindStringOptions())
+		}
+		if object {
+			// We've got a destination object, we'll create a JSON representation
+			// of the input value, and let the json library deal with the unmarshaling
+			return bindSplitPartsToDestinationStruct(paramName, parts, opts.Explode, dest)
+		}
+		return bindSplitPartsToDestinationArray(parts, dest, opts.bindStringOptions())
+	}
+
+	value, err = unescapePart(paramName, value)
+	if err != nil {
+		return err
+	}
+
+	// Bind the remaining types as a base type, which includes those which
+	// implement encoding.TextUnmarshaler.
+	return bindStringToObject(value, dest, opts.bindStringOptions())
+}
+
+// unescape unescapes a parameter value, or part of one, based on the
+// location of the parameter.
+func (o BindStyledParameterOptions) unescape(paramName string, value string) (string, error) {
+	var err error
+	switch o.ParamLocation {
+	case ParamLocationQuery, ParamLocationUndefined:
+		// We unescape undefined parameter locations here for older generated code,
+		// since prior to this refactoring, they always query unescaped.
+		value, err = url.QueryUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("error unescaping query parameter '%s': %v", paramName, err)
+		}
+	case ParamLocationPath:
+		value, err = url.PathUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("error unescaping path parameter '%s': %v", paramName, err)
+		}
+	case ParamLocationHeader, ParamLocationCookie:
+		// Headers and cookies are percent-encoded by StyleParamWithOptions,
+		// but other clients may send values which aren't, so those are taken
+		// verbatim. Headers are only decoded when asked to be, since their
+		// values are otherwise sent as they are.
+		if o.ParamLocation == ParamLocationCookie || o.DecodePercent {
+			if unescaped, err := url.PathUnescape(value); err == nil {
+				value = unescaped
+			}
+		}
+		if o.DecodeMIMEWords && o.ParamLocation == ParamLocationHeader {
+			value, err = new(mime.WordDecoder).DecodeHeader(value)
+			if err != nil {
+				return "", fmt.Errorf("error decoding header parameter '%s': %v", paramName, err)
+			}
+		}
 	}
+	return value, nil
+}
 
-	// Try to bind the remaining types as a base type.
-	return BindStringToObject(value, dest)
+// keepValue stands in for unescaping values which are already unescaped.
+func keepValue(_ string, value string) (string, error) {
+	return value, nil
+}
+
+func (o BindStyledParameterOptions) bindStringOptions() bindStringOptions {
+	return bindStringOptions{
+		lenientBool: o.LenientBool,
+		timeParsers: o.TimeParsers,
+	}
 }
 
 // This is a complex set of operations, but each given parameter style can be
@@ -201,7 +353,7 @@ func splitStyledParameter(style string, explode bool, object bool, paramName str
 			str := strings.TrimPrefix(value, prefix)
 			return strings.Split(str, ","), nil
 		}
-	case "form":
+	case "form", "spaceDelimited", "pipeDelimited":
 		var parts []string
 		if explode {
 			parts = strings.Split(value, "&")
@@ -213,7 +365,7 @@ func splitStyledParameter(style string, explode bool, object bool, paramName str
 			}
 			return parts, nil
 		} else {
-			parts = strings.Split(value, ",")
+			parts = strings.Split(value, styleDelimiter(style))
 			prefix := paramName + "="
 			for i := range parts {
 				parts[i] = strings.TrimPrefix(parts[i], prefix)
@@ -225,9 +377,22 @@ func splitStyledParameter(style string, explode bool, object bool, paramName str
 	return nil, fmt.Errorf("unhandled parameter style: %s", style)
 }
 
+// styleDelimiter returns the delimiter between unexploded values of the
+// form, spaceDelimited and pipeDelimited styles.
+func styleDelimiter(style string) string {
+	switch style {
+	case "spaceDelimited":
+		return " "
+	case "pipeDelimited":
+		return "|"
+	default:
+		return ","
+	}
+}
+
 // Given a set of values as a slice, create a slice to hold them all, and
 // assign to each one by one.
-func bindSplitPartsToDestinationArray(parts []string, dest interface{}) error {
+func bindSplitPartsToDestinationArray(parts []string, dest interface{}, opts bindStringOptions) error {
 	// Everything comes in by pointer, dereference it
 	v := reflect.Indirect(reflect.ValueOf(dest))
 
@@ -239,7 +404,129 @@ func bindSplitPartsToDestinationArray(parts []string, dest interface{}) error {
 	// hold all the parts.
 	newArray := reflect.MakeSlice(t, len(parts), len(parts))
 	for i, p := range parts {
-		err := BindStringToObject(p, newArray.Index(i).Addr().Interface())
+		err := bindStringToObject(p, newArray.Index(i).Addr().Interface(), opts)
+		if err != nil {
+			return fmt.Errorf("error setting array element: %w", err)
+		}
+	}
+	v.Set(newArray)
+	return nil
+}
+
+// bindSplitPartsToDestinationMap binds the properties of an object to a
+// destination map, like bindSplitPartsToDestinationStruct does for structs,
+// binding each key and value to the key and element types of the map.
+func bindSplitPartsToDestinationMap(paramName string, parts []string, explode bool, dest interface{}, opts bindStringOptions) error {
+	var pairs [][2]string
+	if explode {
+		pairs = make([][2]string, len(parts))
+		for i, property := range parts {
+			key, value, found := strings.Cut(property, "=")
+			if !found {
+				return fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+			}
+			pairs[i] = [2]string{key, value}
+		}
+	} else {
+		if len(parts)%2 != 0 {
+			return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
+		}
+		pairs = make([][2]string, len(parts)/2)
+		for i := 0; i < len(parts); i += 2 {
+			pairs[i/2] = [2]string{parts[i], parts[i+1]}
+		}
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	t := v.Type()
+	newMap := reflect.MakeMapWithSize(t, len(pairs))
+	for _, pair := range pairs {
+		key := reflect.New(t.Key())
+		if err := bindStringToObject(pair[0], key.Interface(), opts); err != nil {
+			return fmt.Errorf("error binding key '%s' of parameter '%s': %w", pair[0], paramName, err)
+		}
+		elem := reflect.New(t.Elem())
+		if t.Elem().Kind() == reflect.Interface && t.Elem().NumMethod() == 0 {
+			// Free-form values are kept as strings.
+			elem.Elem().Set(reflect.ValueOf(pair[1]))
+		} else if err := bindStringToObject(pair[1], elem.Interface(), opts); err != nil {
+			return fmt.Errorf("error binding property '%s' of parameter '%s': %w", pair[0], paramName, err)
+		}
+		newMap.SetMapIndex(key.Elem(), elem.Elem())
+	}
+	v.Set(newMap)
+	return nil
+}
+
+// matrixMapEntries flattens the parts of an exploded matrix map given as
+// entries, each named after the parameter and holding a key and a value,
+// such as "color=R,100", into alternating keys and values, as for an
+// unexploded map. It returns false when the parts aren't all entries, and
+// are properties instead, such as "R=100".
+func matrixMapEntries(paramName string, parts []string) ([]string, bool) {
+	prefix := paramName + "="
+	entries := make([]string, 0, 2*len(parts))
+	for _, part := range parts {
+		entry, found := strings.CutPrefix(part, prefix)
+		if !found {
+			return nil, false
+		}
+		key, value, found := strings.Cut(entry, ",")
+		if !found || strings.Contains(value, ",") {
+			return nil, false
+		}
+		entries = append(entries, key, value)
+	}
+	return entries, len(entries) > 0
+}
+
+// isBinderSlice tells whether values of type t are slices which bind
+// themselves from a single value, such as types.Bytes, rather than arrays.
+func isBinderSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice &&
+		(reflect.PtrTo(t).Implements(reflect.TypeOf((*Binder)(nil)).Elem()) ||
+			reflect.PtrTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()))
+}
+
+// isObjectDestination tells whether values of type t are bound from
+// properties, rather than from a single primitive value.
+func isObjectDestination(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PtrTo(t)
+	return !pt.Implements(reflect.TypeOf((*Binder)(nil)).Elem()) &&
+		!pt.Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) &&
+		!pt.Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) &&
+		!isFormatType(t) &&
+		!t.ConvertibleTo(reflect.TypeOf(time.Time{})) &&
+		!t.ConvertibleTo(reflect.TypeOf(types.Date{}))
+}
+
+// bindSplitPartsToDestinationStructArray binds each part, which holds an
+// unexploded object, to an element of the destination array of structs. The
+// properties and values of each object are unescaped once split up.
+func bindSplitPartsToDestinationStructArray(paramName string, parts []string, dest interface{},
+	unescape func(paramName string, value string) (string, error)) error {
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	newArray := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+	for i, p := range parts {
+		elem := newArray.Index(i)
+		if elem.Kind() == reflect.Ptr {
+			elem.Set(reflect.New(elem.Type().Elem()))
+			elem = elem.Elem()
+		}
+		fields := strings.Split(p, ",")
+		for j := range fields {
+			var err error
+			if fields[j], err = unescape(paramName, fields[j]); err != nil {
+				return err
+			}
+		}
+		err := bindSplitPartsToDestinationStruct(paramName, fields, false, elem.Addr().Interface())
 		if err != nil {
 			return fmt.Errorf("error setting array element: %w", err)
 		}
@@ -292,6 +579,37 @@ func bindSplitPartsToDestinationStruct(paramName string, parts []string, explode
 	return nil
 }
 
+// bindSplitPartsToHeaderObject binds the properties of a header object, split
+// up as for bindSplitPartsToDestinationStruct, to the fields of the struct
+// dest points to, converting each value to the type of its field, as for
+// exploded query objects:
+// (unexploded) X-Meta: role,admin,level,5
+// (exploded)   X-Meta: role=admin,level=5
+// Headers are often written with a space after each comma, so the optional
+// whitespace around properties is trimmed.
+func bindSplitPartsToHeaderObject(paramName string, parts []string, explode bool, dest interface{},
+	opts bindStringOptions) error {
+	values := make(url.Values, len(parts))
+	if explode {
+		for _, part := range parts {
+			key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+			if !found {
+				return fmt.Errorf("parameter '%s' has invalid exploded format", paramName)
+			}
+			values.Add(key, value)
+		}
+	} else {
+		if len(parts)%2 != 0 {
+			return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
+		}
+		for i := 0; i < len(parts); i += 2 {
+			values.Add(strings.TrimSpace(parts[i]), strings.TrimSpace(parts[i+1]))
+		}
+	}
+	_, err := bindParamsToExplodedObject(paramName, values, dest, opts)
+	return err
+}
+
 // BindQueryParameter works much like BindStyledParameter, however it takes a query argument
 // input array from the url package, since query arguments come through a
 // different path than the styled arguments. They're also exceptionally fussy.
@@ -306,8 +624,31 @@ func bindSplitPartsToDestinationStruct(paramName string, parts []string, explode
 // tell them apart. This code tries to fail, but the moral of the story is that
 // you shouldn't pass objects via form styled query arguments, just use
 // the Content parameter form.
+//
+// Optional parameters may also be bound to a types.Optional, whose Set field
+// tells whether the parameter was given, for this and the other query,
+// header and cookie binders.
+//
+// Destinations which are Validatable are validated once bound.
 func BindQueryParameter(style string, explode bool, required bool, paramName string,
-	queryParams url.Values, dest interface{}) error {
+	queryParams url.Values, dest interface{}) (err error) {
+	defer recoverPanic(&err, "error binding query parameter '%s'", paramName)
+	if o, ok := dest.(types.OptionalValue); ok {
+		return bindOptional(o, required, paramName, ParamLocationQuery, func(dest interface{}) error {
+			return BindQueryParameter(style, explode, false, paramName, queryParams, dest)
+		})
+	}
+	if err := checkDestination(paramName, dest, !required); err != nil {
+		return err
+	}
+	if err := bindQueryParameter(style, explode, required, paramName, queryParams, dest, bindStringOptions{}); err != nil {
+		return err
+	}
+	return validate(paramName, dest)
+}
+
+func bindQueryParameter(style string, explode bool, required bool, paramName string,
+	queryParams url.Values, dest interface{}, opts bindStringOptions) error {
 
 	// dv = destination value.
 	dv := reflect.Indirect(reflect.ValueOf(dest))
@@ -349,9 +690,15 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 	// This is the basic type of the destination object.
 	t := v.Type()
 	k := t.Kind()
+	if isBinderSlice(t) || isFormatType(t) {
+		// Bind it as a primitive.
+		k = reflect.Invalid
+	}
 
 	switch style {
-	case "form":
+	case "form", "spaceDelimited", "pipeDelimited":
+		// The delimited styles only differ from the form style in the
+		// delimiter of unexploded values.
 		var parts []string
 		if explode {
 			// ok, the explode case in query arguments is very, very annoying,
@@ -368,23 +715,28 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 
 				if !found {
 					if required {
-						return fmt.Errorf("query parameter '%s' is required", paramName)
+						return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
 					} else {
 						// If an optional parameter is not found, we do nothing,
 						return nil
 					}
 				}
-				err = bindSplitPartsToDestinationArray(values, output)
+				if isObjectDestination(t.Elem()) {
+					// Each value is an unexploded object: point=x,1,y,2&point=x,3,y,4
+					err = bindSplitPartsToDestinationStructArray(paramName, values, output, keepValue)
+				} else {
+					err = bindSplitPartsToDestinationArray(values, output, opts)
+				}
 			case reflect.Struct:
 				// This case is really annoying, and error prone, but the
 				// form style object binding doesn't tell us which arguments
 				// in the query string correspond to the object's fields. We'll
 				// try to bind field by field.
 				var fieldsPresent bool
-				fieldsPresent, err = bindParamsToExplodedObject(paramName, queryParams, output)
+				fieldsPresent, err = bindParamsToExplodedObject(paramName, queryParams, output, opts)
 				// If no fields were set, and there is no error, we will not fall
 				// through to assign the destination.
-				if !fieldsPresent {
+				if err == nil && !fieldsPresent {
 					return nil
 				}
 			default:
@@ -392,24 +744,16 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 				// unmarshal.
 				if len(values) == 0 {
 					if required {
-						return fmt.Errorf("query parameter '%s' is required", paramName)
+						return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
 					} else {
 						return nil
 					}
 				}
-				if len(values) != 1 {
-					return fmt.Errorf("multiple values for single value parameter '%s'", paramName)
-				}
-
-				if !found {
-					if required {
-						return fmt.Errorf("query parameter '%s' is required", paramName)
-					} else {
-						// If an optional parameter is not found, we do nothing,
-						return nil
-					}
+				var value string
+				if value, err = singleValue(paramName, values, opts.duplicatePolicy); err != nil {
+					return err
 				}
-				err = BindStringToObject(values[0], output)
+				err = bindStringToObject(value, output, opts)
 			}
 			if err != nil {
 				return err
@@ -424,26 +768,35 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 			values, found := queryParams[paramName]
 			if !found {
 				if required {
-					return fmt.Errorf("query parameter '%s' is required", paramName)
+					return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
 				} else {
 					return nil
 				}
 			}
-			if len(values) != 1 {
-				return fmt.Errorf("parameter '%s' is not exploded, but is specified multiple times", paramName)
+			value, err := singleValue(paramName, values, opts.duplicatePolicy)
+			if err != nil {
+				return err
 			}
-			parts = strings.Split(values[0], ",")
+			parts = strings.Split(value, styleDelimiter(style))
 		}
 		var err error
 		switch k {
 		case reflect.Slice:
-			err = bindSplitPartsToDestinationArray(parts, output)
+			if !isObjectDestination(t.Elem()) {
+				err = bindSplitPartsToDestinationArray(parts, output, opts)
+			} else if style != "form" {
+				// The objects are delimited by the style's delimiter, and
+				// their properties by commas: point=x,1,y,2|x,3,y,4
+				err = bindSplitPartsToDestinationStructArray(paramName, parts, output, keepValue)
+			} else {
+				return fmt.Errorf("parameter '%s' is an array of objects, which must be exploded", paramName)
+			}
 		case reflect.Struct:
 			err = bindSplitPartsToDestinationStruct(paramName, parts, explode, output)
 		default:
 			if len(parts) == 0 {
 				if required {
-					return fmt.Errorf("query parameter '%s' is required", paramName)
+					return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
 				} else {
 					return nil
 				}
@@ -451,7 +804,7 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 			if len(parts) != 1 {
 				return fmt.Errorf("multiple values for single value parameter '%s'", paramName)
 			}
-			err = BindStringToObject(parts[0], output)
+			err = bindStringToObject(parts[0], output, opts)
 		}
 		if err != nil {
 			return err
@@ -464,13 +817,497 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 		if !explode {
 			return errors.New("deepObjects must be exploded")
 		}
-		return UnmarshalDeepObject(dest, paramName, queryParams)
-	case "spaceDelimited", "pipeDelimited":
-		return fmt.Errorf("query arguments of style '%s' aren't yet supported", style)
+		d := &deepObjectDecoder{
+			opts: UnmarshalDeepObjectOptions{
+				LenientBool: opts.lenientBool,
+				TimeParsers: opts.timeParsers,
+			},
+			paramName: paramName,
+		}
+		return d.unmarshal(dest, queryParams)
+	default:
+		custom, found := lookupParamStyle(style)
+		if !found {
+			return fmt.Errorf("style '%s' on parameter '%s' is invalid", style, paramName)
+		}
+		values, found := queryParams[paramName]
+		if !found {
+			if required {
+				return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
+			}
+			return nil
+		}
+		value, err := singleValue(paramName, values, opts.duplicatePolicy)
+		if err != nil {
+			return err
+		}
+		err = custom.binder(paramName, value, output, BindStyledParameterOptions{
+			ParamLocation: ParamLocationQuery,
+			Explode:       explode,
+			Required:      required,
+			LenientBool:   opts.lenientBool,
+			TimeParsers:   opts.timeParsers,
+		})
+		if err != nil {
+			return err
+		}
+		if !required {
+			dv.Set(reflect.ValueOf(output))
+		}
+		return nil
+
+	}
+}
+
+// BindQueryParameterOptions defines optional arguments for
+// BindQueryParameterWithOptions.
+type BindQueryParameterOptions struct {
+	// Whether the parameter should use exploded structure
+	Explode bool
+	// Whether the parameter is required in the query
+	Required bool
+	// TimeParsers are the formats accepted for time.Time values, in place of
+	// the package's TimeParsers.
+	TimeParsers []TimeParser
+	// DuplicatePolicy decides what happens when a parameter which takes a
+	// single value is given more than once.
+	DuplicatePolicy DuplicateParamPolicy
+	// TrimSpace trims the whitespace surrounding each value of the query.
+	TrimSpace bool
+	// EmptyValue decides what happens when the parameter is given with an
+	// empty value, as in ?limit=, which the spec allows for query parameters
+	// with allowEmptyValue.
+	EmptyValue EmptyValuePolicy
+	// Default is bound when an optional parameter is absent from the query,
+	// instead of leaving the destination nil. It's given in the parameter's
+	// unexploded form, as it would appear after query unescaping, such as
+	// "3,4,5" for an array, or, for deepObject parameters, as a query string,
+	// such as "id[role]=admin&id[firstName]=Alex".
+	Default string
+}
+
+func (o BindQueryParameterOptions) bindStringOptions() bindStringOptions {
+	return bindStringOptions{
+		timeParsers:     o.TimeParsers,
+		duplicatePolicy: o.DuplicatePolicy,
+	}
+}
+
+// EmptyValuePolicy selects what query binding does when a parameter is
+// given with an empty value.
+type EmptyValuePolicy int
+
+const (
+	// EmptyValueBind binds empty values like any other, which fails for
+	// types which can't be empty, such as numbers.
+	EmptyValueBind EmptyValuePolicy = iota
+	// EmptyValueZero binds empty values as the zero value of the
+	// destination, allocating optional destinations.
+	EmptyValueZero
+	// EmptyValueUnset treats empty values as though the parameter were
+	// absent, so that optional destinations are left nil, or take their
+	// default, and required ones fail.
+	EmptyValueUnset
+	// EmptyValueError fails on empty values, as for parameters which don't
+	// set allowEmptyValue.
+	EmptyValueError
+)
+
+// DuplicateParamPolicy selects what query binding does when a parameter
+// which takes a single value, such as a primitive, an unexploded array or
+// object, or a property of an exploded object, is given more than once.
+type DuplicateParamPolicy int
+
+const (
+	// DuplicateParamReject fails with a *DuplicateParamError.
+	DuplicateParamReject DuplicateParamPolicy = iota
+	// DuplicateParamFirstWins keeps the first value.
+	DuplicateParamFirstWins
+	// DuplicateParamLastWins keeps the last value.
+	DuplicateParamLastWins
+)
+
+// DuplicateParamError is returned when a parameter which takes a single
+// value is given more than once under the DuplicateParamReject policy.
+type DuplicateParamError struct {
+	ParamName string
+	// Values are all the values given for the parameter.
+	Values []string
+}
+
+func (e *DuplicateParamError) Error() string {
+	return fmt.Sprintf("multiple values for single value parameter '%s'", e.ParamName)
+}
+
+// singleValue picks the value of a parameter which takes a single one from
+// its non-empty values, following policy.
+func singleValue(paramName string, values []string, policy DuplicateParamPolicy) (string, error) {
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	switch policy {
+	case DuplicateParamFirstWins:
+		return values[0], nil
+	case DuplicateParamLastWins:
+		return values[len(values)-1], nil
 	default:
-		return fmt.Errorf("style '%s' on parameter '%s' is invalid", style, paramName)
+		return "", &DuplicateParamError{ParamName: paramName, Values: values}
+	}
+}
+
+// BindQueryParameterWithOptions works like BindQueryParameter, taking its
+// optional arguments from opts.
+func BindQueryParameterWithOptions(style string, paramName string, queryParams url.Values,
+	dest interface{}, opts BindQueryParameterOptions) (err error) {
+	defer recoverPanic(&err, "error binding query parameter '%s'", paramName)
+	if o, ok := dest.(types.OptionalValue); ok {
+		optional := opts
+		optional.Required = false
+		return bindOptional(o, opts.Required, paramName, ParamLocationQuery, func(dest interface{}) error {
+			return BindQueryParameterWithOptions(style, paramName, queryParams, dest, optional)
+		})
+	}
+	if err := checkDestination(paramName, dest, !opts.Required); err != nil {
+		return err
+	}
+	if opts.TrimSpace {
+		trimmed := make(url.Values, len(queryParams))
+		for name, values := range queryParams {
+			trimmed[name] = make([]string, len(values))
+			for i, value := range values {
+				trimmed[name][i] = strings.TrimSpace(value)
+			}
+		}
+		queryParams = trimmed
+	}
+
+	if values := queryParams[paramName]; len(values) == 1 && values[0] == "" {
+		switch opts.EmptyValue {
+		case EmptyValueZero:
+			dv := reflect.Indirect(reflect.ValueOf(dest))
+			if opts.Required {
+				dv.Set(reflect.Zero(dv.Type()))
+			} else {
+				dv.Set(reflect.New(dv.Type().Elem()))
+			}
+			return validate(paramName, dest)
+		case EmptyValueUnset:
+			unset := make(url.Values, len(queryParams))
+			for name, values := range queryParams {
+				if name != paramName {
+					unset[name] = values
+				}
+			}
+			queryParams = unset
+		case EmptyValueError:
+			return fmt.Errorf("query parameter '%s' can't be empty", paramName)
+		}
+	}
+
+	useDefault := !opts.Required && opts.Default != ""
+
+	// deepObject destinations are allocated even when none of their keys are
+	// present, so absence is checked up front.
+	if style == "deepObject" {
+		if useDefault && !hasDeepObjectParam(queryParams, paramName) {
+			defaults, err := url.ParseQuery(opts.Default)
+			if err != nil {
+				return fmt.Errorf("error parsing default of parameter '%s': %w", paramName, err)
+			}
+			queryParams = defaults
+		}
+		if err := bindQueryParameter(style, opts.Explode, opts.Required, paramName, queryParams, dest, opts.bindStringOptions()); err != nil {
+			return err
+		}
+		return validate(paramName, dest)
+	}
+
+	err = bindQueryParameter(style, opts.Explode, opts.Required, paramName, queryParams, dest, opts.bindStringOptions())
+	if err != nil {
+		return err
+	}
 
+	// Optional destinations are left nil when the parameter is absent.
+	dv := reflect.Indirect(reflect.ValueOf(dest))
+	if !useDefault || dv.Kind() != reflect.Ptr || !dv.IsNil() {
+		return validate(paramName, dest)
 	}
+
+	// The built in styles all accept the unexploded form, which keeps
+	// objects together under the parameter name.
+	explode := opts.Explode
+	if builtinParamStyles[style] {
+		explode = false
+	}
+	defaults := url.Values{paramName: []string{opts.Default}}
+	if err := bindQueryParameter(style, explode, false, paramName, defaults, dest, opts.bindStringOptions()); err != nil {
+		return fmt.Errorf("error binding default of parameter '%s': %w", paramName, err)
+	}
+	return validate(paramName, dest)
+}
+
+// FreeFormQueryOptions defines optional arguments for BindFreeFormQuery.
+type FreeFormQueryOptions struct {
+	// Prefix restricts binding to the parameters whose names start with it,
+	// such as "filter.", and is trimmed from the keys of the destination.
+	Prefix string
+	// Exclude lists the names of parameters which are bound by other means,
+	// and are left out of the destination.
+	Exclude []string
+}
+
+// BindFreeFormQuery binds the query parameters which aren't otherwise
+// declared, such as arbitrary filters, into dest, which must be a pointer to
+// a map with string keys. Maps of slices, such as map[string][]string,
+// receive every value of a parameter, while other maps take a single one,
+// and fail when a parameter is repeated. The destination is left alone when
+// there are no parameters to bind, and, like the other binders, a pointer to
+// a pointer to a map is allocated as needed.
+func BindFreeFormQuery(queryParams url.Values, dest interface{}, opts FreeFormQueryOptions) (err error) {
+	defer recoverPanic(&err, "error binding free form query parameters")
+	if err := checkDestination("", dest, false); err != nil {
+		return err
+	}
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	t := v.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+		return fmt.Errorf("free form query parameters can't be bound to %s", t)
+	}
+
+	excluded := make(map[string]bool, len(opts.Exclude))
+	for _, name := range opts.Exclude {
+		excluded[name] = true
+	}
+
+	m := reflect.MakeMap(t)
+	for name, values := range queryParams {
+		key, found := strings.CutPrefix(name, opts.Prefix)
+		if !found || excluded[name] {
+			continue
+		}
+		elem := reflect.New(t.Elem())
+		if t.Elem().Kind() == reflect.Interface && t.Elem().NumMethod() == 0 {
+			// Free-form values are kept as strings, or slices of them when
+			// they're repeated.
+			if len(values) == 1 {
+				elem.Elem().Set(reflect.ValueOf(values[0]))
+			} else {
+				elem.Elem().Set(reflect.ValueOf(values))
+			}
+		} else if t.Elem().Kind() == reflect.Slice {
+			if err := bindSplitPartsToDestinationArray(values, elem.Interface(), bindStringOptions{}); err != nil {
+				return fmt.Errorf("error binding query parameter '%s': %w", name, err)
+			}
+		} else {
+			if len(values) != 1 {
+				return fmt.Errorf("multiple values for single value parameter '%s'", name)
+			}
+			if err := BindStringToObject(values[0], elem.Interface()); err != nil {
+				return fmt.Errorf("error binding query parameter '%s': %w", name, err)
+			}
+		}
+		m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem.Elem())
+	}
+	if m.Len() == 0 {
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(t)
+		ptr.Elem().Set(m)
+		m = ptr
+	}
+	v.Set(m)
+	return validate("", dest)
+}
+
+// hasDeepObjectParam reports whether any of the query keys belong to the
+// deepObject parameter paramName.
+func hasDeepObjectParam(queryParams url.Values, paramName string) bool {
+	for key := range queryParams {
+		if path, err := deepObjectPath(key, paramName); path != nil || err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// BindCookieParameter binds a cookie parameter of the request, which must
+// use the form style, the only one allowed for cookies. Like
+// BindQueryParameter, it expects a pointer to the destination, and, when the
+// parameter isn't required, a pointer to a pointer, which is left alone if
+// the cookie is absent. Values are percent-decoded, as they're encoded by
+// StyleParamWithLocation; values which aren't valid percent-encoding are
+// taken verbatim.
+// (unexploded) Cookie: id=3,4,5
+// (exploded)   Cookie: id=3; id=4; id=5
+// (exploded)   Cookie: role=admin; firstName=Alex
+func BindCookieParameter(style string, explode bool, required bool, paramName string,
+	r *http.Request, dest interface{}) (err error) {
+	defer recoverPanic(&err, "error binding cookie parameter '%s'", paramName)
+	if o, ok := dest.(types.OptionalValue); ok {
+		return bindOptional(o, required, paramName, ParamLocationCookie, func(dest interface{}) error {
+			return BindCookieParameter(style, explode, false, paramName, r, dest)
+		})
+	}
+	if err := checkDestination(paramName, dest, !required); err != nil {
+		return err
+	}
+	if style != "form" {
+		return fmt.Errorf("style '%s' on cookie parameter '%s' is invalid", style, paramName)
+	}
+
+	values := make(url.Values)
+	for _, c := range r.Cookies() {
+		values.Add(c.Name, c.Value)
+	}
+
+	if explode {
+		// Each cookie holds a whole value, so there are no delimiters to
+		// worry about, and we can unescape them up front.
+		for _, vs := range values {
+			for i, v := range vs {
+				if unescaped, err := url.PathUnescape(v); err == nil {
+					vs[i] = unescaped
+				}
+			}
+		}
+		err := BindQueryParameter(style, explode, required, paramName, values, dest)
+		var requiredErr *RequiredParamError
+		if errors.As(err, &requiredErr) {
+			requiredErr.Location = ParamLocationCookie
+		}
+		return err
+	}
+
+	cookieValues, found := values[paramName]
+	if !found {
+		if required {
+			return &RequiredParamError{ParamName: paramName, Location: ParamLocationCookie}
+		}
+		return nil
+	}
+	if len(cookieValues) != 1 {
+		return fmt.Errorf("parameter '%s' is not exploded, but is specified multiple times", paramName)
+	}
+
+	// The unexploded value is split up before it's unescaped, so that escaped
+	// commas are kept in the values.
+	opts := BindStyledParameterOptions{
+		ParamLocation: ParamLocationCookie,
+		Required:      required,
+	}
+	if required {
+		return BindStyledParameterWithOptions(style, paramName, cookieValues[0], dest, opts)
+	}
+	dv := reflect.Indirect(reflect.ValueOf(dest))
+	output := reflect.New(dv.Type().Elem())
+	if err := BindStyledParameterWithOptions(style, paramName, cookieValues[0], output.Interface(), opts); err != nil {
+		return err
+	}
+	dv.Set(output)
+	return nil
+}
+
+// BindHeaderParameterOptions defines optional arguments for
+// BindHeaderParameter.
+type BindHeaderParameterOptions struct {
+	// Whether the parameter should use exploded structure
+	Explode bool
+	// Whether the parameter is required in the request
+	Required bool
+	// DecodeMIMEWords decodes MIME encoded-words in the value, as described
+	// by RFC 2047.
+	DecodeMIMEWords bool
+	// DecodePercent decodes the percent-encoding StyleParamWithOptions
+	// applies to header values, as BindStyledParameterOptions.DecodePercent
+	// does. Without it, the value is bound as it was sent.
+	DecodePercent bool
+	// CaseInsensitive matches the parameter name against header names
+	// regardless of case, for headers which weren't canonicalized when they
+	// were set, such as by assigning to the http.Header map directly.
+	CaseInsensitive bool
+	// Default is bound when an optional header is absent, instead of
+	// leaving the destination nil. It's given as the header's value would
+	// be, such as "3,4,5" for an array.
+	Default string
+}
+
+// BindHeaderParameter binds the header parameter paramName, which is looked
+// up by its canonical name, as http.Header.Get does. A header which is given
+// more than once is merged into a single comma separated list, as RFC 9110
+// allows, when it's bound to an array or an object; otherwise, it's an
+// error. As with BindQueryParameter, optional parameters are passed as a
+// pointer to a pointer, which is left alone when the header is absent.
+func BindHeaderParameter(style string, paramName string, header http.Header, dest interface{},
+	opts BindHeaderParameterOptions) (err error) {
+	defer recoverPanic(&err, "error binding header parameter '%s'", paramName)
+	if o, ok := dest.(types.OptionalValue); ok {
+		optional := opts
+		optional.Required = false
+		return bindOptional(o, opts.Required, paramName, ParamLocationHeader, func(dest interface{}) error {
+			return BindHeaderParameter(style, paramName, header, dest, optional)
+		})
+	}
+	if err := checkDestination(paramName, dest, !opts.Required); err != nil {
+		return err
+	}
+	values := header.Values(paramName)
+	if len(values) == 0 && opts.CaseInsensitive {
+		names := make([]string, 0, len(header))
+		for name := range header {
+			if strings.EqualFold(name, paramName) {
+				names = append(names, name)
+			}
+		}
+		// Headers which differ only in case are merged in a stable order.
+		sort.Strings(names)
+		for _, name := range names {
+			values = append(values, header[name]...)
+		}
+	}
+	if len(values) == 0 {
+		if opts.Required {
+			return &RequiredParamError{ParamName: paramName, Location: ParamLocationHeader}
+		}
+		if opts.Default == "" {
+			return nil
+		}
+		values = []string{opts.Default}
+	}
+
+	value := values[0]
+	if len(values) > 1 {
+		t := reflect.Indirect(reflect.ValueOf(dest)).Type()
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Map && !isObjectDestination(t) {
+			return fmt.Errorf("header parameter '%s' is specified multiple times", paramName)
+		}
+		value = strings.Join(values, ",")
+	}
+
+	styledOpts := BindStyledParameterOptions{
+		ParamLocation:   ParamLocationHeader,
+		Explode:         opts.Explode,
+		Required:        opts.Required,
+		DecodeMIMEWords: opts.DecodeMIMEWords,
+		DecodePercent:   opts.DecodePercent,
+	}
+	if opts.Required {
+		return BindStyledParameterWithOptions(style, paramName, value, dest, styledOpts)
+	}
+	dv := reflect.Indirect(reflect.ValueOf(dest))
+	output := reflect.New(dv.Type().Elem())
+	if err := BindStyledParameterWithOptions(style, paramName, value, output.Interface(), styledOpts); err != nil {
+		return err
+	}
+	dv.Set(output)
+	return nil
 }
 
 // bindParamsToExplodedObject reflects the destination structure, and pulls the value for
@@ -480,7 +1317,7 @@ func BindQueryParameter(style string, explode bool, required bool, paramName str
 // set its value. This function returns a boolean, telling us whether there was
 // anything to bind. There will be nothing to bind if a parameter isn't found by name,
 // or none of an exploded object's fields are present.
-func bindParamsToExplodedObject(paramName string, values url.Values, dest interface{}) (bool, error) {
+func bindParamsToExplodedObject(paramName string, values url.Values, dest interface{}, opts bindStringOptions) (bool, error) {
 	// Dereference pointers to their destination values
 	binder, v, t := indirect(dest)
 	if binder != nil {
@@ -488,7 +1325,7 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 		if !found {
 			return false, nil
 		}
-		return true, BindStringToObject(values.Get(paramName), dest)
+		return true, bindStringToObject(values.Get(paramName), dest, opts)
 	}
 	if t.Kind() != reflect.Struct {
 		return false, fmt.Errorf("unmarshaling query arg '%s' into wrong type", paramName)
@@ -503,27 +1340,21 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 			continue
 		}
 
-		// Find the json annotation on the field, and use the json specified
-		// name if available, otherwise, just the field name.
-		tag := fieldT.Tag.Get("json")
-		fieldName := fieldT.Name
-		if tag != "" {
-			tagParts := strings.Split(tag, ",")
-			name := tagParts[0]
-			if name != "" {
-				fieldName = name
-			}
-		}
-
 		// At this point, we look up field name in the parameter list.
+		fieldName := jsonFieldName(fieldT)
 		fieldVal, found := values[fieldName]
 		if found {
-			if len(fieldVal) != 1 {
-				return false, fmt.Errorf("field '%s' specified multiple times for param '%s'", fieldName, paramName)
+			value, err := singleValue(fieldName, fieldVal, opts.duplicatePolicy)
+			if err != nil {
+				return false, err
+			}
+			err = bindStringToObject(value, v.Field(i).Addr().Interface(), opts)
+			var overflowErr *OverflowError
+			if errors.As(err, &overflowErr) {
+				overflowErr.Field = fieldName
 			}
-			err := BindStringToObject(fieldVal[0], v.Field(i).Addr().Interface())
 			if err != nil {
-				return false, fmt.Errorf("could not bind query arg '%s' to request object: %s'", paramName, err)
+				return false, fmt.Errorf("could not bind query arg '%s' to request object: %w'", paramName, err)
 			}
 			fieldsPresent = true
 		}
@@ -531,6 +1362,15 @@ func bindParamsToExplodedObject(paramName string, values url.Values, dest interf
 	return fieldsPresent, nil
 }
 
+// jsonFieldName returns the name of a field in its json tag if it has one,
+// otherwise, just the field name.
+func jsonFieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
 // indirect
 func indirect(dest interface{}) (interface{}, reflect.Value, reflect.Type) {
 	v := reflect.ValueOf(dest)
@@ -538,9 +1378,20 @@ func indirect(dest interface{}) (interface{}, reflect.Value, reflect.Type) {
 		if u, ok := v.Interface().(Binder); ok {
 			return u, reflect.Value{}, nil
 		}
+		if _, ok := v.Interface().(sql.Scanner); ok {
+			return dest, reflect.Value{}, nil
+		}
+		// As for isObjectDestination, structs which unmarshal themselves
+		// from text, such as types.Duration, are primitives.
+		if _, ok := v.Interface().(encoding.TextUnmarshaler); ok {
+			return dest, reflect.Value{}, nil
+		}
 	}
 	v = reflect.Indirect(v)
 	t := v.Type()
+	if isFormatType(t) {
+		return dest, reflect.Value{}, nil
+	}
 	// special handling for custom types which might look like an object. We
 	// don't want to use object binding on them, but rather treat them as
 	// primitive types. time.Time{} is a unique case since we can't add a Binder
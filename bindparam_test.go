@@ -15,9 +15,14 @@ package runtime
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -335,6 +340,42 @@ func TestBindQueryParameter(t *testing.T) {
 		assert.Equal(t, expected, birthday)
 	})
 
+	t.Run("delimited objects", func(t *testing.T) {
+		type Color struct {
+			R string `json:"R"`
+			G string `json:"G"`
+		}
+		expected := Color{R: "100", G: "200"}
+
+		var space Color
+		err := BindQueryParameter("spaceDelimited", false, true, "color", url.Values{"color": {"R 100 G 200"}}, &space)
+		require.NoError(t, err)
+		assert.Equal(t, expected, space)
+
+		var pipe *Color
+		err = BindQueryParameter("pipeDelimited", false, false, "color", url.Values{"color": {"R|100|G|200"}}, &pipe)
+		require.NoError(t, err)
+		assert.Equal(t, &expected, pipe)
+
+		var exploded Color
+		err = BindQueryParameter("pipeDelimited", true, true, "color", url.Values{"R": {"100"}, "G": {"200"}}, &exploded)
+		require.NoError(t, err)
+		assert.Equal(t, expected, exploded)
+
+		var ids []int
+		err = BindQueryParameter("pipeDelimited", false, true, "ids", url.Values{"ids": {"3|4|5"}}, &ids)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 4, 5}, ids)
+
+		var styled Color
+		err = BindStyledParameterWithOptions("spaceDelimited", "color", "color=R%20100%20G%20200", &styled, BindStyledParameterOptions{
+			ParamLocation: ParamLocationQuery,
+			Required:      true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, styled)
+	})
+
 	t.Run("optional", func(t *testing.T) {
 		queryParams := url.Values{
 			"time":   {"2020-12-09T16:09:53+00:00"},
@@ -446,14 +487,14 @@ func TestBindParamsToExplodedObject(t *testing.T) {
 	}
 
 	var dstTime time.Time
-	fieldsPresent, err := bindParamsToExplodedObject("time", values, &dstTime)
+	fieldsPresent, err := bindParamsToExplodedObject("time", values, &dstTime, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, now, dstTime)
 
 	type AliasedTime time.Time
 	var aDstTime AliasedTime
-	fieldsPresent, err = bindParamsToExplodedObject("time", values, &aDstTime)
+	fieldsPresent, err = bindParamsToExplodedObject("time", values, &aDstTime, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, now, aDstTime)
@@ -461,19 +502,19 @@ func TestBindParamsToExplodedObject(t *testing.T) {
 	expectedDate := MockBinder{Time: time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC)}
 
 	var dstDate MockBinder
-	fieldsPresent, err = bindParamsToExplodedObject("date", values, &dstDate)
+	fieldsPresent, err = bindParamsToExplodedObject("date", values, &dstDate, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, expectedDate, dstDate)
 
 	var eDstDate EmbeddedMockBinder
-	fieldsPresent, err = bindParamsToExplodedObject("date", values, &eDstDate)
+	fieldsPresent, err = bindParamsToExplodedObject("date", values, &eDstDate, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, expectedDate, dstDate)
 
 	var nTDstDate AnotherMockBinder
-	fieldsPresent, err = bindParamsToExplodedObject("date", values, &nTDstDate)
+	fieldsPresent, err = bindParamsToExplodedObject("date", values, &nTDstDate, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, expectedDate, nTDstDate)
@@ -483,7 +524,7 @@ func TestBindParamsToExplodedObject(t *testing.T) {
 	}
 
 	var optDstTime ObjectWithOptional
-	fieldsPresent, err = bindParamsToExplodedObject("explodedObject", values, &optDstTime)
+	fieldsPresent, err = bindParamsToExplodedObject("explodedObject", values, &optDstTime, bindStringOptions{})
 	assert.NoError(t, err)
 	assert.True(t, fieldsPresent)
 	assert.EqualValues(t, &now, optDstTime.Time)
@@ -502,3 +543,822 @@ func TestBindStyledParameterWithLocation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, *expectedBig, dstBigNumber)
 }
+
+func TestBindStyledParameterLenientBool(t *testing.T) {
+	var dst bool
+	err := BindStyledParameterWithOptions("simple", "flag", "on", &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	})
+	assert.Error(t, err)
+
+	err = BindStyledParameterWithOptions("simple", "flag", "on", &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+		LenientBool:   true,
+	})
+	require.NoError(t, err)
+	assert.True(t, dst)
+
+	var dstSlice []bool
+	err = BindStyledParameterWithOptions("simple", "flags", "yes,no,1,off", &dstSlice, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+		LenientBool:   true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, false}, dstSlice)
+}
+
+func TestBindStyledParameterMatrixObjectArray(t *testing.T) {
+	type Item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	items := []Item{{ID: "1", Name: "a b"}, {ID: "2", Name: "c"}}
+
+	styled, err := StyleParamWithLocation("matrix", true, "item", ParamLocationPath, items)
+	require.NoError(t, err)
+	assert.Equal(t, ";item=id,1,name,a%20b;item=id,2,name,c", styled)
+
+	var dst []Item
+	err = BindStyledParameterWithOptions("matrix", "item", styled, &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, items, dst)
+
+	var dstPtrs []*Item
+	err = BindStyledParameterWithOptions("matrix", "item", styled, &dstPtrs, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*Item{&items[0], &items[1]}, dstPtrs)
+
+	err = BindStyledParameterWithOptions("matrix", "item", ";item=id,1,name", &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+		Required:      true,
+	})
+	assert.Error(t, err)
+
+	// Arrays of primitives are unaffected.
+	var ids []int
+	err = BindStyledParameterWithOptions("matrix", "id", ";id=3;id=4", &ids, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, ids)
+}
+
+func TestBindStyledParameterEscapedDelimiters(t *testing.T) {
+	values := []string{"a,b", "c;d", "e"}
+
+	styled, err := StyleParamWithLocation("simple", false, "ids", ParamLocationPath, values)
+	require.NoError(t, err)
+	assert.Equal(t, "a%2Cb,c%3Bd,e", styled)
+
+	var dst []string
+	err = BindStyledParameterWithOptions("simple", "ids", styled, &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, values, dst)
+
+	styled, err = StyleParamWithLocation("matrix", true, "ids", ParamLocationPath, values)
+	require.NoError(t, err)
+	assert.Equal(t, ";ids=a%2Cb;ids=c%3Bd;ids=e", styled)
+
+	err = BindStyledParameterWithOptions("matrix", "ids", styled, &dst, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, values, dst)
+
+	type Object struct {
+		Name string `json:"name"`
+	}
+	var object Object
+	err = BindStyledParameterWithOptions("simple", "object", "name,a%2Cb", &object, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Object{Name: "a,b"}, object)
+}
+
+func TestBindStyledParameterMap(t *testing.T) {
+	tests := []struct {
+		style   string
+		explode bool
+		styled  string
+	}{
+		{"simple", false, "a%20b,1,c,2"},
+		{"simple", true, "a%20b=1,c=2"},
+		{"label", false, ".a%20b,1,c,2"},
+		{"label", true, ".a%20b=1.c=2"},
+		{"matrix", false, ";m=a%20b,1,c,2"},
+		{"matrix", true, ";a%20b=1;c=2"},
+	}
+	src := map[string]int{"a b": 1, "c": 2}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%t", tt.style, tt.explode), func(t *testing.T) {
+			styled, err := StyleParamWithLocation(tt.style, tt.explode, "m", ParamLocationPath, src)
+			require.NoError(t, err)
+			assert.Equal(t, tt.styled, styled)
+
+			var dst map[string]int
+			err = BindStyledParameterWithOptions(tt.style, "m", styled, &dst, BindStyledParameterOptions{
+				ParamLocation: ParamLocationPath,
+				Explode:       tt.explode,
+				Required:      true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, src, dst)
+		})
+	}
+
+	var free map[string]interface{}
+	err := BindStyledParameterWithOptions("label", "m", ".x=1.y=z", &free, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"x": "1", "y": "z"}, free)
+
+	var ints map[int]bool
+	err = BindStyledParameterWithOptions("label", "m", ".1=true.2=false", &ints, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]bool{1: true, 2: false}, ints)
+
+	err = BindStyledParameterWithOptions("label", "m", ".x=a.y", &free, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Explode:       true,
+	})
+	assert.Error(t, err)
+}
+
+func TestBindStyledParameterHeader(t *testing.T) {
+	opts := BindStyledParameterOptions{ParamLocation: ParamLocationHeader, Required: true, DecodePercent: true}
+
+	var name string
+	require.NoError(t, BindStyledParameterWithOptions("simple", "X-Name", "Zo%C3%AB %22Z%22%2C 50%25", &name, opts))
+	assert.Equal(t, "Zoë \"Z\", 50%", name)
+
+	// Values from clients which don't encode them are taken verbatim.
+	require.NoError(t, BindStyledParameterWithOptions("simple", "X-Name", "100%", &name, opts))
+	assert.Equal(t, "100%", name)
+
+	var names []string
+	require.NoError(t, BindStyledParameterWithOptions("simple", "X-Names", "a%2Cb,c", &names, opts))
+	assert.Equal(t, []string{"a,b", "c"}, names)
+
+	styled, err := StyleParamWithLocation("simple", false, "X-Names", ParamLocationHeader, []string{" x ", "é,\""})
+	require.NoError(t, err)
+	require.NoError(t, BindStyledParameterWithOptions("simple", "X-Names", styled, &names, opts))
+	assert.Equal(t, []string{" x ", "é,\""}, names)
+}
+
+func TestBindCookieParameter(t *testing.T) {
+	type Object struct {
+		Role      string `json:"role"`
+		FirstName string `json:"firstName"`
+	}
+	newRequest := func(cookies ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		for _, c := range cookies {
+			r.Header.Add("Cookie", c)
+		}
+		return r
+	}
+
+	t.Run("primitive", func(t *testing.T) {
+		var id int
+		require.NoError(t, BindCookieParameter("form", false, true, "id", newRequest("id=5"), &id))
+		assert.Equal(t, 5, id)
+
+		var name string
+		styled, err := StyleParamWithLocation("simple", false, "name", ParamLocationCookie, "a b;c,d\"é%")
+		require.NoError(t, err)
+		require.NoError(t, BindCookieParameter("form", false, true, "name", newRequest("name="+styled), &name))
+		assert.Equal(t, "a b;c,d\"é%", name)
+
+		// Values which aren't percent-encoded are taken verbatim.
+		require.NoError(t, BindCookieParameter("form", false, true, "name", newRequest("name=100%"), &name))
+		assert.Equal(t, "100%", name)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		var ids []string
+		require.NoError(t, BindCookieParameter("form", false, true, "ids", newRequest("ids=3,4%2C5"), &ids))
+		assert.Equal(t, []string{"3", "4,5"}, ids)
+
+		var exploded []int
+		require.NoError(t, BindCookieParameter("form", true, true, "ids", newRequest("ids=3; ids=4", "ids=5"), &exploded))
+		assert.Equal(t, []int{3, 4, 5}, exploded)
+	})
+
+	t.Run("object", func(t *testing.T) {
+		expected := Object{Role: "admin", FirstName: "Alex Smith"}
+
+		var object Object
+		require.NoError(t, BindCookieParameter("form", false, true, "id",
+			newRequest("id=role,admin,firstName,Alex%20Smith"), &object))
+		assert.Equal(t, expected, object)
+
+		var exploded Object
+		styled, err := StyleParamWithLocation("form", true, "id", ParamLocationCookie, expected)
+		require.NoError(t, err)
+		require.NoError(t, BindCookieParameter("form", true, true, "id", newRequest(styled), &exploded))
+		assert.Equal(t, expected, exploded)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		var id *int
+		require.NoError(t, BindCookieParameter("form", false, false, "id", newRequest("other=1"), &id))
+		assert.Nil(t, id)
+
+		require.NoError(t, BindCookieParameter("form", false, false, "id", newRequest("id=7"), &id))
+		require.NotNil(t, id)
+		assert.Equal(t, 7, *id)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var id int
+		assert.Error(t, BindCookieParameter("form", false, true, "id", newRequest(), &id))
+		assert.Error(t, BindCookieParameter("form", false, true, "id", newRequest("id=1; id=2"), &id))
+		assert.Error(t, BindCookieParameter("simple", false, true, "id", newRequest("id=1"), &id))
+	})
+}
+
+func TestBindParameterDefaults(t *testing.T) {
+	type Object struct {
+		Role      string `json:"role"`
+		FirstName string `json:"firstName"`
+	}
+
+	t.Run("styled", func(t *testing.T) {
+		opts := BindStyledParameterOptions{ParamLocation: ParamLocationHeader, Default: "3,4"}
+		var ids []int
+		require.NoError(t, BindStyledParameterWithOptions("simple", "X-Ids", "", &ids, opts))
+		assert.Equal(t, []int{3, 4}, ids)
+
+		require.NoError(t, BindStyledParameterWithOptions("simple", "X-Ids", "5", &ids, opts))
+		assert.Equal(t, []int{5}, ids)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		var limit *int
+		opts := BindQueryParameterOptions{Explode: true, Default: "10"}
+		require.NoError(t, BindQueryParameterWithOptions("form", "limit", url.Values{}, &limit, opts))
+		require.NotNil(t, limit)
+		assert.Equal(t, 10, *limit)
+
+		limit = nil
+		require.NoError(t, BindQueryParameterWithOptions("form", "limit", url.Values{"limit": {"0"}}, &limit, opts))
+		require.NotNil(t, limit)
+		assert.Equal(t, 0, *limit)
+
+		var ids *[]int
+		opts.Default = "1 2"
+		require.NoError(t, BindQueryParameterWithOptions("spaceDelimited", "ids", url.Values{}, &ids, opts))
+		assert.Equal(t, &[]int{1, 2}, ids)
+
+		// Exploded objects take their default in the unexploded form.
+		var obj *Object
+		opts.Default = "role,admin,firstName,Alex"
+		require.NoError(t, BindQueryParameterWithOptions("form", "id", url.Values{"other": {"x"}}, &obj, opts))
+		assert.Equal(t, &Object{Role: "admin", FirstName: "Alex"}, obj)
+
+		obj = nil
+		opts.Default = "id[role]=admin&id[firstName]=Alex"
+		require.NoError(t, BindQueryParameterWithOptions("deepObject", "id", url.Values{}, &obj, opts))
+		assert.Equal(t, &Object{Role: "admin", FirstName: "Alex"}, obj)
+
+		limit = nil
+		opts.Default = "ten"
+		assert.ErrorContains(t, BindQueryParameterWithOptions("form", "limit", url.Values{}, &limit, opts),
+			"error binding default of parameter 'limit'")
+
+		// Defaults don't apply to required parameters.
+		var required int
+		opts = BindQueryParameterOptions{Required: true, Default: "10"}
+		assert.Error(t, BindQueryParameterWithOptions("form", "limit", url.Values{}, &required, opts))
+	})
+
+	t.Run("header", func(t *testing.T) {
+		var limit *int
+		opts := BindHeaderParameterOptions{Default: "25"}
+		require.NoError(t, BindHeaderParameter("simple", "X-Limit", http.Header{}, &limit, opts))
+		require.NotNil(t, limit)
+		assert.Equal(t, 25, *limit)
+
+		limit = nil
+		require.NoError(t, BindHeaderParameter("simple", "X-Limit", http.Header{"X-Limit": {"0"}}, &limit, opts))
+		assert.Equal(t, 0, *limit)
+
+		var ids *[]int
+		var b Bindings
+		b.HeaderWithOptions("simple", "X-Ids", http.Header{}, &ids, BindHeaderParameterOptions{Default: "3,4"})
+		require.NoError(t, b.Err())
+		assert.Equal(t, &[]int{3, 4}, ids)
+
+		// Defaults don't apply to required headers.
+		var required int
+		opts.Required = true
+		assert.Error(t, BindHeaderParameter("simple", "X-Limit", http.Header{}, &required, opts))
+	})
+}
+
+func TestRequiredParamError(t *testing.T) {
+	assertRequired := func(t *testing.T, err error, location ParamLocation, message string) {
+		t.Helper()
+		var requiredErr *RequiredParamError
+		require.ErrorAs(t, err, &requiredErr)
+		assert.Equal(t, "id", requiredErr.ParamName)
+		assert.Equal(t, location, requiredErr.Location)
+		assert.EqualError(t, err, message)
+	}
+
+	var id int
+	var ids []int
+	assertRequired(t, BindQueryParameter("form", true, true, "id", url.Values{}, &id),
+		ParamLocationQuery, "query parameter 'id' is required")
+	assertRequired(t, BindQueryParameter("pipeDelimited", false, true, "id", url.Values{}, &ids),
+		ParamLocationQuery, "query parameter 'id' is required")
+	assertRequired(t, BindJSONQueryParam("id", true, url.Values{}, &id),
+		ParamLocationQuery, "query parameter 'id' is required")
+	assertRequired(t, BindStyledParameterWithOptions("simple", "id", "", &id,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath, Required: true}),
+		ParamLocationPath, "path parameter 'id' is required")
+	assertRequired(t, BindStyledParameter("simple", false, "id", "", &id),
+		ParamLocationUndefined, "parameter 'id' is required")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	assertRequired(t, BindCookieParameter("form", true, true, "id", r, &id),
+		ParamLocationCookie, "cookie parameter 'id' is required")
+	assertRequired(t, BindCookieParameter("form", false, true, "id", r, &id),
+		ParamLocationCookie, "cookie parameter 'id' is required")
+
+	// Malformed parameters aren't reported as missing.
+	var requiredErr *RequiredParamError
+	err := BindQueryParameter("form", true, true, "id", url.Values{"id": {"x"}}, &id)
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &requiredErr))
+}
+
+func TestBindQueryParameterObjectArray(t *testing.T) {
+	type Point struct {
+		X int `json:"x,string"`
+		Y int `json:"y,string"`
+	}
+	expected := []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}
+
+	exploded := url.Values{"point": {"x,1,y,2", "x,3,y,4"}}
+	for _, style := range []string{"form", "spaceDelimited", "pipeDelimited"} {
+		var points []Point
+		require.NoError(t, BindQueryParameter(style, true, true, "point", exploded, &points), style)
+		assert.Equal(t, expected, points, style)
+	}
+
+	var optional *[]*Point
+	require.NoError(t, BindQueryParameter("form", true, false, "point", exploded, &optional))
+	require.NotNil(t, optional)
+	assert.Equal(t, []*Point{{X: 1, Y: 2}, {X: 3, Y: 4}}, *optional)
+
+	var points []Point
+	require.NoError(t, BindQueryParameter("pipeDelimited", false, true, "point",
+		url.Values{"point": {"x,1,y,2|x,3,y,4"}}, &points))
+	assert.Equal(t, expected, points)
+
+	require.NoError(t, BindQueryParameter("spaceDelimited", false, true, "point",
+		url.Values{"point": {"x,1,y,2 x,3,y,4"}}, &points))
+	assert.Equal(t, expected, points)
+
+	// Unexploded form arrays can't tell the objects apart.
+	assert.ErrorContains(t, BindQueryParameter("form", false, true, "point",
+		url.Values{"point": {"x,1,y,2,x,3,y,4"}}, &points), "must be exploded")
+
+	assert.ErrorContains(t, BindQueryParameter("form", true, true, "point",
+		url.Values{"point": {"x,1,y"}}, &points), "property/values need to be pairs")
+}
+
+func TestBindFreeFormQuery(t *testing.T) {
+	query := url.Values{
+		"limit":         {"10"},
+		"filter.name":   {"Alex"},
+		"filter.status": {"active", "pending"},
+		"color":         {"red"},
+	}
+
+	var all map[string][]string
+	require.NoError(t, BindFreeFormQuery(query, &all, FreeFormQueryOptions{Exclude: []string{"limit"}}))
+	assert.Equal(t, map[string][]string{
+		"filter.name":   {"Alex"},
+		"filter.status": {"active", "pending"},
+		"color":         {"red"},
+	}, all)
+
+	var filters map[string][]string
+	require.NoError(t, BindFreeFormQuery(query, &filters, FreeFormQueryOptions{Prefix: "filter."}))
+	assert.Equal(t, map[string][]string{"name": {"Alex"}, "status": {"active", "pending"}}, filters)
+
+	var single map[string]string
+	err := BindFreeFormQuery(query, &single, FreeFormQueryOptions{Prefix: "filter."})
+	assert.ErrorContains(t, err, "multiple values for single value parameter 'filter.status'")
+	require.NoError(t, BindFreeFormQuery(query, &single, FreeFormQueryOptions{Exclude: []string{"filter.status"}}))
+	assert.Equal(t, map[string]string{"limit": "10", "filter.name": "Alex", "color": "red"}, single)
+
+	var free map[string]interface{}
+	require.NoError(t, BindFreeFormQuery(query, &free, FreeFormQueryOptions{Prefix: "filter."}))
+	assert.Equal(t, map[string]interface{}{"name": "Alex", "status": []string{"active", "pending"}}, free)
+
+	var ints map[string]int
+	require.NoError(t, BindFreeFormQuery(url.Values{"a": {"1"}}, &ints, FreeFormQueryOptions{}))
+	assert.Equal(t, map[string]int{"a": 1}, ints)
+	assert.Error(t, BindFreeFormQuery(url.Values{"a": {"x"}}, &ints, FreeFormQueryOptions{}))
+
+	// Optional destinations are only allocated when there's something to bind.
+	var optional *map[string]string
+	require.NoError(t, BindFreeFormQuery(query, &optional, FreeFormQueryOptions{Prefix: "sort."}))
+	assert.Nil(t, optional)
+	require.NoError(t, BindFreeFormQuery(query, &optional, FreeFormQueryOptions{Prefix: "col"}))
+	assert.Equal(t, &map[string]string{"or": "red"}, optional)
+
+	var wrong []string
+	assert.Error(t, BindFreeFormQuery(query, &wrong, FreeFormQueryOptions{}))
+}
+
+func TestBindHeaderParameter(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-request-id", "abc")
+	header.Add("X-Ids", "1,2")
+	header.Add("X-Ids", "3")
+	// Set without canonicalization, as by HTTP/2 aware middleware.
+	header["x-trace"] = []string{"t1"}
+
+	var requestID string
+	require.NoError(t, BindHeaderParameter("simple", "X-Request-Id", header, &requestID,
+		BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, "abc", requestID)
+
+	// Repeated headers are merged for arrays, but not for single values.
+	var ids []int
+	require.NoError(t, BindHeaderParameter("simple", "x-ids", header, &ids,
+		BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, []int{1, 2, 3}, ids)
+	var id int
+	assert.ErrorContains(t, BindHeaderParameter("simple", "X-Ids", header, &id,
+		BindHeaderParameterOptions{Required: true}), "specified multiple times")
+
+	var trace *string
+	err := BindHeaderParameter("simple", "X-Trace", header, &trace, BindHeaderParameterOptions{Required: true})
+	var requiredErr *RequiredParamError
+	require.ErrorAs(t, err, &requiredErr)
+	assert.Equal(t, ParamLocationHeader, requiredErr.Location)
+	require.NoError(t, BindHeaderParameter("simple", "X-Trace", header, &trace, BindHeaderParameterOptions{}))
+	assert.Nil(t, trace)
+	require.NoError(t, BindHeaderParameter("simple", "X-Trace", header, &trace,
+		BindHeaderParameterOptions{CaseInsensitive: true}))
+	require.NotNil(t, trace)
+	assert.Equal(t, "t1", *trace)
+}
+
+func TestBindParamsToExplodedObjectOverflow(t *testing.T) {
+	type Object struct {
+		Small int8 `json:"small"`
+	}
+	var dst Object
+	_, err := bindParamsToExplodedObject("id", url.Values{"small": {"128"}}, &dst, bindStringOptions{})
+	var overflowErr *OverflowError
+	require.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, "small", overflowErr.Field)
+}
+
+// money is a custom scalar, bound from values such as "$1.50".
+type money struct {
+	Cents int64
+}
+
+func (m *money) Bind(src string) error {
+	var dollars, cents int64
+	if _, err := fmt.Sscanf(src, "$%d.%02d", &dollars, &cents); err != nil {
+		return fmt.Errorf("invalid amount '%s'", src)
+	}
+	m.Cents = dollars*100 + cents
+	return nil
+}
+
+// percent is a custom scalar with a primitive kind, bound from values such
+// as "15%".
+type percent int
+
+func (p *percent) Bind(src string) error {
+	n, err := strconv.Atoi(strings.TrimSuffix(src, "%"))
+	if err != nil || !strings.HasSuffix(src, "%") {
+		return fmt.Errorf("invalid percentage '%s'", src)
+	}
+	*p = percent(n)
+	return nil
+}
+
+func TestBindBinderSlices(t *testing.T) {
+	expected := []money{{Cents: 150}, {Cents: 200}}
+
+	var amounts []money
+	require.NoError(t, BindStyledParameterWithOptions("simple", "amounts", "$1.50,$2.00", &amounts,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath}))
+	assert.Equal(t, expected, amounts)
+
+	var amount money
+	require.NoError(t, BindStyledParameterWithOptions("simple", "amount", "$1.50", &amount,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath}))
+	assert.Equal(t, money{Cents: 150}, amount)
+
+	var pointers []*money
+	require.NoError(t, BindQueryParameter("form", true, true, "amounts",
+		url.Values{"amounts": {"$1.50", "$2.00"}}, &pointers))
+	assert.Equal(t, []*money{{Cents: 150}, {Cents: 200}}, pointers)
+
+	var optional *[]money
+	require.NoError(t, BindQueryParameter("pipeDelimited", false, false, "amounts",
+		url.Values{"amounts": {"$1.50|$2.00"}}, &optional))
+	assert.Equal(t, &expected, optional)
+
+	var percents []percent
+	require.NoError(t, BindStyledParameterWithOptions("label", "p", ".15%25.20%25", &percents,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath, Explode: true}))
+	assert.Equal(t, []percent{15, 20}, percents)
+
+	var obj struct {
+		Amounts  []money   `json:"amounts"`
+		Percents []percent `json:"percents"`
+	}
+	require.NoError(t, UnmarshalDeepObject(&obj, "o", url.Values{
+		"o[amounts][0]":  {"$1.50"},
+		"o[amounts][1]":  {"$2.00"},
+		"o[percents][0]": {"5%"},
+	}))
+	assert.Equal(t, expected, obj.Amounts)
+	assert.Equal(t, []percent{5}, obj.Percents)
+
+	err := BindStyledParameterWithOptions("simple", "amounts", "$1.50,2", &amounts,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath})
+	assert.ErrorContains(t, err, "invalid amount '2'")
+	err = UnmarshalDeepObject(&obj, "o", url.Values{"o[percents][0]": {"5"}})
+	assert.ErrorContains(t, err, "invalid percentage '5'")
+}
+
+func TestBindStyledParameterExplodedMaps(t *testing.T) {
+	opts := BindStyledParameterOptions{ParamLocation: ParamLocationPath, Explode: true}
+	expected := map[string]int{"R": 100, "G": 200}
+
+	var m map[string]int
+	require.NoError(t, BindStyledParameterWithOptions("matrix", "color", ";R=100;G=200", &m, opts))
+	assert.Equal(t, expected, m)
+
+	require.NoError(t, BindStyledParameterWithOptions("matrix", "color", ";color=R,100;color=G,200", &m, opts))
+	assert.Equal(t, expected, m)
+
+	require.NoError(t, BindStyledParameterWithOptions("label", "color", ".R=100.G=200", &m, opts))
+	assert.Equal(t, expected, m)
+
+	// Escaped delimiters are kept in keys and values.
+	var s map[string]string
+	require.NoError(t, BindStyledParameterWithOptions("matrix", "tag", ";tag=a%2Cb,c%3Bd;tag=e,f", &s, opts))
+	assert.Equal(t, map[string]string{"a,b": "c;d", "e": "f"}, s)
+
+	require.NoError(t, BindStyledParameterWithOptions("matrix", "color", ";color=B,50", &m, opts))
+	assert.Equal(t, map[string]int{"B": 50}, m)
+
+	err := BindStyledParameterWithOptions("matrix", "color", ";color=R,100;G", &m, opts)
+	assert.ErrorContains(t, err, "invalid exploded format")
+	err = BindStyledParameterWithOptions("matrix", "color", ";color=R,x", &m, opts)
+	assert.ErrorContains(t, err, "error binding property 'R' of parameter 'color'")
+}
+
+func TestBindQueryParameterDuplicatePolicy(t *testing.T) {
+	query := url.Values{"limit": {"1", "2", "3"}, "ids": {"1,2", "3,4"}, "role": {"admin", "user"}}
+
+	var limit int
+	err := BindQueryParameterWithOptions("form", "limit", query, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true})
+	var duplicateErr *DuplicateParamError
+	require.ErrorAs(t, err, &duplicateErr)
+	assert.Equal(t, "limit", duplicateErr.ParamName)
+	assert.Equal(t, []string{"1", "2", "3"}, duplicateErr.Values)
+	assert.EqualError(t, err, "multiple values for single value parameter 'limit'")
+
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", query, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true, DuplicatePolicy: DuplicateParamFirstWins}))
+	assert.Equal(t, 1, limit)
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", query, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true, DuplicatePolicy: DuplicateParamLastWins}))
+	assert.Equal(t, 3, limit)
+
+	// Unexploded arrays take a single value, too.
+	var ids []int
+	assert.ErrorAs(t, BindQueryParameterWithOptions("form", "ids", query, &ids,
+		BindQueryParameterOptions{Required: true}), &duplicateErr)
+	require.NoError(t, BindQueryParameterWithOptions("form", "ids", query, &ids,
+		BindQueryParameterOptions{Required: true, DuplicatePolicy: DuplicateParamLastWins}))
+	assert.Equal(t, []int{3, 4}, ids)
+
+	// As do the properties of exploded objects.
+	type Object struct {
+		Role string `json:"role"`
+	}
+	var obj Object
+	assert.ErrorAs(t, BindQueryParameterWithOptions("form", "id", query, &obj,
+		BindQueryParameterOptions{Explode: true, Required: true}), &duplicateErr)
+	assert.Equal(t, "role", duplicateErr.ParamName)
+	require.NoError(t, BindQueryParameterWithOptions("form", "id", query, &obj,
+		BindQueryParameterOptions{Explode: true, Required: true, DuplicatePolicy: DuplicateParamFirstWins}))
+	assert.Equal(t, Object{Role: "admin"}, obj)
+
+	// Exploded arrays keep every value.
+	var all []int
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", query, &all,
+		BindQueryParameterOptions{Explode: true, Required: true, DuplicatePolicy: DuplicateParamFirstWins}))
+	assert.Equal(t, []int{1, 2, 3}, all)
+}
+
+func TestBindHeaderParameterMIMEWords(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Name", "=?UTF-8?B?Wm/Dqw==?= =?ISO-8859-1?Q?M=FCller?=")
+	header.Set("X-Names", "=?UTF-8?Q?Zo=C3=AB?=,=?UTF-8?Q?a=2Cb?=")
+	header.Set("X-Bad", "=?KOI8-R?B?8NLJ18XU?=")
+
+	var name string
+	require.NoError(t, BindHeaderParameter("simple", "X-Name", header, &name,
+		BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, "=?UTF-8?B?Wm/Dqw==?= =?ISO-8859-1?Q?M=FCller?=", name)
+
+	opts := BindHeaderParameterOptions{Required: true, DecodeMIMEWords: true}
+	require.NoError(t, BindHeaderParameter("simple", "X-Name", header, &name, opts))
+	assert.Equal(t, "ZoëMüller", name)
+
+	// Encoded commas are part of the values.
+	var names []string
+	require.NoError(t, BindHeaderParameter("simple", "X-Names", header, &names, opts))
+	assert.Equal(t, []string{"Zoë", "a,b"}, names)
+
+	assert.ErrorContains(t, BindHeaderParameter("simple", "X-Bad", header, &name, opts),
+		"error decoding header parameter 'X-Bad'")
+}
+
+func TestBindHeaderParameterObjects(t *testing.T) {
+	type Meta struct {
+		Role   string  `json:"role"`
+		Level  int     `json:"level"`
+		Admin  bool    `json:"admin"`
+		Weight float64 `json:"weight"`
+	}
+	expected := Meta{Role: "admin", Level: 5, Admin: true, Weight: 1.5}
+
+	header := http.Header{}
+	header.Set("X-Meta", "role=admin,level=5,admin=true,weight=1.5")
+	header.Set("X-Meta-Pairs", "role, admin, level, 5, admin, true, weight, 1.5")
+	header.Add("X-Meta-Repeated", "role=admin, level=5")
+	header.Add("X-Meta-Repeated", "admin=true, weight=1.5")
+
+	var meta Meta
+	require.NoError(t, BindHeaderParameter("simple", "X-Meta", header, &meta,
+		BindHeaderParameterOptions{Explode: true, Required: true}))
+	assert.Equal(t, expected, meta)
+
+	var pairs *Meta
+	require.NoError(t, BindHeaderParameter("simple", "X-Meta-Pairs", header, &pairs,
+		BindHeaderParameterOptions{}))
+	require.NotNil(t, pairs)
+	assert.Equal(t, expected, *pairs)
+
+	meta = Meta{}
+	require.NoError(t, BindHeaderParameter("simple", "X-Meta-Repeated", header, &meta,
+		BindHeaderParameterOptions{Explode: true, Required: true}))
+	assert.Equal(t, expected, meta)
+
+	header.Set("X-Bad-Level", "role=admin,level=high")
+	err := BindHeaderParameter("simple", "X-Bad-Level", header, &meta,
+		BindHeaderParameterOptions{Explode: true, Required: true})
+	assert.ErrorContains(t, err, "X-Bad-Level")
+
+	header.Set("X-Bad-Format", "role,admin,level")
+	err = BindHeaderParameter("simple", "X-Bad-Format", header, &meta, BindHeaderParameterOptions{Required: true})
+	assert.EqualError(t, err, "parameter 'X-Bad-Format' has invalid format, property/values need to be pairs")
+}
+
+func TestBindParameterTrimAndEmptyValues(t *testing.T) {
+	var limit int
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", url.Values{"limit": {" 10 "}}, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true, TrimSpace: true}))
+	assert.Equal(t, 10, limit)
+	assert.Error(t, BindQueryParameterWithOptions("form", "limit", url.Values{"limit": {" 10 "}}, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true}))
+
+	var name string
+	require.NoError(t, BindStyledParameterWithOptions("simple", "X-Name", "  Alex ", &name,
+		BindStyledParameterOptions{ParamLocation: ParamLocationHeader, TrimSpace: true}))
+	assert.Equal(t, "Alex", name)
+
+	empty := url.Values{"limit": {""}}
+
+	// By default, empty values are bound as they are.
+	assert.Error(t, BindQueryParameterWithOptions("form", "limit", empty, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true}))
+
+	limit = 5
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", empty, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true, EmptyValue: EmptyValueZero}))
+	assert.Equal(t, 0, limit)
+	var optional *int
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", empty, &optional,
+		BindQueryParameterOptions{Explode: true, EmptyValue: EmptyValueZero}))
+	require.NotNil(t, optional)
+	assert.Equal(t, 0, *optional)
+
+	optional = nil
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", empty, &optional,
+		BindQueryParameterOptions{Explode: true, EmptyValue: EmptyValueUnset}))
+	assert.Nil(t, optional)
+	require.NoError(t, BindQueryParameterWithOptions("form", "limit", empty, &optional,
+		BindQueryParameterOptions{Explode: true, EmptyValue: EmptyValueUnset, Default: "20"}))
+	require.NotNil(t, optional)
+	assert.Equal(t, 20, *optional)
+	var requiredErr *RequiredParamError
+	assert.ErrorAs(t, BindQueryParameterWithOptions("form", "limit", empty, &limit,
+		BindQueryParameterOptions{Explode: true, Required: true, EmptyValue: EmptyValueUnset}), &requiredErr)
+
+	// Whitespace only values are empty once trimmed.
+	err := BindQueryParameterWithOptions("form", "limit", url.Values{"limit": {"  "}}, &optional,
+		BindQueryParameterOptions{Explode: true, TrimSpace: true, EmptyValue: EmptyValueError})
+	assert.EqualError(t, err, "query parameter 'limit' can't be empty")
+}
+
+func TestBindQueryParameterTextUnmarshalerStruct(t *testing.T) {
+	// Structs which unmarshal themselves from text are bound as primitives,
+	// not exploded objects.
+	var timeout types.Duration
+	require.NoError(t, BindQueryParameter("form", true, true, "timeout", url.Values{"timeout": {"PT1H30M"}}, &timeout))
+	assert.Equal(t, types.Duration{Hours: 1, Minutes: 30}, timeout)
+
+	var optional *types.Duration
+	require.NoError(t, BindQueryParameter("form", true, false, "timeout", url.Values{"Hours": {"1"}}, &optional))
+	assert.Nil(t, optional)
+}
+
+func TestBindParameterBase64(t *testing.T) {
+	// Byte slices which bind themselves aren't bound as arrays.
+	var data types.Bytes
+	require.NoError(t, BindQueryParameter("form", true, true, "data", url.Values{"data": {"aGVsbG8="}}, &data))
+	assert.Equal(t, "hello", string(data))
+
+	var sig types.Base64URL
+	require.NoError(t, BindStyledParameterWithOptions("simple", "sig", "-_8B", &sig, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	}))
+	assert.Equal(t, []byte{0xfb, 0xff, 0x01}, sig.Bytes())
+
+	var token types.Bytes
+	require.NoError(t, BindHeaderParameter("simple", "X-Token", http.Header{"X-Token": {"aGk="}}, &token,
+		BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, "hi", string(token))
+}
+
+func TestBindHeaderParameterPercent(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Callback", "https://h/?q=a%20b")
+	header.Set("X-Ratio", "50%25")
+
+	// Headers are bound as they were sent, unless they're to be decoded.
+	var callback, ratio string
+	require.NoError(t, BindHeaderParameter("simple", "X-Callback", header, &callback, BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, "https://h/?q=a%20b", callback)
+	require.NoError(t, BindHeaderParameter("simple", "X-Ratio", header, &ratio, BindHeaderParameterOptions{Required: true}))
+	assert.Equal(t, "50%25", ratio)
+
+	require.NoError(t, BindHeaderParameter("simple", "X-Ratio", header, &ratio,
+		BindHeaderParameterOptions{Required: true, DecodePercent: true}))
+	assert.Equal(t, "50%", ratio)
+
+	styled, err := StyleParamWithLocation("simple", false, "X-Names", ParamLocationHeader, []string{"a,b", "50%"})
+	require.NoError(t, err)
+	header.Set("X-Names", styled)
+	var names []string
+	require.NoError(t, BindHeaderParameter("simple", "X-Names", header, &names,
+		BindHeaderParameterOptions{Required: true, DecodePercent: true}))
+	assert.Equal(t, []string{"a,b", "50%"}, names)
+}
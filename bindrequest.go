@@ -0,0 +1,138 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// paramField is a field of a parameters struct, and the parameter it binds.
+type paramField struct {
+	index int
+	in    ParamLocation
+	tag   paramTag
+}
+
+// paramsPlan is how to bind a parameters struct type, worked out from its
+// param tags the first time it's bound.
+type paramsPlan struct {
+	fields []paramField
+	err    error
+}
+
+// paramsPlans caches the *paramsPlan of each parameters struct type.
+var paramsPlans sync.Map
+
+// BindRequestParamsFunc binds the parameters of r to the fields of the
+// struct dst points to which have a param tag, as BindRequestParams does,
+// looking up path parameters by their name with pathParam. It lets routers
+// which keep path parameters to themselves, such as chi or gorilla/mux,
+// share the same parameters structs.
+func BindRequestParamsFunc(r *http.Request, dst interface{}, pathParam func(name string) string) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding request parameters requires a pointer to a struct, not %T", dst)
+	}
+	v = v.Elem()
+	plan := lookupParamsPlan(v.Type())
+	if plan.err != nil {
+		return plan.err
+	}
+
+	var query *ParsedQuery
+	var b Bindings
+	for _, f := range plan.fields {
+		in := f.in
+		bindField(f.tag, v.Field(f.index), func(p paramTag, dest interface{}) {
+			switch in {
+			case ParamLocationPath:
+				b.Path(p.style, p.explode, p.name, pathParam(p.name), dest)
+			case ParamLocationQuery:
+				if query == nil {
+					query = requestQuery(r)
+				}
+				b.Query(p.style, p.name, query.values, dest, BindQueryParameterOptions{Explode: p.explode, Required: p.required, Default: p.def})
+			case ParamLocationHeader:
+				b.HeaderWithOptions(p.style, p.name, r.Header, dest,
+					BindHeaderParameterOptions{Explode: p.explode, Required: p.required, Default: p.def})
+			case ParamLocationCookie:
+				b.Cookie(p.style, p.explode, p.required, p.name, r, dest)
+			}
+		})
+	}
+	if query != nil {
+		query.record(&b)
+	}
+	return bindingsErr(&b, dst)
+}
+
+// lookupParamsPlan returns the plan for binding the struct type t, working
+// it out the first time.
+func lookupParamsPlan(t reflect.Type) *paramsPlan {
+	if plan, found := paramsPlans.Load(t); found {
+		return plan.(*paramsPlan)
+	}
+	plan, _ := paramsPlans.LoadOrStore(t, newParamsPlan(t))
+	return plan.(*paramsPlan)
+}
+
+func newParamsPlan(t reflect.Type) *paramsPlan {
+	plan := &paramsPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, found := sf.Tag.Lookup("param")
+		if !found || tag == "-" || !sf.IsExported() {
+			continue
+		}
+		in, tag, err := cutParamLocation(tag)
+		if err == nil {
+			f := paramField{index: i, in: in}
+			switch in {
+			case ParamLocationPath:
+				f.tag, err = parseParamTag(tag, paramTag{style: "simple", required: true})
+			case ParamLocationHeader:
+				f.tag, err = parseParamTag(tag, paramTag{style: "simple"})
+			default:
+				f.tag, err = parseParamTag(tag, paramTag{style: "form"})
+			}
+			if f.tag.name == "" {
+				f.tag.name = sf.Name
+			}
+			f.tag.def = sf.Tag.Get("default")
+			plan.fields = append(plan.fields, f)
+		}
+		if err != nil {
+			plan.err = fmt.Errorf("error parsing param tag of field '%s': %w", sf.Name, err)
+			return plan
+		}
+	}
+	return plan
+}
+
+// cutParamLocation removes the in option from a param tag, returning the
+// location it gives and the rest of the tag.
+func cutParamLocation(tag string) (ParamLocation, string, error) {
+	parts := strings.Split(tag, ",")
+	for i, opt := range parts[1:] {
+		where, found := strings.CutPrefix(opt, "in=")
+		if !found {
+			continue
+		}
+		rest := strings.Join(append(parts[:i+1:i+1], parts[i+2:]...), ",")
+		switch where {
+		case "path":
+			return ParamLocationPath, rest, nil
+		case "query":
+			return ParamLocationQuery, rest, nil
+		case "header":
+			return ParamLocationHeader, rest, nil
+		case "cookie":
+			return ParamLocationCookie, rest, nil
+		default:
+			return ParamLocationUndefined, "", fmt.Errorf("unknown location '%s'", where)
+		}
+	}
+	return ParamLocationUndefined, "", fmt.Errorf("missing in option")
+}
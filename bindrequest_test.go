@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getPetParams struct {
+	ID        int       `param:"id,in=path"`
+	Tags      []string  `param:"tags,in=path,style=label,explode"`
+	Fields    *[]string `param:"fields,in=query,explode=false"`
+	Color     []string  `param:"color,in=query"`
+	Limit     int       `param:"limit,in=query"`
+	RequestID string    `param:"X-Request-ID,in=header,required"`
+	Trace     *int      `param:"X-Trace,in=header"`
+	Session   *string   `param:"session,in=cookie"`
+	Theme     string    `param:"theme,in=cookie"`
+	Skipped   string    `param:"-"`
+	Untagged  string
+}
+
+func (p getPetParams) Validate() error {
+	if p.Limit > 100 {
+		return errors.New("limit is too large")
+	}
+	return nil
+}
+
+func TestBindRequestParams(t *testing.T) {
+	pathParams := map[string]string{"id": "7", "tags": ".a.b"}
+	pathParam := func(name string) string { return pathParams[name] }
+
+	r := httptest.NewRequest(http.MethodGet, "/pets/7/.a.b?fields=name,age&color=red&color=blue&limit=5", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+
+	var params getPetParams
+	require.NoError(t, BindRequestParamsFunc(r, &params, pathParam))
+	assert.Equal(t, 7, params.ID)
+	assert.Equal(t, []string{"a", "b"}, params.Tags)
+	assert.Equal(t, []string{"name", "age"}, *params.Fields)
+	assert.Equal(t, []string{"red", "blue"}, params.Color)
+	assert.Equal(t, 5, params.Limit)
+	assert.Equal(t, "abc", params.RequestID)
+	assert.Nil(t, params.Trace)
+	assert.Equal(t, "s1", *params.Session)
+	assert.Empty(t, params.Theme)
+
+	// Absent query and header parameters take their default tags.
+	var withDefaults struct {
+		Limit int     `param:"limit,in=query" default:"20"`
+		Trace *string `param:"X-Trace,in=header" default:"off"`
+	}
+	require.NoError(t, BindRequestParamsFunc(httptest.NewRequest(http.MethodGet, "/", nil), &withDefaults, pathParam))
+	assert.Equal(t, 20, withDefaults.Limit)
+	assert.Equal(t, "off", *withDefaults.Trace)
+
+	// The plan is worked out once, and reused.
+	plan, found := paramsPlans.Load(reflect.TypeOf(params))
+	require.True(t, found)
+	assert.Len(t, plan.(*paramsPlan).fields, 9)
+	assert.Same(t, plan, lookupParamsPlan(reflect.TypeOf(params)))
+
+	// Every field is bound, and all the failures are reported.
+	pathParams["id"] = "x"
+	r = httptest.NewRequest(http.MethodGet, "/pets/x/.a?limit=ten", nil)
+	r.Header.Set("X-Trace", "t")
+	err := BindRequestParamsFunc(r, &getPetParams{}, pathParam)
+	var paramErrs ParamErrors
+	require.ErrorAs(t, err, &paramErrs)
+	require.Len(t, paramErrs, 4)
+	assert.Equal(t, "id", paramErrs[0].ParamName)
+	assert.Equal(t, ParamLocationPath, paramErrs[0].Location)
+	assert.Equal(t, "limit", paramErrs[1].ParamName)
+	assert.Equal(t, ParamLocationQuery, paramErrs[1].Location)
+	assert.Equal(t, "X-Request-ID", paramErrs[2].ParamName)
+	assert.Equal(t, "X-Trace", paramErrs[3].ParamName)
+
+	pathParams["id"] = "7"
+	r = httptest.NewRequest(http.MethodGet, "/pets/7/.a?limit=500", nil)
+	r.Header.Set("X-Request-ID", "abc")
+	err = BindRequestParamsFunc(r, &getPetParams{}, pathParam)
+	assert.EqualError(t, err, "validation failed: limit is too large")
+
+	assert.EqualError(t, BindRequestParamsFunc(r, &struct {
+		ID int `param:"id"`
+	}{}, pathParam), "error parsing param tag of field 'ID': missing in option")
+	assert.EqualError(t, BindRequestParamsFunc(r, &struct {
+		ID int `param:"id,in=body"`
+	}{}, pathParam), "error parsing param tag of field 'ID': unknown location 'body'")
+	assert.EqualError(t, BindRequestParamsFunc(r, &struct {
+		ID int `param:"id,in=path,deep"`
+	}{}, pathParam), "error parsing param tag of field 'ID': unknown option 'deep'")
+	assert.EqualError(t, BindRequestParamsFunc(r, params, pathParam),
+		"binding request parameters requires a pointer to a struct, not runtime.getPetParams")
+}
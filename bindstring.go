@@ -14,11 +14,14 @@
 package runtime
 
 import (
+	"database/sql"
 	"encoding"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/oapi-codegen/runtime/types"
@@ -30,7 +33,61 @@ import (
 // type aliases. This function was the easy way out, the better way, since we
 // know the destination type each place that we use this, is to generate code
 // to read each specific type.
-func BindStringToObject(src string, dst interface{}) error {
+func BindStringToObject(src string, dst interface{}) (err error) {
+	defer recoverPanic(&err, "error binding string '%s'", src)
+	if err := checkDestination("", dst, false); err != nil {
+		return err
+	}
+	return bindStringToObject(src, dst, bindStringOptions{})
+}
+
+// bindStringOptions carries the binding behavior that callers with options,
+// such as the styled parameter and deepObject binders, pass down to
+// BindStringToObject.
+type bindStringOptions struct {
+	// lenientBool accepts the extra boolean spellings understood by parseBool.
+	lenientBool bool
+	// timeParsers replace TimeParsers when they're set.
+	timeParsers []TimeParser
+	// duplicatePolicy picks the value of query parameters, and properties of
+	// exploded objects, which are given more than once.
+	duplicatePolicy DuplicateParamPolicy
+}
+
+// TimeParser parses time.Time parameter values. TimeLayout,
+// UnixTimeFormatter and UnixMilliTimeFormatter are all TimeParsers.
+type TimeParser interface {
+	ParseTime(s string) (time.Time, error)
+}
+
+// TimeParsers are the formats accepted when binding time.Time values, unless
+// the binding options give their own. They're tried in order, and the first
+// to succeed is used. By default, RFC3339 times are accepted, with or
+// without fractional seconds, as are full dates. It's meant to be set once,
+// before any binding happens, such as from an init function.
+var TimeParsers = []TimeParser{
+	TimeLayout(time.RFC3339Nano),
+	TimeLayout(types.DateFormat),
+}
+
+// parseTime parses src with the first of parsers which accepts it, or with
+// TimeParsers when parsers is nil.
+func parseTime(src string, parsers []TimeParser) (time.Time, error) {
+	if parsers == nil {
+		parsers = TimeParsers
+	}
+	errs := make([]error, 0, len(parsers))
+	for _, parser := range parsers {
+		t, err := parser.ParseTime(src)
+		if err == nil {
+			return t, nil
+		}
+		errs = append(errs, err)
+	}
+	return time.Time{}, fmt.Errorf("error parsing '%s' as time: %w", src, errors.Join(errs...))
+}
+
+func bindStringToObject(src string, dst interface{}, opts bindStringOptions) error {
 	var err error
 
 	v := reflect.ValueOf(dst)
@@ -58,72 +115,55 @@ func BindStringToObject(src string, dst interface{}) error {
 		return errors.New("destination is not settable")
 	}
 
-	switch t.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		var val int64
-		val, err = strconv.ParseInt(src, 10, 64)
-		if err == nil {
-			if v.OverflowInt(val) {
-				err = fmt.Errorf("value '%s' overflows destination of type: %s", src, t.Kind())
-			}
-			if err == nil {
-				v.SetInt(val)
-			}
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		var val uint64
-		val, err = strconv.ParseUint(src, 10, 64)
-		if err == nil {
-			if v.OverflowUint(val) {
-				err = fmt.Errorf("value '%s' overflows destination of type: %s", src, t.Kind())
-			}
-			v.SetUint(val)
+	// Types of registered formats are parsed by their codec.
+	if handled, err := bindFormat(src, v); handled {
+		return err
+	}
+
+	// Types which know how to bind themselves take precedence over parsing
+	// by kind. Times and dates implement encoding.TextUnmarshaler too, but
+	// we're more lenient about their formats below.
+	if dstType, ok := v.Addr().Interface().(Binder); ok {
+		return dstType.Bind(src)
+	}
+	if tu, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok &&
+		!t.ConvertibleTo(reflect.TypeOf(time.Time{})) && !t.ConvertibleTo(reflect.TypeOf(types.Date{})) {
+		if err := tu.UnmarshalText([]byte(src)); err != nil {
+			return fmt.Errorf("error unmarshaling '%s' text as %T: %s", src, dst, err)
 		}
+		return nil
+	}
+
+	// database/sql types, such as sql.NullString, scan the value. Dates
+	// scan too, but they're parsed strictly below.
+	if scanner, ok := v.Addr().Interface().(sql.Scanner); ok && !t.ConvertibleTo(reflect.TypeOf(types.Date{})) {
+		return bindScanner(src, scanner, opts)
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		err = bindNumber(v, src)
 	case reflect.String:
 		v.SetString(src)
 		err = nil
-	case reflect.Float64, reflect.Float32:
-		var val float64
-		val, err = strconv.ParseFloat(src, 64)
-		if err == nil {
-			if v.OverflowFloat(val) {
-				err = fmt.Errorf("value '%s' overflows destination of type: %s", src, t.Kind())
-			}
-			v.SetFloat(val)
-		}
 	case reflect.Bool:
 		var val bool
-		val, err = strconv.ParseBool(src)
+		val, err = parseBool(src, opts.lenientBool)
 		if err == nil {
 			v.SetBool(val)
 		}
-	case reflect.Array:
-		if tu, ok := dst.(encoding.TextUnmarshaler); ok {
-			if err := tu.UnmarshalText([]byte(src)); err != nil {
-				return fmt.Errorf("error unmarshaling '%s' text as %T: %s", src, dst, err)
-			}
-
-			return nil
-		}
-		fallthrough
 	case reflect.Struct:
-		// if this is not of type Time or of type Date look to see if this is of type Binder.
-		if dstType, ok := dst.(Binder); ok {
-			return dstType.Bind(src)
-		}
-
 		if t.ConvertibleTo(reflect.TypeOf(time.Time{})) {
 			// Don't fail on empty string.
 			if src == "" {
 				return nil
 			}
 			// Time is a special case of a struct that we handle
-			parsedTime, err := time.Parse(time.RFC3339Nano, src)
+			parsedTime, err := parseTime(src, opts.timeParsers)
 			if err != nil {
-				parsedTime, err = time.Parse(types.DateFormat, src)
-				if err != nil {
-					return fmt.Errorf("error parsing '%s' as RFC3339 or 2006-01-02 time: %s", src, err)
-				}
+				return err
 			}
 			// So, assigning this gets a little fun. We have a value to the
 			// dereference destination. We can't do a conversion to
@@ -143,11 +183,10 @@ func BindStringToObject(src string, dst interface{}) error {
 			if src == "" {
 				return nil
 			}
-			parsedTime, err := time.Parse(types.DateFormat, src)
+			parsedDate, err := types.ParseDate(src, types.DefaultDateOptions())
 			if err != nil {
 				return fmt.Errorf("error parsing '%s' as date: %s", src, err)
 			}
-			parsedDate := types.Date{Time: parsedTime}
 
 			// We have to do the same dance here to assign, just like with times
 			// above.
@@ -172,3 +211,124 @@ func BindStringToObject(src string, dst interface{}) error {
 	}
 	return nil
 }
+
+// bindScanner binds src to an sql.Scanner, such as sql.NullInt64, by
+// scanning it as a string, which the sql.Null types convert to their own
+// types. Empty values scan as NULL, and times are parsed as any other time
+// would be, since sql.NullTime won't scan strings.
+func bindScanner(src string, scanner sql.Scanner, opts bindStringOptions) error {
+	var value interface{} = src
+	if src == "" {
+		value = nil
+	} else if _, isTime := scanner.(*sql.NullTime); isTime {
+		t, err := parseTime(src, opts.timeParsers)
+		if err != nil {
+			return err
+		}
+		value = t
+	}
+	if err := scanner.Scan(value); err != nil {
+		return fmt.Errorf("error scanning '%s' into %T: %w", src, scanner, err)
+	}
+	return nil
+}
+
+// OverflowError is returned when a number is outside the range of the
+// integer or floating point type it's bound to, rather than truncating it.
+type OverflowError struct {
+	// Field names the field which was being bound, when it's known.
+	Field string
+	Value string
+	Type  reflect.Type
+	// Min and Max are the bounds of Type.
+	Min string
+	Max string
+}
+
+func (e *OverflowError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("value '%s' is out of range for %s, which must be between %s and %s",
+			e.Value, e.Type, e.Min, e.Max)
+	}
+	return fmt.Sprintf("value '%s' of field '%s' is out of range for %s, which must be between %s and %s",
+		e.Value, e.Field, e.Type, e.Min, e.Max)
+}
+
+// newOverflowError describes src overflowing the numeric type t.
+func newOverflowError(src string, t reflect.Type) *OverflowError {
+	e := &OverflowError{Value: src, Type: t}
+	bits := t.Bits()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.Min = strconv.FormatInt(-1<<(bits-1), 10)
+		e.Max = strconv.FormatInt(1<<(bits-1)-1, 10)
+	case reflect.Float32, reflect.Float64:
+		max := math.MaxFloat64
+		if bits == 32 {
+			max = math.MaxFloat32
+		}
+		e.Min = strconv.FormatFloat(-max, 'g', -1, bits)
+		e.Max = strconv.FormatFloat(max, 'g', -1, bits)
+	default:
+		e.Min = "0"
+		e.Max = strconv.FormatUint(math.MaxUint64>>(64-bits), 10)
+	}
+	return e
+}
+
+// bindNumber parses src into v, which must be of one of the integer or
+// floating point kinds, sized or not. Numbers which don't fit v are
+// reported as an *OverflowError.
+func bindNumber(v reflect.Value, src string) error {
+	t := v.Type()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err := strconv.ParseInt(src, 10, t.Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return newOverflowError(src, t)
+		}
+		if err != nil {
+			return err
+		}
+		v.SetInt(val)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val, err := strconv.ParseUint(src, 10, t.Bits())
+		if err != nil {
+			// Negative numbers are out of range, rather than malformed.
+			if _, intErr := strconv.ParseInt(src, 10, 64); errors.Is(err, strconv.ErrRange) ||
+				strings.HasPrefix(src, "-") && (intErr == nil || errors.Is(intErr, strconv.ErrRange)) {
+				return newOverflowError(src, t)
+			}
+			return err
+		}
+		v.SetUint(val)
+	case reflect.Float32, reflect.Float64:
+		val, err := strconv.ParseFloat(src, t.Bits())
+		if errors.Is(err, strconv.ErrRange) {
+			return newOverflowError(src, t)
+		}
+		if err != nil {
+			return err
+		}
+		v.SetFloat(val)
+	default:
+		return fmt.Errorf("can not bind to destination of type: %s", t.Kind())
+	}
+	return nil
+}
+
+// parseBool parses a boolean value. In lenient mode, it also accepts the
+// spellings sent by HTML forms and some clients, such as "on"/"off" and
+// "yes"/"no", without regard to case.
+func parseBool(src string, lenient bool) (bool, error) {
+	if !lenient {
+		return strconv.ParseBool(src)
+	}
+	switch strings.ToLower(src) {
+	case "1", "t", "true", "on", "y", "yes":
+		return true, nil
+	case "0", "f", "false", "off", "n", "no":
+		return false, nil
+	}
+	return false, &strconv.NumError{Func: "ParseBool", Num: src, Err: strconv.ErrSyntax}
+}
@@ -14,13 +14,19 @@
 package runtime
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"math"
+	"net/netip"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBindStringToObject(t *testing.T) {
@@ -209,3 +215,184 @@ func TestBindStringToObject(t *testing.T) {
 	assert.Equal(t, dstUUID.String(), uuidString)
 
 }
+
+func TestParseBoolLenient(t *testing.T) {
+	for _, src := range []string{"1", "t", "TRUE", "on", "On", "y", "yes", "YES"} {
+		val, err := parseBool(src, true)
+		assert.NoError(t, err, src)
+		assert.True(t, val, src)
+	}
+	for _, src := range []string{"0", "f", "False", "off", "OFF", "n", "no"} {
+		val, err := parseBool(src, true)
+		assert.NoError(t, err, src)
+		assert.False(t, val, src)
+	}
+	_, err := parseBool("maybe", true)
+	assert.Error(t, err)
+
+	// The strict parser only understands strconv.ParseBool spellings.
+	_, err = parseBool("on", false)
+	assert.Error(t, err)
+}
+
+type textID string
+
+func (id *textID) UnmarshalText(text []byte) error {
+	if !strings.HasPrefix(string(text), "id-") {
+		return fmt.Errorf("invalid id '%s'", text)
+	}
+	*id = textID(strings.TrimPrefix(string(text), "id-"))
+	return nil
+}
+
+func TestBindStringToObjectTextUnmarshaler(t *testing.T) {
+	var addr netip.Addr
+	assert.NoError(t, BindStringToObject("192.168.0.1", &addr))
+	assert.Equal(t, netip.MustParseAddr("192.168.0.1"), addr)
+	assert.Error(t, BindStringToObject("not an address", &addr))
+
+	// TextUnmarshaler is preferred to parsing by kind.
+	var id textID
+	assert.NoError(t, BindStringToObject("id-42", &id))
+	assert.Equal(t, textID("42"), id)
+	assert.Error(t, BindStringToObject("42", &id))
+
+	// Optional destinations are allocated.
+	var optional *netip.Addr
+	assert.NoError(t, BindStringToObject("::1", &optional))
+	assert.Equal(t, netip.MustParseAddr("::1"), *optional)
+
+	// Times keep accepting dates, which their UnmarshalText would reject.
+	var ts time.Time
+	assert.NoError(t, BindStringToObject("2020-01-02", &ts))
+	assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), ts)
+}
+
+func TestBindStringToObjectOverflow(t *testing.T) {
+	assertOverflow := func(t *testing.T, err error, min, max string) {
+		t.Helper()
+		var overflowErr *OverflowError
+		require.ErrorAs(t, err, &overflowErr)
+		assert.Equal(t, min, overflowErr.Min)
+		assert.Equal(t, max, overflowErr.Max)
+	}
+
+	var i8 int8
+	assertOverflow(t, BindStringToObject("300", &i8), "-128", "127")
+	assert.Equal(t, int8(0), i8)
+	assertOverflow(t, BindStringToObject("-129", &i8), "-128", "127")
+	assert.NoError(t, BindStringToObject("-128", &i8))
+	assert.Equal(t, int8(-128), i8)
+
+	var u16 uint16
+	assertOverflow(t, BindStringToObject("65536", &u16), "0", "65535")
+	assertOverflow(t, BindStringToObject("-1", &u16), "0", "65535")
+	assert.Equal(t, uint16(0), u16)
+	var notOverflow *OverflowError
+	assert.False(t, errors.As(BindStringToObject("-x", &u16), &notOverflow))
+
+	var i64 int64
+	assertOverflow(t, BindStringToObject("9223372036854775808", &i64), "-9223372036854775808", "9223372036854775807")
+
+	var ptr uintptr
+	assert.NoError(t, BindStringToObject("4096", &ptr))
+	assert.Equal(t, uintptr(4096), ptr)
+
+	var f32 float32
+	assertOverflow(t, BindStringToObject("1e39", &f32), "-3.4028235e+38", "3.4028235e+38")
+	assert.NoError(t, BindStringToObject("1.5", &f32))
+	assert.Equal(t, float32(1.5), f32)
+
+	err := BindStringToObject("300", &i8)
+	assert.EqualError(t, err, "error binding string parameter: value '300' is out of range for int8, which must be between -128 and 127")
+}
+
+func TestBindTimeParsers(t *testing.T) {
+	expected := time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)
+
+	var ts time.Time
+	assert.NoError(t, BindStringToObject("2023-04-05T06:07:08Z", &ts))
+	assert.Equal(t, expected, ts)
+	assert.ErrorContains(t, BindStringToObject("1680674828", &ts), "error parsing '1680674828' as time")
+
+	parsers := []TimeParser{TimeLayout(time.RFC3339), UnixTimeFormatter{}}
+	require.NoError(t, BindStyledParameterWithOptions("simple", "ts", "1680674828", &ts,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath, TimeParsers: parsers}))
+	assert.Equal(t, expected, ts)
+
+	var times []time.Time
+	require.NoError(t, BindQueryParameterWithOptions("form", "ts", url.Values{"ts": {"1680674828000", "2023-04-05T06:07:08Z"}},
+		&times, BindQueryParameterOptions{Explode: true, Required: true,
+			TimeParsers: []TimeParser{UnixMilliTimeFormatter{}, TimeLayout(time.RFC3339)}}))
+	assert.Equal(t, []time.Time{expected, expected}, times)
+
+	var obj struct {
+		At time.Time `json:"at"`
+	}
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&obj, "o", url.Values{"o[at]": {"2023-04-05 06:07:08"}},
+		UnmarshalDeepObjectOptions{TimeParsers: []TimeParser{TimeLayout(time.DateTime)}}))
+	assert.Equal(t, expected, obj.At)
+
+	// The package wide parsers apply when none are given.
+	defaultParsers := TimeParsers
+	defer func() { TimeParsers = defaultParsers }()
+	TimeParsers = append(TimeParsers, UnixTimeFormatter{})
+	assert.NoError(t, BindStringToObject("1680674828", &ts))
+	assert.Equal(t, expected, ts)
+
+	assert.Equal(t, "1680674828000", UnixMilliTimeFormatter{}.FormatTime(expected))
+}
+
+func TestBindStringToObjectSQLNullTypes(t *testing.T) {
+	var s sql.NullString
+	require.NoError(t, BindStringToObject("hello", &s))
+	assert.Equal(t, sql.NullString{String: "hello", Valid: true}, s)
+	require.NoError(t, BindStringToObject("", &s))
+	assert.False(t, s.Valid)
+
+	var i sql.NullInt64
+	require.NoError(t, BindStringToObject("42", &i))
+	assert.Equal(t, sql.NullInt64{Int64: 42, Valid: true}, i)
+	assert.ErrorContains(t, BindStringToObject("x", &i), "error scanning 'x' into *sql.NullInt64")
+
+	var b sql.NullBool
+	require.NoError(t, BindStringToObject("true", &b))
+	assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, b)
+
+	var ts sql.NullTime
+	require.NoError(t, BindStringToObject("2020-01-02T03:04:05Z", &ts))
+	assert.Equal(t, sql.NullTime{Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true}, ts)
+
+	var optional *sql.NullFloat64
+	require.NoError(t, BindStringToObject("1.5", &optional))
+	assert.Equal(t, &sql.NullFloat64{Float64: 1.5, Valid: true}, optional)
+
+	// They're bound as primitives by the styled and deepObject binders.
+	var ids []sql.NullInt32
+	require.NoError(t, BindStyledParameterWithOptions("simple", "ids", "1,,3", &ids,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath}))
+	assert.Equal(t, []sql.NullInt32{{Int32: 1, Valid: true}, {}, {Int32: 3, Valid: true}}, ids)
+
+	var name sql.NullString
+	require.NoError(t, BindQueryParameter("form", true, true, "name", url.Values{"name": {"Alex"}}, &name))
+	assert.Equal(t, sql.NullString{String: "Alex", Valid: true}, name)
+
+	var obj struct {
+		Name  sql.NullString `json:"name"`
+		Since sql.NullTime   `json:"since"`
+	}
+	require.NoError(t, UnmarshalDeepObject(&obj, "o", url.Values{"o[name]": {"Alex"}, "o[since]": {"2020-01-02"}}))
+	assert.Equal(t, sql.NullString{String: "Alex", Valid: true}, obj.Name)
+	assert.Equal(t, sql.NullTime{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true}, obj.Since)
+}
+
+func TestBindStringToObjectDateOptions(t *testing.T) {
+	t.Cleanup(func() { types.SetDefaultDateOptions(types.DateOptions{}) })
+
+	var d types.Date
+	assert.Error(t, BindStringToObject("2020-11-05T23:00:00-02:00", &d))
+
+	types.SetDefaultDateOptions(types.DateOptions{TruncateTime: true})
+	require.NoError(t, BindStringToObject("2020-11-05T23:00:00-02:00", &d))
+	assert.Equal(t, types.NewDate(2020, time.November, 6), d)
+}
@@ -0,0 +1,210 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// BodyCodec encodes and decodes the bodies of a media type, such as
+// application/cbor. Codecs are registered for their media type by
+// RegisterBodyCodec, so that the implementation behind helpers such as
+// BindCBOR and WriteCBOR may be chosen by the program.
+type BodyCodec interface {
+	// Marshal encodes v as a body.
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal decodes a body into v, which is a non-nil pointer.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+var (
+	bodyCodecsMu sync.RWMutex
+	bodyCodecs   = make(map[string]BodyCodec)
+)
+
+func init() {
+	RegisterBodyCodec(jsonContentType, jsonCodec{})
+}
+
+// jsonCodec is the BodyCodec of JSON bodies, which encoding/json encodes.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// UnsupportedMediaTypeError is returned when no codec is registered for the
+// media type of a body, which is best answered with the status StatusCode
+// returns, 415 Unsupported Media Type.
+type UnsupportedMediaTypeError struct {
+	// ContentType is the content type of the body.
+	ContentType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("no codec is registered for the content type '%s'", e.ContentType)
+}
+
+// StatusCode returns http.StatusUnsupportedMediaType.
+func (e *UnsupportedMediaTypeError) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+// RegisterBodyCodec makes codec the codec of the given media type, such as
+// application/cbor, replacing the one registered for it before, such as the
+// default codec of the runtime:
+//
+//	func init() {
+//		runtime.RegisterBodyCodec("application/cbor", fxCBORCodec{})
+//	}
+//
+// Like RegisterFormat, it's meant to be called from an init function, and
+// panics if the media type is invalid or the codec is nil.
+func RegisterBodyCodec(mediaType string, codec BodyCodec) {
+	if codec == nil {
+		panic("runtime: RegisterBodyCodec codec must not be nil")
+	}
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		panic(fmt.Sprintf("runtime: RegisterBodyCodec called with invalid media type %q", mediaType))
+	}
+
+	bodyCodecsMu.Lock()
+	defer bodyCodecsMu.Unlock()
+	bodyCodecs[mt] = codec
+}
+
+// LookupBodyCodec returns the codec registered for the media type of
+// contentType, whose parameters are ignored. A media type with a structured
+// syntax suffix, such as application/problem+json, falls back to the codec
+// of its suffix, such as application/json.
+func LookupBodyCodec(contentType string) (BodyCodec, bool) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	bodyCodecsMu.RLock()
+	defer bodyCodecsMu.RUnlock()
+	codec, found := bodyCodecs[mt]
+	if !found {
+		if i := strings.LastIndexByte(mt, '+'); i >= 0 {
+			codec, found = bodyCodecs["application/"+mt[i+1:]]
+		}
+	}
+	return codec, found
+}
+
+// BindBody decodes the body of r into dst, as BindBodyWithOptions does.
+func BindBody(r *http.Request, dst interface{}) error {
+	return BindBodyWithOptions(r, dst, BodyOptions{})
+}
+
+// BindBodyWithOptions decodes the body of r into dst with the codec
+// registered for its Content-Type, such as application/json,
+// application/cbor or application/msgpack, so that a handler binds each of
+// them the same way. When there's none, the error is an
+// *UnsupportedMediaTypeError. When it's decoded, a Validatable dst is
+// validated.
+func BindBodyWithOptions(r *http.Request, dst interface{}, opts BodyOptions) error {
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return &UnsupportedMediaTypeError{ContentType: contentType}
+	}
+	return bindCodecBody(r, dst, mediaType, opts)
+}
+
+// WriteBody writes a response with the given status and headers, whose body
+// is v encoded with the codec registered for contentType, which is the
+// Content-Type of the response unless headers set another. When there's no
+// codec for it, the error is an *UnsupportedMediaTypeError, and nothing is
+// written.
+func WriteBody(w http.ResponseWriter, status int, contentType string, v interface{}, headers http.Header) error {
+	return writeCodecBody(w, status, v, headers, contentType)
+}
+
+// WriteNegotiated writes a response with the given status and headers, whose
+// body is v encoded as the content type among offered which is best for
+// the Accept header of r, chosen by NegotiateContentType, such as
+// application/x-protobuf for a client which accepts it and
+// application/json otherwise. The response varies by Accept. When none of
+// offered is acceptable, the error is a *NotAcceptableError, and nothing is
+// written.
+func WriteNegotiated(w http.ResponseWriter, r *http.Request, status int, offered []string, v interface{}, headers http.Header) error {
+	contentType, err := NegotiateContentType(r, offered)
+	if err != nil {
+		return err
+	}
+	w.Header().Add("Vary", "Accept")
+	return writeCodecBody(w, status, v, headers, contentType)
+}
+
+// bindCodecBody decodes the body of r into dst with the codec registered
+// for mediaType, and validates it, as BindXMLWithOptions does for XML.
+func bindCodecBody(r *http.Request, dst interface{}, mediaType string, opts BodyOptions) (err error) {
+	name := bodyFormatName(mediaType)
+	defer recoverPanic(&err, "error binding %s body", name)
+	if err := checkDestination("", dst, false); err != nil {
+		return err
+	}
+	codec, found := LookupBodyCodec(mediaType)
+	if !found {
+		return &UnsupportedMediaTypeError{ContentType: mediaType}
+	}
+	data, err := io.ReadAll(limitBody(r.Body, opts.MaxBodySize))
+	if err != nil {
+		return fmt.Errorf("error reading %s body: %w", name, err)
+	}
+	if err := codec.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("error decoding %s body: %w", name, err)
+	}
+	return validate("", dst)
+}
+
+// writeCodecBody writes a response with the given status and headers, whose
+// body is v encoded with the codec registered for mediaType, which is its
+// Content-Type unless headers set another. The body is encoded before the
+// status is written, so that an error encoding it may still be answered.
+func writeCodecBody(w http.ResponseWriter, status int, v interface{}, headers http.Header, mediaType string) error {
+	data, err := marshalCodecBody(v, mediaType)
+	if err != nil {
+		return err
+	}
+	if !writeResponseHeader(w, status, headers, mediaType) {
+		return nil
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// marshalCodecBody encodes v with the codec registered for mediaType.
+func marshalCodecBody(v interface{}, mediaType string) ([]byte, error) {
+	codec, found := LookupBodyCodec(mediaType)
+	if !found {
+		return nil, &UnsupportedMediaTypeError{ContentType: mediaType}
+	}
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s body: %w", bodyFormatName(mediaType), err)
+	}
+	return data, nil
+}
+
+// bodyFormatName names the format of a media type in errors, such as CBOR
+// for application/cbor, or JSON for application/problem+json.
+func bodyFormatName(mediaType string) string {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	_, subtype, _ := strings.Cut(strings.TrimSpace(mediaType), "/")
+	if i := strings.LastIndexByte(subtype, '+'); i >= 0 {
+		subtype = subtype[i+1:]
+	}
+	return strings.ToUpper(strings.TrimPrefix(subtype, "x-"))
+}
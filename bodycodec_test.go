@@ -0,0 +1,93 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonBodyCodec is a BodyCodec which encodes bodies as JSON.
+type jsonBodyCodec struct{}
+
+func (jsonBodyCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonBodyCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func TestRegisterBodyCodec(t *testing.T) {
+	defaultCodec, found := LookupBodyCodec("application/cbor")
+	require.True(t, found)
+	RegisterBodyCodec("application/cbor", jsonBodyCodec{})
+	defer RegisterBodyCodec("application/cbor", defaultCodec)
+
+	var pet xmlPet
+	require.NoError(t, BindCBOR(newBodyRequest("application/cbor", []byte(`{"id":1,"name":"Rex"}`)), &pet))
+	assert.Equal(t, xmlPet{ID: 1, Name: "Rex"}, pet)
+
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteCBOR(w, http.StatusOK, pet, nil))
+	assert.Equal(t, `{"id":1,"name":"Rex"}`, w.Body.String())
+
+	codec, found := LookupBodyCodec("application/cbor; charset=utf-8")
+	assert.True(t, found)
+	assert.Equal(t, jsonBodyCodec{}, codec)
+
+	_, found = LookupBodyCodec("application/unknown")
+	assert.False(t, found)
+	assert.EqualError(t, bindCodecBody(newBodyRequest("application/unknown", ""), &pet, "application/unknown", BodyOptions{}),
+		"no codec is registered for the content type 'application/unknown'")
+
+	assert.Panics(t, func() { RegisterBodyCodec("application/cbor", nil) })
+	assert.Panics(t, func() { RegisterBodyCodec("", jsonBodyCodec{}) })
+}
+
+func TestBindBody(t *testing.T) {
+	msgpack := []byte{0x82, 0xa2, 'i', 'd', 0x01, 0xa4, 'n', 'a', 'm', 'e', 0xa3, 'R', 'e', 'x'}
+	cbor := []byte{0xa2, 0x62, 'i', 'd', 0x01, 0x64, 'n', 'a', 'm', 'e', 0x63, 'R', 'e', 'x'}
+	for contentType, body := range map[string][]byte{
+		"application/json":                []byte(`{"id":1,"name":"Rex"}`),
+		"application/json; charset=utf-8": []byte(`{"id":1,"name":"Rex"}`),
+		"application/merge-patch+json":    []byte(`{"id":1,"name":"Rex"}`),
+		"application/msgpack":             msgpack,
+		"application/x-msgpack":           msgpack,
+		"application/cbor":                cbor,
+	} {
+		var pet xmlPet
+		require.NoError(t, BindBody(newBodyRequest(contentType, body), &pet), contentType)
+		assert.Equal(t, xmlPet{ID: 1, Name: "Rex"}, pet, contentType)
+	}
+
+	err := BindBody(newBodyRequest("application/json", []byte(`{"id":1}`)), &xmlPet{})
+	assert.EqualError(t, err, "validation failed: name is required")
+
+	err = BindBody(newBodyRequest("text/csv", []byte("1,Rex")), &xmlPet{})
+	var unsupported *UnsupportedMediaTypeError
+	require.ErrorAs(t, err, &unsupported)
+	assert.Equal(t, http.StatusUnsupportedMediaType, unsupported.StatusCode())
+	assert.EqualError(t, err, "no codec is registered for the content type 'text/csv'")
+
+	err = BindBody(newBodyRequest("", []byte(`{}`)), &xmlPet{})
+	assert.True(t, errors.As(err, &unsupported))
+}
+
+func TestWriteBody(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteBody(w, http.StatusOK, "application/problem+json", xmlPet{ID: 1, Name: "Rex"}, nil))
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"id":1,"name":"Rex"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	err := WriteBody(w, http.StatusOK, "text/csv", xmlPet{}, nil)
+	var unsupported *UnsupportedMediaTypeError
+	assert.ErrorAs(t, err, &unsupported)
+	assert.Empty(t, w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Type"))
+}
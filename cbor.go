@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// cborContentType is the media type of CBOR bodies, defined by RFC 8949.
+const cborContentType = "application/cbor"
+
+func init() {
+	h := &codec.CborHandle{}
+	// Maps decode as they do from JSON, so that values decoded into
+	// interface{} may be encoded as JSON too.
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	RegisterBodyCodec(cborContentType, handleCodec{h})
+}
+
+// handleCodec is a BodyCodec of a codec.Handle, such as the default codec of
+// CBOR bodies. Struct fields are named by their codec tag, falling back to
+// their json tag, as they are for JSON bodies.
+type handleCodec struct {
+	h codec.Handle
+}
+
+func (c handleCodec) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, c.h).Encode(v)
+	return data, err
+}
+
+func (c handleCodec) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, c.h).Decode(v)
+}
+
+// BindCBOR decodes the CBOR body of r into dst, as BindCBORWithOptions does.
+func BindCBOR(r *http.Request, dst interface{}) error {
+	return BindCBORWithOptions(r, dst, BodyOptions{})
+}
+
+// BindCBORWithOptions decodes the CBOR body of r, as declared by the
+// application/cbor media type, into dst with the codec registered for it,
+// which may be replaced with RegisterBodyCodec. When it's decoded, a
+// Validatable dst is validated, as it is by BindXMLWithOptions.
+func BindCBORWithOptions(r *http.Request, dst interface{}, opts BodyOptions) error {
+	return bindCodecBody(r, dst, cborContentType, opts)
+}
+
+// WriteCBOR writes a response with the given status and headers, whose body
+// is v encoded as CBOR, as WriteJSON does for JSON. The Content-Type is
+// application/cbor, unless headers set another.
+func WriteCBOR(w http.ResponseWriter, status int, v interface{}, headers http.Header) error {
+	return writeCodecBody(w, status, v, headers, cborContentType)
+}
+
+// MarshalCBOR encodes v as CBOR, such as for the body of a request.
+func MarshalCBOR(v interface{}) ([]byte, error) {
+	return marshalCodecBody(v, cborContentType)
+}
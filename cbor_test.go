@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type cborReading struct {
+	Sensor string     `json:"sensor"`
+	Value  float64    `json:"value"`
+	Day    types.Date `json:"day"`
+	Tags   []string   `json:"tags,omitempty"`
+}
+
+func TestBindCBOR(t *testing.T) {
+	// {"name": "Rex"}, as encoded by another implementation.
+	var pet xmlPet
+	body := []byte{0xa1, 0x64, 'n', 'a', 'm', 'e', 0x63, 'R', 'e', 'x'}
+	require.NoError(t, BindCBOR(newBodyRequest("application/cbor", body), &pet))
+	assert.Equal(t, xmlPet{Name: "Rex"}, pet)
+
+	body = []byte{0xa1, 0x62, 'i', 'd', 0x01}
+	err := BindCBOR(newBodyRequest("application/cbor", body), &xmlPet{})
+	assert.EqualError(t, err, "validation failed: name is required")
+
+	err = BindCBOR(newBodyRequest("application/cbor", []byte{0xa1, 0x64}), &xmlPet{})
+	assert.ErrorContains(t, err, "error decoding CBOR body: ")
+
+	err = BindCBORWithOptions(newBodyRequest("application/cbor", bytes.Repeat([]byte{0x60}, 100)), &xmlPet{}, BodyOptions{MaxBodySize: 64})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.EqualError(t, err, "error reading CBOR body: request body exceeds the limit MaxBodySize=64")
+
+	assert.Error(t, BindCBOR(newBodyRequest("application/cbor", body), xmlPet{}))
+}
+
+func TestWriteCBOR(t *testing.T) {
+	reading := cborReading{
+		Sensor: "t1",
+		Value:  21.5,
+		Day:    types.Date{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		Tags:   []string{"indoor"},
+	}
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteCBOR(w, http.StatusOK, reading, nil))
+	assert.Equal(t, "application/cbor", w.Header().Get("Content-Type"))
+
+	var bound cborReading
+	require.NoError(t, BindCBOR(newBodyRequest("application/cbor", w.Body.Bytes()), &bound))
+	assert.Equal(t, reading, bound)
+
+	data, err := MarshalCBOR(xmlPet{ID: 1, Name: "Rex"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xa2, 0x62, 'i', 'd', 0x01, 0x64, 'n', 'a', 'm', 'e', 0x63, 'R', 'e', 'x'}, data)
+}
@@ -1,6 +1,10 @@
 package runtime
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,148 +18,906 @@ import (
 	"github.com/oapi-codegen/runtime/types"
 )
 
-func marshalDeepObject(in interface{}, path []string) ([]string, error) {
-	var result []string
+// DefaultDeepObjectMaxDepth is the nesting depth beyond which
+// MarshalDeepObject gives up, unless overridden by
+// MarshalDeepObjectOptions.MaxDepth.
+const DefaultDeepObjectMaxDepth = 32
 
-	switch t := in.(type) {
-	case []interface{}:
-		// For the array, we will use numerical subscripts of the form [x],
-		// in the same order as the array.
-		for i, iface := range t {
-			newPath := append(path, strconv.Itoa(i))
-			fields, err := marshalDeepObject(iface, newPath)
-			if err != nil {
-				return nil, fmt.Errorf("error traversing array: %w", err)
+// DeepObjectArrayStyle selects the notation MarshalDeepObject uses for the
+// elements of arrays.
+type DeepObjectArrayStyle int
+
+const (
+	// DeepObjectArrayIndexed uses numeric subscripts: p[as][0]=a&p[as][1]=b
+	DeepObjectArrayIndexed DeepObjectArrayStyle = iota
+	// DeepObjectArrayBrackets uses empty subscripts, as expected by PHP and
+	// Rails: p[as][]=a&p[as][]=b. It can only represent arrays of primitive
+	// values.
+	DeepObjectArrayBrackets
+	// DeepObjectArrayRepeat repeats the field for each element:
+	// p[as]=a&p[as]=b. It can only represent arrays of primitive values,
+	// which are only bound back under DeepObjectDuplicateAppend.
+	DeepObjectArrayRepeat
+)
+
+// MarshalDeepObjectOptions defines optional arguments for MarshalDeepObjectWithOptions
+type MarshalDeepObjectOptions struct {
+	// MaxDepth limits how deeply nested the input may be. Zero means
+	// DefaultDeepObjectMaxDepth.
+	MaxDepth int
+	// ArrayStyle is the notation used for array elements.
+	ArrayStyle DeepObjectArrayStyle
+}
+
+func MarshalDeepObject(i interface{}, paramName string) (string, error) {
+	return MarshalDeepObjectWithOptions(i, paramName, MarshalDeepObjectOptions{})
+}
+
+// MarshalDeepObjectWithOptions marshals i as a deepObject style parameter
+// named paramName, honoring the given options.
+func MarshalDeepObjectWithOptions(i interface{}, paramName string, opts MarshalDeepObjectOptions) (_ string, err error) {
+	defer recoverPanic(&err, "error marshaling parameter '%s'", paramName)
+	e := &deepObjectEncoder{
+		opts:     opts,
+		visiting: make(map[uintptr]struct{}),
+	}
+	if e.opts.MaxDepth <= 0 {
+		e.opts.MaxDepth = DefaultDeepObjectMaxDepth
+	}
+	fields, err := e.marshal(reflect.ValueOf(i), nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("error traversing object: %w", err)
+	}
+
+	// Prefix the param name to each subscripted field.
+	for i := range fields {
+		fields[i] = paramName + fields[i]
+	}
+	return strings.Join(fields, "&"), nil
+}
+
+// deepObjectEncoder walks a Go value and produces deepObject fields. It
+// follows the same field naming rules as encoding/json, and defers to JSON
+// for any value which knows how to marshal itself.
+type deepObjectEncoder struct {
+	opts MarshalDeepObjectOptions
+	// visiting holds the pointers and maps on the path to the value being
+	// marshaled, so that we can detect cycles.
+	visiting map[uintptr]struct{}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// marshal marshals v at the given path. depth counts the objects and arrays
+// v is nested in, which the path doesn't when elements are repeated fields.
+func (e *deepObjectEncoder) marshal(v reflect.Value, path []string, depth int) ([]string, error) {
+	if depth > e.opts.MaxDepth {
+		return nil, fmt.Errorf("exceeded maximum depth of %d", e.opts.MaxDepth)
+	}
+	if !v.IsValid() {
+		return e.leaf(path, nil), nil
+	}
+
+	// Types of registered formats are formatted by their codec.
+	if s, ok, err := formatValue(v); ok {
+		if err != nil {
+			return nil, err
+		}
+		return e.leaf(path, s), nil
+	}
+
+	// Values which marshal themselves are converted to JSON, and the
+	// resulting generic structure is walked instead.
+	if m, ok := jsonMarshaler(v); ok {
+		return e.marshalJSON(m, path, depth)
+	}
+
+	// Primitive values with a String method, such as enums, serialize as
+	// their intended token rather than their underlying value.
+	if s, ok := primitiveStringer(v); ok {
+		return e.leaf(path, s.String()), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return e.leaf(path, nil), nil
+		}
+		if v.Kind() == reflect.Ptr {
+			ptr := v.Pointer()
+			if _, found := e.visiting[ptr]; found {
+				return nil, fmt.Errorf("encountered a cycle via %s", v.Type())
 			}
-			result = append(result, fields...)
+			e.visiting[ptr] = struct{}{}
+			defer delete(e.visiting, ptr)
 		}
-	case map[string]interface{}:
-		// For a map, each key (field name) becomes a member of the path, and
-		// we recurse. First, sort the keys.
-		keys := make([]string, len(t))
-		i := 0
-		for k := range t {
-			keys[i] = k
-			i++
+		return e.marshal(v.Elem(), path, depth)
+	case reflect.Struct:
+		fields := make(map[string]reflect.Value)
+		e.structFields(v, fields)
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
 		}
 		sort.Strings(keys)
+		return e.marshalFields(path, keys, fields, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			return e.leaf(path, nil), nil
+		}
+		ptr := v.Pointer()
+		if _, found := e.visiting[ptr]; found {
+			return nil, fmt.Errorf("encountered a cycle via %s", v.Type())
+		}
+		e.visiting[ptr] = struct{}{}
+		defer delete(e.visiting, ptr)
 
-		// Now, for each key, we recursively marshal it.
-		for _, k := range keys {
-			newPath := append(path, k)
-			fields, err := marshalDeepObject(t[k], newPath)
+		// For a map, each key (field name) becomes a member of the path, and
+		// we recurse, in sorted key order.
+		fields := make(map[string]reflect.Value, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := mapKeyString(iter.Key())
 			if err != nil {
 				return nil, fmt.Errorf("error traversing map: %w", err)
 			}
+			fields[k] = iter.Value()
+		}
+		keys := make([]string, 0, len(fields))
+		for k := range fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return e.marshalFields(path, keys, fields, depth)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.IsNil() {
+				return e.leaf(path, nil), nil
+			}
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				// Like encoding/json, byte slices are base64 encoded.
+				return e.leaf(path, base64.StdEncoding.EncodeToString(v.Bytes())), nil
+			}
+		}
+		// For the array, we will use subscripts in the configured notation,
+		// in the same order as the array.
+		var result []string
+		for i := 0; i < v.Len(); i++ {
+			var elemPath []string
+			switch e.opts.ArrayStyle {
+			case DeepObjectArrayIndexed:
+				elemPath = appendPath(path, strconv.Itoa(i))
+			case DeepObjectArrayBrackets:
+				elemPath = appendPath(path, "")
+			case DeepObjectArrayRepeat:
+				elemPath = path
+			default:
+				return nil, fmt.Errorf("unsupported array style %d", e.opts.ArrayStyle)
+			}
+			if e.opts.ArrayStyle != DeepObjectArrayIndexed && nestsSubscripts(v.Index(i)) {
+				return nil, errNestedArrayElement
+			}
+			fields, err := e.marshal(v.Index(i), elemPath, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("error traversing array: %w", err)
+			}
+			if e.opts.ArrayStyle != DeepObjectArrayIndexed {
+				// Values which marshal themselves as JSON can only be
+				// told apart once they're marshaled.
+				prefix := "[" + strings.Join(elemPath, "][") + "]="
+				for _, f := range fields {
+					if !strings.HasPrefix(f, prefix) {
+						return nil, errNestedArrayElement
+					}
+				}
+			}
 			result = append(result, fields...)
 		}
+		return result, nil
+	case reflect.String:
+		return e.leaf(path, v.String()), nil
+	case reflect.Bool:
+		return e.leaf(path, strconv.FormatBool(v.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return e.leaf(path, strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.leaf(path, strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32:
+		return e.leaf(path, strconv.FormatFloat(v.Float(), 'f', -1, 32)), nil
+	case reflect.Float64:
+		return e.leaf(path, strconv.FormatFloat(v.Float(), 'f', -1, 64)), nil
 	default:
-		// Now, for a concrete value, we will turn the path elements
-		// into a deepObject style set of subscripts. [a, b, c] turns into
-		// [a][b][c]
-		prefix := "[" + strings.Join(path, "][") + "]"
-		result = []string{
-			prefix + fmt.Sprintf("=%v", t),
+		return nil, fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// errNestedArrayElement is returned for arrays of objects or arrays, whose
+// elements have no subscripts of their own in the Repeat and Brackets
+// styles, to tell which element the subscripts of their fields belong to.
+var errNestedArrayElement = errors.New("arrays of objects or arrays can only be marshaled with indexed subscripts")
+
+// nestsSubscripts tells whether v is an object or an array, which marshals
+// to fields with subscripts of their own, rather than to a single value.
+// Values which marshal themselves as JSON are only known once they're
+// marshaled.
+func nestsSubscripts(v reflect.Value) bool {
+	for {
+		if !v.IsValid() {
+			return false
+		}
+		if _, ok, _ := formatValue(v); ok {
+			return false
+		}
+		if _, ok := jsonMarshaler(v); ok {
+			return false
+		}
+		if _, ok := primitiveStringer(v); ok {
+			return false
+		}
+		if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+			break
+		}
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Array:
+		return true
+	case reflect.Map, reflect.Slice:
+		return !v.IsNil() && !(v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8)
+	default:
+		return false
+	}
+}
+
+func (e *deepObjectEncoder) marshalFields(path []string, keys []string, fields map[string]reflect.Value, depth int) ([]string, error) {
+	var result []string
+	for _, k := range keys {
+		f, err := e.marshal(fields[k], appendPath(path, k), depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("error traversing field '%s': %w", k, err)
 		}
+		result = append(result, f...)
 	}
 	return result, nil
 }
 
-func MarshalDeepObject(i interface{}, paramName string) (string, error) {
-	// We're going to marshal to JSON and unmarshal into an interface{},
-	// which will use the json pkg to deal with all the field annotations. We
-	// can then walk the generic object structure to produce a deepObject. This
-	// isn't efficient and it would be more efficient to reflect on our own,
-	// but it's complicated, error-prone code.
-	buf, err := json.Marshal(i)
+// marshalJSON marshals m to JSON, and walks the resulting generic structure.
+func (e *deepObjectEncoder) marshalJSON(m interface{}, path []string, depth int) ([]string, error) {
+	buf, err := json.Marshal(m)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal input to JSON: %w", err)
+		return nil, fmt.Errorf("failed to marshal input to JSON: %w", err)
 	}
+	d := json.NewDecoder(bytes.NewReader(buf))
+	d.UseNumber()
 	var i2 interface{}
-	err = json.Unmarshal(buf, &i2)
-	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if err = d.Decode(&i2); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return e.marshal(reflect.ValueOf(i2), path, depth)
+}
+
+// structFields collects the fields of the struct v under the names which
+// encoding/json would give them, skipping those it would omit. Fields of
+// embedded structs are promoted, unless a field of the same name exists at
+// a shallower level.
+func (e *deepObjectEncoder) structFields(v reflect.Value, fields map[string]reflect.Value) {
+	t := v.Type()
+	var embedded []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagParts := strings.Split(tag, ",")
+		name := tagParts[0]
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, v.Field(i))
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		f := v.Field(i)
+		if hasTagOption(tagParts, "omitempty") && isEmptyValue(f) {
+			continue
+		}
+		if hasTagOption(tagParts, "string") {
+			f = quotedStringValue(f)
+		}
+		fields[name] = f
+	}
+
+	for _, f := range embedded {
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() {
+				continue
+			}
+			f = f.Elem()
+		}
+		promoted := make(map[string]reflect.Value)
+		e.structFields(f, promoted)
+		for k, pf := range promoted {
+			if _, found := fields[k]; !found {
+				fields[k] = pf
+			}
+		}
+	}
+}
+
+// jsonMarshaler returns the value as a json.Marshaler or
+// encoding.TextMarshaler, if it is one, in the same way encoding/json would
+// pick these interfaces up, including pointer receivers on addressable values.
+func jsonMarshaler(v reflect.Value) (interface{}, bool) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, false
+	}
+	t := v.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return v.Interface(), true
+	}
+	if v.CanAddr() {
+		pt := reflect.PtrTo(t)
+		if pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType) {
+			return v.Addr().Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// primitiveStringer returns the value as a fmt.Stringer if it is one, and is
+// of a primitive kind. Containers are never treated as Stringers, since
+// their String method is usually meant for debugging rather than
+// serialization.
+func primitiveStringer(v reflect.Value) (fmt.Stringer, bool) {
+	switch v.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+	default:
+		return nil, false
+	}
+	if !v.CanInterface() {
+		return nil, false
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s, true
 	}
-	fields, err := marshalDeepObject(i2, nil)
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// quotedStringValue applies the ",string" json tag option, which only
+// affects strings, since numbers and booleans look the same in a deepObject
+// either way. String values are replaced by their JSON encoding.
+func quotedStringValue(v reflect.Value) reflect.Value {
+	s := v
+	if s.Kind() == reflect.Ptr {
+		if s.IsNil() {
+			return v
+		}
+		s = s.Elem()
+	}
+	if s.Kind() != reflect.String {
+		return v
+	}
+	buf, err := json.Marshal(s.String())
 	if err != nil {
-		return "", fmt.Errorf("error traversing JSON structure: %w", err)
+		return v
 	}
+	return reflect.ValueOf(string(buf))
+}
 
-	// Prefix the param name to each subscripted field.
-	for i := range fields {
-		fields[i] = paramName + fields[i]
+// mapKeyString converts a map key into a field name, as encoding/json does.
+func mapKeyString(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		buf, err := tm.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(buf), nil
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", k.Type())
+}
+
+// isEmptyValue reports whether v is empty, as defined by the json
+// "omitempty" tag option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
+
+// leaf turns the path elements into a deepObject style set of subscripts,
+// [a, b, c] turns into [a][b][c], and assigns the value.
+func (e *deepObjectEncoder) leaf(path []string, value interface{}) []string {
+	prefix := "[" + strings.Join(path, "][") + "]"
+	return []string{
+		prefix + fmt.Sprintf("=%v", value),
 	}
-	return strings.Join(fields, "&"), nil
 }
 
 type fieldOrValue struct {
 	fields map[string]fieldOrValue
 	value  string
+	// values holds every value of a leaf given more than once, under the
+	// DeepObjectDuplicateAppend policy.
+	values []string
 }
 
-func (f *fieldOrValue) appendPathValue(path []string, value string) {
+func (f *fieldOrValue) appendPathValue(path []string, key string, value string, policy DeepObjectDuplicatePolicy) error {
 	fieldName := path[0]
+	pv, found := f.fields[fieldName]
+	if found && (pv.fields == nil) != (len(path) == 1) {
+		return fmt.Errorf("%s is given both as a value and as an object", key)
+	}
+
 	if len(path) == 1 {
-		f.fields[fieldName] = fieldOrValue{value: value}
-		return
+		if !found {
+			f.fields[fieldName] = fieldOrValue{value: value}
+			return nil
+		}
+		if fieldName == "" {
+			// An empty subscript, as in p[as][]=a&p[as][]=b, is meant to
+			// be repeated, once for each element.
+			policy = DeepObjectDuplicateAppend
+		}
+		switch policy {
+		case DeepObjectDuplicateFirstWins:
+		case DeepObjectDuplicateLastWins:
+			pv.value = value
+		case DeepObjectDuplicateAppend:
+			if pv.values == nil {
+				pv.values = []string{pv.value}
+			}
+			pv.values = append(pv.values, value)
+		default:
+			return &DuplicateKeyError{Key: key, Values: append(pv.leafValues(), value)}
+		}
+		f.fields[fieldName] = pv
+		return nil
 	}
 
-	pv, found := f.fields[fieldName]
 	if !found {
 		pv = fieldOrValue{
 			fields: make(map[string]fieldOrValue),
 		}
 		f.fields[fieldName] = pv
 	}
-	pv.appendPathValue(path[1:], value)
+	return pv.appendPathValue(path[1:], key, value, policy)
 }
 
-func makeFieldOrValue(paths [][]string, values []string) fieldOrValue {
+// leafValues returns all the values of a leaf.
+func (f fieldOrValue) leafValues() []string {
+	if f.values != nil {
+		return f.values
+	}
+	return []string{f.value}
+}
 
-	f := fieldOrValue{
-		fields: make(map[string]fieldOrValue),
+// toInterface converts the tree into generic Go values, with nested fields
+// as map[string]interface{} and leaves as strings, or []interface{} if they
+// were given more than once.
+func (f fieldOrValue) toInterface() interface{} {
+	if f.fields == nil {
+		if f.values != nil {
+			values := make([]interface{}, len(f.values))
+			for i, v := range f.values {
+				values[i] = v
+			}
+			return values
+		}
+		return f.value
 	}
-	for i := range paths {
-		path := paths[i]
-		value := values[i]
-		f.appendPathValue(path, value)
+	m := make(map[string]interface{}, len(f.fields))
+	for k, v := range f.fields {
+		m[k] = v.toInterface()
 	}
-	return f
+	return m
+}
+
+// DeepObjectDuplicatePolicy selects what UnmarshalDeepObject does when the
+// same field is given more than once.
+type DeepObjectDuplicatePolicy int
+
+const (
+	// DeepObjectDuplicateError fails with a *DuplicateKeyError.
+	DeepObjectDuplicateError DeepObjectDuplicatePolicy = iota
+	// DeepObjectDuplicateFirstWins keeps the first value.
+	DeepObjectDuplicateFirstWins
+	// DeepObjectDuplicateLastWins keeps the last value.
+	DeepObjectDuplicateLastWins
+	// DeepObjectDuplicateAppend keeps all the values, in order, which binds
+	// them to a slice, as in p[as]=a&p[as]=b. The values of empty
+	// subscripts, as in p[as][]=a&p[as][]=b, are kept so under every policy.
+	DeepObjectDuplicateAppend
+)
+
+// DuplicateKeyError is returned when a deepObject field is given more than
+// once under the DeepObjectDuplicateError policy.
+type DuplicateKeyError struct {
+	// Key is the full name of the field, such as "p[o][id]".
+	Key string
+	// Values are all the values given for the field.
+	Values []string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("%s has multiple values", e.Key)
+}
+
+// UnmarshalDeepObjectOptions defines optional arguments for UnmarshalDeepObjectWithOptions
+type UnmarshalDeepObjectOptions struct {
+	// DuplicatePolicy decides what happens when the same field is given
+	// more than once.
+	DuplicatePolicy DeepObjectDuplicatePolicy
+	// CaseInsensitive matches subscript keys to struct fields regardless of
+	// case when there is no exact match, like encoding/json does.
+	CaseInsensitive bool
+	// LenientBool accepts "on"/"off", "yes"/"no" and similar spellings when
+	// binding booleans, in addition to those understood by strconv.ParseBool.
+	LenientBool bool
+	// TimeParsers are the formats accepted for time.Time values, in place of
+	// the package's TimeParsers.
+	TimeParsers []TimeParser
 }
 
 func UnmarshalDeepObject(dst interface{}, paramName string, params url.Values) error {
+	return UnmarshalDeepObjectWithOptions(dst, paramName, params, UnmarshalDeepObjectOptions{})
+}
+
+// UnmarshalDeepObjectWithOptions unmarshals the deepObject parameter paramName
+// found in params into dst, honoring the given options. Destinations which
+// are Validatable are validated once bound.
+func UnmarshalDeepObjectWithOptions(dst interface{}, paramName string, params url.Values, opts UnmarshalDeepObjectOptions) (err error) {
+	defer recoverPanic(&err, "error binding parameter '%s'", paramName)
+	if err := checkDestination(paramName, dst, false); err != nil {
+		return err
+	}
+	d := &deepObjectDecoder{
+		opts:      opts,
+		paramName: paramName,
+	}
+	if err := d.unmarshal(dst, params); err != nil {
+		return err
+	}
+	return validate(paramName, dst)
+}
+
+func (d *deepObjectDecoder) unmarshal(dst interface{}, params url.Values) error {
+	paramName := d.paramName
+
 	// Params are all the query args, so we need those that look like
-	// "paramName["...
-	var fieldNames []string
-	var fieldValues []string
-	searchStr := paramName + "["
-	for pName, pValues := range params {
-		if strings.HasPrefix(pName, searchStr) {
-			// trim the parameter name from the full name.
-			pName = pName[len(paramName):]
-			fieldNames = append(fieldNames, pName)
-			if len(pValues) != 1 {
-				return fmt.Errorf("%s has multiple values", pName)
+	// "paramName[", and reconstruct their subscript paths and values. We
+	// go in sorted order, so duplicates are resolved deterministically.
+	keys := make([]string, 0, len(params))
+	for pName := range params {
+		keys = append(keys, pName)
+	}
+	sort.Strings(keys)
+
+	fieldPaths := fieldOrValue{
+		fields: make(map[string]fieldOrValue),
+	}
+	for _, pName := range keys {
+		path, err := deepObjectPath(pName, paramName)
+		if err == nil && path == nil {
+			continue
+		}
+		if err == nil {
+			for _, value := range params[pName] {
+				err = fieldPaths.appendPathValue(path, pName, value, d.opts.DuplicatePolicy)
+				if err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			if d.partial {
+				d.skipped = append(d.skipped, SkippedField{Field: pName, Err: err})
+				continue
 			}
-			fieldValues = append(fieldValues, pValues[0])
+			return err
 		}
 	}
 
-	// Now, for each field, reconstruct its subscript path and value
-	paths := make([][]string, len(fieldNames))
-	for i, path := range fieldNames {
-		path = strings.TrimLeft(path, "[")
-		path = strings.TrimRight(path, "]")
-		paths[i] = strings.Split(path, "][")
+	err := d.assignPathValues(dst, fieldPaths, nil)
+	if err != nil {
+		return fmt.Errorf("error assigning value to destination: %w", err)
+	}
+
+	return nil
+}
+
+// deepObjectPath splits a query key of the form paramName[a][b] into its
+// subscripts, [a, b]. The key must start with the exact parameter name,
+// either literally or query escaped, followed by the opening bracket of the
+// first subscript, so that names which contain brackets themselves, or which
+// share a prefix with another parameter, are never mis-split. It returns
+// nil if the key belongs to another parameter, and an error if it belongs to
+// this one, but its subscripts are malformed.
+func deepObjectPath(key, paramName string) ([]string, error) {
+	var rest string
+	if strings.HasPrefix(key, paramName+"[") {
+		rest = key[len(paramName):]
+	} else if escaped := url.QueryEscape(paramName); escaped != paramName && strings.HasPrefix(key, escaped+"[") {
+		rest = key[len(escaped):]
+	} else {
+		return nil, nil
 	}
 
-	fieldPaths := makeFieldOrValue(paths, fieldValues)
-	err := assignPathValues(dst, fieldPaths)
+	var path []string
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 || strings.IndexByte(rest[1:end], '[') >= 0 {
+			return nil, fmt.Errorf("%s has malformed subscripts", key)
+		}
+		path = append(path, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return path, nil
+}
+
+// MarshalObjectForm marshals an object parameter with style=form and
+// explode=false, the unexploded counterpart of a deepObject, where
+// properties and values alternate in a comma separated list:
+// p=role,admin,firstName,Alex. Since the format is flat, properties must be
+// primitive values. Keys and values are query escaped.
+func MarshalObjectForm(i interface{}, paramName string) (_ string, err error) {
+	defer recoverPanic(&err, "error marshaling parameter '%s'", paramName)
+	e := &deepObjectEncoder{
+		opts:     MarshalDeepObjectOptions{MaxDepth: DefaultDeepObjectMaxDepth},
+		visiting: make(map[uintptr]struct{}),
+	}
+	fields, err := e.marshal(reflect.ValueOf(i), nil, 0)
 	if err != nil {
+		return "", fmt.Errorf("error traversing object: %w", err)
+	}
+
+	parts := make([]string, 0, 2*len(fields))
+	for _, f := range fields {
+		// Each field looks like [key]=value, anything with more subscripts
+		// is nested, and can't be represented.
+		kv := strings.SplitN(f, "=", 2)
+		key := strings.TrimSuffix(strings.TrimPrefix(kv[0], "["), "]")
+		if key == "" || strings.Contains(key, "][") {
+			return "", fmt.Errorf("parameter '%s' must be a flat object to use form style", paramName)
+		}
+		parts = append(parts, url.QueryEscape(key), url.QueryEscape(kv[1]))
+	}
+	return paramName + "=" + strings.Join(parts, ","), nil
+}
+
+// UnmarshalObjectForm binds an object parameter with style=form and
+// explode=false, as produced by MarshalObjectForm, into dst.
+func UnmarshalObjectForm(dst interface{}, paramName string, params url.Values) (err error) {
+	defer recoverPanic(&err, "error binding parameter '%s'", paramName)
+	if err := checkDestination(paramName, dst, false); err != nil {
+		return err
+	}
+	values, found := params[paramName]
+	if !found {
+		return nil
+	}
+	if len(values) != 1 {
+		return fmt.Errorf("parameter '%s' is not exploded, but is specified multiple times", paramName)
+	}
+	parts := strings.Split(values[0], ",")
+	if len(parts)%2 != 0 {
+		return fmt.Errorf("parameter '%s' has invalid format, property/values need to be pairs", paramName)
+	}
+
+	f := fieldOrValue{
+		fields: make(map[string]fieldOrValue, len(parts)/2),
+	}
+	for i := 0; i < len(parts); i += 2 {
+		f.fields[parts[i]] = fieldOrValue{value: parts[i+1]}
+	}
+	d := &deepObjectDecoder{paramName: paramName}
+	if err := d.assignPathValues(dst, f, nil); err != nil {
 		return fmt.Errorf("error assigning value to destination: %w", err)
 	}
+	return validate(paramName, dst)
+}
 
-	return nil
+// MarshalDottedForm marshals an object parameter with style=form and
+// explode=true, flattening nested objects into dotted keys, which the form
+// style can't otherwise represent: role=admin&address.city=Paris. Arrays of
+// primitives repeat their key, as with any exploded form parameter. Keys and
+// values are query escaped.
+func MarshalDottedForm(i interface{}, paramName string) (_ string, err error) {
+	defer recoverPanic(&err, "error marshaling parameter '%s'", paramName)
+	e := &deepObjectEncoder{
+		opts: MarshalDeepObjectOptions{
+			MaxDepth:   DefaultDeepObjectMaxDepth,
+			ArrayStyle: DeepObjectArrayRepeat,
+		},
+		visiting: make(map[uintptr]struct{}),
+	}
+	fields, err := e.marshal(reflect.ValueOf(i), nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("error traversing object: %w", err)
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, f := range fields {
+		// Each field looks like [a][b]=value, which becomes a.b=value.
+		end := strings.Index(f, "]=")
+		if !strings.HasPrefix(f, "[") || end <= 1 {
+			return "", fmt.Errorf("parameter '%s' must be an object to use dotted keys", paramName)
+		}
+		path := strings.Split(f[1:end], "][")
+		for _, p := range path {
+			if p == "" || strings.Contains(p, ".") {
+				return "", fmt.Errorf("parameter '%s' has key '%s' which can't be dotted", paramName, p)
+			}
+		}
+		parts = append(parts, url.QueryEscape(strings.Join(path, "."))+"="+url.QueryEscape(f[end+2:]))
+	}
+	return strings.Join(parts, "&"), nil
+}
+
+// UnmarshalDottedForm binds an object parameter with style=form and
+// explode=true, as produced by MarshalDottedForm, into dst. Since exploded
+// form parameters aren't named in the query, only the keys which start with
+// a field of dst are bound; when dst is a map, every key is.
+func UnmarshalDottedForm(dst interface{}, paramName string, params url.Values) (err error) {
+	defer recoverPanic(&err, "error binding parameter '%s'", paramName)
+	if err := checkDestination(paramName, dst, false); err != nil {
+		return err
+	}
+	var fieldMap map[string]int
+	if t := reflect.Indirect(reflect.ValueOf(dst)).Type(); t.Kind() == reflect.Struct {
+		var err error
+		fieldMap, err = fieldIndicesByJSONTag(reflect.New(t).Elem().Interface())
+		if err != nil {
+			return fmt.Errorf("failed enumerating fields: %w", err)
+		}
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	f := fieldOrValue{
+		fields: make(map[string]fieldOrValue),
+	}
+	for _, key := range keys {
+		path := strings.Split(key, ".")
+		if fieldMap != nil {
+			if _, found := fieldMap[path[0]]; !found {
+				continue
+			}
+		}
+		for _, value := range params[key] {
+			if err := f.appendPathValue(path, key, value, DeepObjectDuplicateAppend); err != nil {
+				return fmt.Errorf("parameter '%s': %w", paramName, err)
+			}
+		}
+	}
+	if len(f.fields) == 0 {
+		return nil
+	}
+
+	d := &deepObjectDecoder{paramName: paramName}
+	if err := d.assignPathValues(dst, f, nil); err != nil {
+		return fmt.Errorf("error assigning value to destination: %w", err)
+	}
+	return validate(paramName, dst)
+}
+
+// SkippedField describes a deepObject field which UnmarshalDeepObjectPartial
+// could not bind.
+type SkippedField struct {
+	// Field is the full name of the field, as it appeared in the query,
+	// such as "p[o][id]".
+	Field string
+	// Err is the reason the field was skipped.
+	Err error
+}
+
+func (s SkippedField) Error() string {
+	return fmt.Sprintf("%s: %s", s.Field, s.Err)
+}
+
+func (s SkippedField) Unwrap() error {
+	return s.Err
+}
+
+// UnmarshalDeepObjectPartial works like UnmarshalDeepObjectWithOptions, but
+// rather than failing on the first field that can't be bound, it binds
+// everything it can, and returns the fields it had to skip along with the
+// reason. An error is only returned when nothing could be bound at all, for
+// example, when the destination is of an unsupported type.
+func UnmarshalDeepObjectPartial(dst interface{}, paramName string, params url.Values, opts UnmarshalDeepObjectOptions) (_ []SkippedField, err error) {
+	defer recoverPanic(&err, "error binding parameter '%s'", paramName)
+	if err := checkDestination(paramName, dst, false); err != nil {
+		return nil, err
+	}
+	d := &deepObjectDecoder{
+		opts:      opts,
+		paramName: paramName,
+		partial:   true,
+	}
+	if err := d.unmarshal(dst, params); err != nil {
+		return d.skipped, err
+	}
+	return d.skipped, validate(paramName, dst)
+}
+
+// deepObjectDecoder holds the state of a single deepObject unmarshal.
+type deepObjectDecoder struct {
+	opts      UnmarshalDeepObjectOptions
+	paramName string
+	// In partial mode, fields which fail to bind are recorded in skipped,
+	// rather than aborting the whole unmarshal.
+	partial bool
+	skipped []SkippedField
+}
+
+// skip records a field at path which could not be bound. It returns false
+// when we aren't in partial mode, and the caller should fail instead.
+func (d *deepObjectDecoder) skip(path []string, err error) bool {
+	if !d.partial {
+		return false
+	}
+	d.skipped = append(d.skipped, SkippedField{
+		Field: d.paramName + "[" + strings.Join(path, "][") + "]",
+		Err:   err,
+	})
+	return true
+}
+
+// appendPath returns a copy of path with elem appended, so that sibling
+// fields never share a backing array.
+func appendPath(path []string, elem string) []string {
+	newPath := make([]string, len(path)+1)
+	copy(newPath, path)
+	newPath[len(path)] = elem
+	return newPath
 }
 
 // This returns a field name, either using the variable name, or the json
@@ -174,6 +936,44 @@ func getFieldName(f reflect.StructField) string {
 	return n
 }
 
+// hasJSONStringOption reports whether the field's json tag carries the
+// ",string" option.
+func hasJSONStringOption(f reflect.StructField) bool {
+	tag, found := f.Tag.Lookup("json")
+	if !found {
+		return false
+	}
+	return hasTagOption(strings.Split(tag, ","), "string")
+}
+
+// hasTagOption reports whether the options following the name in a split
+// struct tag include opt.
+func hasTagOption(tagParts []string, opt string) bool {
+	for _, o := range tagParts[1:] {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// unquoteStringOption undoes the extra quoting that encoding/json applies to
+// string fields tagged with ",string". Numeric and bool fields are already
+// carried as plain text in a deepObject, so they are left untouched.
+func unquoteStringOption(t reflect.Type, value string) (string, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.String {
+		return value, nil
+	}
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return "", fmt.Errorf("invalid use of ,string struct tag, trying to unmarshal %q into %v", value, t)
+	}
+	return s, nil
+}
+
 // Create a map of field names that we'll see in the deepObject to reflect
 // field indices on the given type.
 func fieldIndicesByJSONTag(i interface{}) (map[string]int, error) {
@@ -186,90 +986,104 @@ func fieldIndicesByJSONTag(i interface{}) (map[string]int, error) {
 	fieldMap := make(map[string]int)
 	for i := 0; i < n; i++ {
 		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
 		fieldName := getFieldName(field)
 		fieldMap[fieldName] = i
 	}
 	return fieldMap, nil
 }
 
-func assignPathValues(dst interface{}, pathValues fieldOrValue) error {
+// lookupFieldIndex finds the field index for the given subscript key. An
+// exact match always wins; with caseInsensitive set, we fall back to the
+// first field whose name matches under case folding.
+func lookupFieldIndex(fieldMap map[string]int, key string, caseInsensitive bool) (int, bool) {
+	if i, found := fieldMap[key]; found {
+		return i, true
+	}
+	if !caseInsensitive {
+		return 0, false
+	}
+	found := false
+	index := 0
+	for name, i := range fieldMap {
+		if strings.EqualFold(name, key) && (!found || i < index) {
+			index = i
+			found = true
+		}
+	}
+	return index, found
+}
+
+func (d *deepObjectDecoder) assignPathValues(dst interface{}, pathValues fieldOrValue, path []string) error {
 	//t := reflect.TypeOf(dst)
 	v := reflect.ValueOf(dst)
 
 	iv := reflect.Indirect(v)
 	it := iv.Type()
 
+	// Types of registered formats are parsed by their codec.
+	if handled, err := bindFormat(pathValues.value, iv); handled {
+		return err
+	}
+
+	// Binders which aren't structs, such as named primitives, take
+	// precedence over binding by kind. Structs are handled below.
+	switch it.Kind() {
+	case reflect.Map, reflect.Interface, reflect.Slice, reflect.Struct, reflect.Ptr:
+	default:
+		if binder, ok := v.Interface().(Binder); ok {
+			return binder.Bind(pathValues.value)
+		}
+	}
+
 	switch it.Kind() {
 	case reflect.Map:
 		dstMap := reflect.MakeMap(iv.Type())
-		for key, value := range pathValues.fields {
-			dstKey := reflect.ValueOf(key)
+		for _, key := range sortedFieldOrValueKeys(pathValues.fields) {
+			value := pathValues.fields[key]
+			keyPath := appendPath(path, key)
+			dstKey, err := mapKeyValue(it.Key(), key)
+			if err != nil {
+				err = fmt.Errorf("error binding map key '%s': %w", key, err)
+				if d.skip(keyPath, err) {
+					continue
+				}
+				return err
+			}
 			dstVal := reflect.New(iv.Type().Elem())
-			err := assignPathValues(dstVal.Interface(), value)
+			err = d.assignPathValues(dstVal.Interface(), value, keyPath)
 			if err != nil {
+				if d.skip(keyPath, err) {
+					continue
+				}
 				return fmt.Errorf("error binding map: %w", err)
 			}
 			dstMap.SetMapIndex(dstKey, dstVal.Elem())
 		}
 		iv.Set(dstMap)
 		return nil
+	case reflect.Interface:
+		// Free-form values, such as map[string]interface{}, take on the
+		// shape of the subscripts: nested objects become nested maps, and
+		// leaves are kept as strings.
+		if it.NumMethod() != 0 {
+			return d.assignDiscriminated(iv, pathValues, path)
+		}
+		iv.Set(reflect.ValueOf(pathValues.toInterface()))
+		return nil
 	case reflect.Slice:
-		sliceLength := len(pathValues.fields)
-		dstSlice := reflect.MakeSlice(it, sliceLength, sliceLength)
-		err := assignSlice(dstSlice, pathValues)
+		err := d.assignSlice(iv, pathValues, path)
 		if err != nil {
 			return fmt.Errorf("error assigning slice: %w", err)
 		}
-		iv.Set(dstSlice)
 		return nil
 	case reflect.Struct:
-		// Some special types we care about are structs. Handle them
-		// here. They may be redefined, so we need to do some hoop
-		// jumping. If the types are aliased, we need to type convert
-		// the pointer, then set the value of the dereference pointer.
-
-		// We check to see if the object implements the Binder interface first.
-		if dst, isBinder := v.Interface().(Binder); isBinder {
-			return dst.Bind(pathValues.value)
-		}
-		// Then check the legacy types
-		if it.ConvertibleTo(reflect.TypeOf(types.Date{})) {
-			var date types.Date
-			var err error
-			date.Time, err = time.Parse(types.DateFormat, pathValues.value)
-			if err != nil {
-				return fmt.Errorf("invalid date format: %w", err)
-			}
-			dst := iv
-			if it != reflect.TypeOf(types.Date{}) {
-				// Types are aliased, convert the pointers.
-				ivPtr := iv.Addr()
-				aPtr := ivPtr.Convert(reflect.TypeOf(&types.Date{}))
-				dst = reflect.Indirect(aPtr)
-			}
-			dst.Set(reflect.ValueOf(date))
-		}
-		if it.ConvertibleTo(reflect.TypeOf(time.Time{})) {
-			var tm time.Time
-			var err error
-			tm, err = time.Parse(time.RFC3339Nano, pathValues.value)
-			if err != nil {
-				// Fall back to parsing it as a date.
-				// TODO: why is this marked as an ineffassign?
-				tm, err = time.Parse(types.DateFormat, pathValues.value) //nolint:ineffassign,staticcheck
-				if err != nil {
-					return fmt.Errorf("error parsing '%s' as RFC3339 or 2006-01-02 time: %s", pathValues.value, err)
-				}
-				return fmt.Errorf("invalid date format: %w", err)
-			}
-			dst := iv
-			if it != reflect.TypeOf(time.Time{}) {
-				// Types are aliased, convert the pointers.
-				ivPtr := iv.Addr()
-				aPtr := ivPtr.Convert(reflect.TypeOf(&time.Time{}))
-				dst = reflect.Indirect(aPtr)
-			}
-			dst.Set(reflect.ValueOf(tm))
+		// Some special types we care about are structs, but they are bound
+		// from a single value rather than from subscripted fields.
+		if handled, err := assignScalarStruct(v, pathValues.value, d.opts.TimeParsers); handled {
+			return err
 		}
 		fieldMap, err := fieldIndicesByJSONTag(iv.Interface())
 		if err != nil {
@@ -277,13 +1091,30 @@ func assignPathValues(dst interface{}, pathValues fieldOrValue) error {
 		}
 		for _, fieldName := range sortedFieldOrValueKeys(pathValues.fields) {
 			fieldValue := pathValues.fields[fieldName]
-			fieldIndex, found := fieldMap[fieldName]
+			fieldPath := appendPath(path, fieldName)
+			fieldIndex, found := lookupFieldIndex(fieldMap, fieldName, d.opts.CaseInsensitive)
 			if !found {
-				return fmt.Errorf("field [%s] is not present in destination object", fieldName)
+				err = fmt.Errorf("field [%s] is not present in destination object", fieldName)
+				if d.skip(fieldPath, err) {
+					continue
+				}
+				return err
 			}
 			field := iv.Field(fieldIndex)
-			err = assignPathValues(field.Addr().Interface(), fieldValue)
+			if fieldValue.fields == nil && hasJSONStringOption(it.Field(fieldIndex)) {
+				fieldValue.value, err = unquoteStringOption(field.Type(), fieldValue.value)
+				if err != nil {
+					if d.skip(fieldPath, err) {
+						continue
+					}
+					return fmt.Errorf("error assigning field [%s]: %w", fieldName, err)
+				}
+			}
+			err = d.assignPathValues(field.Addr().Interface(), fieldValue, fieldPath)
 			if err != nil {
+				if d.skip(fieldPath, err) {
+					continue
+				}
 				return fmt.Errorf("error assigning field [%s]: %w", fieldName, err)
 			}
 		}
@@ -295,36 +1126,31 @@ func assignPathValues(dst interface{}, pathValues fieldOrValue) error {
 		// interface.
 		dstVal := reflect.New(it.Elem())
 		dstPtr := dstVal.Interface()
-		err := assignPathValues(dstPtr, pathValues)
+		err := d.assignPathValues(dstPtr, pathValues, path)
 		iv.Set(dstVal)
 		return err
 	case reflect.Bool:
-		val, err := strconv.ParseBool(pathValues.value)
+		val, err := parseBool(pathValues.value, d.opts.LenientBool)
 		if err != nil {
 			return fmt.Errorf("expected a valid bool, got %s", pathValues.value)
 		}
 		iv.SetBool(val)
 		return nil
-	case reflect.Float32:
-		val, err := strconv.ParseFloat(pathValues.value, 32)
-		if err != nil {
-			return fmt.Errorf("expected a valid float, got %s", pathValues.value)
-		}
-		iv.SetFloat(val)
-		return nil
-	case reflect.Float64:
-		val, err := strconv.ParseFloat(pathValues.value, 64)
-		if err != nil {
-			return fmt.Errorf("expected a valid float, got %s", pathValues.value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		err := bindNumber(iv, pathValues.value)
+		var overflowErr *OverflowError
+		if errors.As(err, &overflowErr) {
+			overflowErr.Field = d.paramName + "[" + strings.Join(path, "][") + "]"
+			return overflowErr
 		}
-		iv.SetFloat(val)
-		return nil
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		val, err := strconv.ParseInt(pathValues.value, 10, 64)
 		if err != nil {
+			if it.Kind() == reflect.Float32 || it.Kind() == reflect.Float64 {
+				return fmt.Errorf("expected a valid float, got %s", pathValues.value)
+			}
 			return fmt.Errorf("expected a valid int, got %s", pathValues.value)
 		}
-		iv.SetInt(val)
 		return nil
 	case reflect.String:
 		iv.SetString(pathValues.value)
@@ -334,29 +1160,140 @@ func assignPathValues(dst interface{}, pathValues fieldOrValue) error {
 	}
 }
 
-func assignSlice(dst reflect.Value, pathValues fieldOrValue) error {
+// assignDiscriminated binds an object to the interface value iv, choosing
+// its concrete type by its discriminator property.
+func (d *deepObjectDecoder) assignDiscriminated(iv reflect.Value, pathValues fieldOrValue, path []string) error {
+	disc, found := lookupDiscriminator(iv.Type())
+	if !found {
+		return errors.New("unhandled type: " + iv.Type().String())
+	}
+	property, found := pathValues.fields[disc.propertyName]
+	if !found || property.fields != nil {
+		return fmt.Errorf("parameter '%s' is missing discriminator '%s'", d.paramName, disc.propertyName)
+	}
+	target, result, err := disc.newValue(d.paramName, property.value)
+	if err != nil {
+		return err
+	}
+	if err := d.assignPathValues(target.Interface(), pathValues, path); err != nil {
+		return err
+	}
+	iv.Set(result)
+	return nil
+}
+
+// assignScalarStruct binds struct types which are represented by a single
+// value: Binder implementations and the legacy types.Date and time.Time. The
+// latter may be redefined, so we need to do some hoop jumping. If the types
+// are aliased, we need to type convert the pointer, then set the value of the
+// dereferenced pointer. It returns false if the type isn't one of these.
+func assignScalarStruct(v reflect.Value, value string, timeParsers []TimeParser) (bool, error) {
+	iv := reflect.Indirect(v)
+	it := iv.Type()
+
+	// We check to see if the object implements the Binder interface first.
+	if dst, isBinder := v.Interface().(Binder); isBinder {
+		return true, dst.Bind(value)
+	}
+	if scanner, isScanner := v.Interface().(sql.Scanner); isScanner && !it.ConvertibleTo(reflect.TypeOf(types.Date{})) {
+		return true, bindScanner(value, scanner, bindStringOptions{timeParsers: timeParsers})
+	}
+	// Then check the legacy types
+	if it.ConvertibleTo(reflect.TypeOf(types.Date{})) {
+		date, err := types.ParseDate(value, types.DefaultDateOptions())
+		if err != nil {
+			return true, fmt.Errorf("invalid date format: %w", err)
+		}
+		dst := iv
+		if it != reflect.TypeOf(types.Date{}) {
+			// Types are aliased, convert the pointers.
+			ivPtr := iv.Addr()
+			aPtr := ivPtr.Convert(reflect.TypeOf(&types.Date{}))
+			dst = reflect.Indirect(aPtr)
+		}
+		dst.Set(reflect.ValueOf(date))
+		return true, nil
+	}
+	if it.ConvertibleTo(reflect.TypeOf(time.Time{})) {
+		tm, err := parseTime(value, timeParsers)
+		if err != nil {
+			return true, err
+		}
+		dst := iv
+		if it != reflect.TypeOf(time.Time{}) {
+			// Types are aliased, convert the pointers.
+			ivPtr := iv.Addr()
+			aPtr := ivPtr.Convert(reflect.TypeOf(&time.Time{}))
+			dst = reflect.Indirect(aPtr)
+		}
+		dst.Set(reflect.ValueOf(tm))
+		return true, nil
+	}
+	return false, nil
+}
+
+// mapKeyValue converts a subscript into a value of the map's key type. Keys
+// with string kinds, including named string types, are converted directly,
+// anything else is bound like any other string parameter.
+func mapKeyValue(keyType reflect.Type, key string) (reflect.Value, error) {
+	if keyType.Kind() == reflect.String {
+		return reflect.ValueOf(key).Convert(keyType), nil
+	}
+	dstKey := reflect.New(keyType)
+	if err := BindStringToObject(key, dstKey.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return dstKey.Elem(), nil
+}
+
+func (d *deepObjectDecoder) assignSlice(dst reflect.Value, pathValues fieldOrValue, path []string) error {
 	// Gather up the values
-	nValues := len(pathValues.fields)
-	values := make([]string, nValues)
-	// We expect to have consecutive array indices in the map
-	for i := 0; i < nValues; i++ {
-		indexStr := strconv.Itoa(i)
-		fv, found := pathValues.fields[indexStr]
-		if !found {
-			return errors.New("array deepObjects must have consecutive indices")
+	var values []fieldOrValue
+	var subscripts []string
+	if bracketed, found := pathValues.fields[""]; found && len(pathValues.fields) == 1 {
+		// Unindexed elements, p[as][]=a&p[as][]=b, share one empty subscript.
+		pathValues = bracketed
+		path = appendPath(path, "")
+	}
+	if pathValues.fields == nil {
+		// Repeated values, p[as]=a&p[as]=b, are a leaf with many values.
+		for _, v := range pathValues.leafValues() {
+			values = append(values, fieldOrValue{value: v})
+		}
+	} else {
+		// We expect to have consecutive array indices in the map
+		nValues := len(pathValues.fields)
+		values = make([]fieldOrValue, nValues)
+		subscripts = make([]string, nValues)
+		for i := 0; i < nValues; i++ {
+			indexStr := strconv.Itoa(i)
+			fv, found := pathValues.fields[indexStr]
+			if !found {
+				return errors.New("array deepObjects must have consecutive indices")
+			}
+			values[i] = fv
+			subscripts[i] = indexStr
 		}
-		values[i] = fv.value
 	}
 
-	// This could be cleaner, but we can call into assignPathValues to
-	// avoid recreating this logic.
-	for i := 0; i < nValues; i++ {
-		dstElem := dst.Index(i).Addr()
-		err := assignPathValues(dstElem.Interface(), fieldOrValue{value: values[i]})
+	// Each element goes through the same pipeline as any other value, so
+	// that Binders, dates, times and nested objects are all handled.
+	dstSlice := reflect.MakeSlice(dst.Type(), len(values), len(values))
+	for i := range values {
+		dstElem := dstSlice.Index(i).Addr()
+		elemPath := path
+		if subscripts != nil {
+			elemPath = appendPath(path, subscripts[i])
+		}
+		err := d.assignPathValues(dstElem.Interface(), values[i], elemPath)
 		if err != nil {
+			if d.skip(elemPath, err) {
+				continue
+			}
 			return fmt.Errorf("error binding array: %w", err)
 		}
 	}
+	dst.Set(dstSlice)
 
 	return nil
 }
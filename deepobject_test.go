@@ -2,10 +2,12 @@ package runtime
 
 import (
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/oapi-codegen/runtime/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -84,3 +86,588 @@ func TestDeepObject(t *testing.T) {
 	require.NoError(t, err)
 	assert.EqualValues(t, srcObj, dstObj)
 }
+
+func TestDeepObjectCaseInsensitive(t *testing.T) {
+	params := url.Values{
+		"p[NAME]": {"Alex"},
+		"p[Id]":   {"12"},
+	}
+
+	var dst InnerObject
+	err := UnmarshalDeepObject(&dst, "p", params)
+	assert.Error(t, err)
+
+	err = UnmarshalDeepObjectWithOptions(&dst, "p", params, UnmarshalDeepObjectOptions{CaseInsensitive: true})
+	require.NoError(t, err)
+	assert.Equal(t, InnerObject{Name: "Alex", ID: 12}, dst)
+
+	// An exact match takes precedence over a case-insensitive one.
+	type exact struct {
+		Lower string `json:"id"`
+		Upper string `json:"ID"`
+	}
+	var e exact
+	err = UnmarshalDeepObjectWithOptions(&e, "p", url.Values{"p[ID]": {"x"}}, UnmarshalDeepObjectOptions{CaseInsensitive: true})
+	require.NoError(t, err)
+	assert.Equal(t, exact{Upper: "x"}, e)
+}
+
+func TestDeepObjectStringOption(t *testing.T) {
+	type withStringOption struct {
+		Count  int     `json:"count,string"`
+		Ratio  float64 `json:"ratio,string"`
+		OK     *bool   `json:"ok,omitempty,string"`
+		Name   string  `json:"name,string"`
+		Plain  string  `json:"plain"`
+		Nested struct {
+			Size int64 `json:"size,string"`
+		} `json:"nested"`
+	}
+
+	ok := true
+	src := withStringOption{Count: 12, Ratio: 0.5, OK: &ok, Name: "joe", Plain: "x"}
+	src.Nested.Size = 42
+
+	marshaled, err := MarshalDeepObject(src, "p")
+	require.NoError(t, err)
+	assert.Equal(t, `p[count]=12&p[name]="joe"&p[nested][size]=42&p[ok]=true&p[plain]=x&p[ratio]=0.5`, marshaled)
+
+	params := make(url.Values)
+	for _, p := range strings.Split(marshaled, "&") {
+		parts := strings.SplitN(p, "=", 2)
+		params.Set(parts[0], parts[1])
+	}
+	var dst withStringOption
+	require.NoError(t, UnmarshalDeepObject(&dst, "p", params))
+	assert.Equal(t, src, dst)
+
+	err = UnmarshalDeepObject(&dst, "p", url.Values{"p[name]": {"joe"}})
+	assert.Error(t, err)
+}
+
+func TestDeepObjectNestedMaps(t *testing.T) {
+	type labelName string
+	type query struct {
+		Filter map[string]map[labelName]string `json:"filter"`
+		Extra  map[string]interface{}          `json:"extra"`
+		ByID   map[int]map[string]InnerObject  `json:"byId"`
+	}
+	params := url.Values{
+		"p[filter][labels][env]":  {"prod"},
+		"p[filter][labels][team]": {"core"},
+		"p[filter][owner][name]":  {"joe"},
+		"p[extra][a][b][c]":       {"deep"},
+		"p[extra][d]":             {"shallow"},
+		"p[byId][7][first][ID]":   {"1"},
+		"p[byId][7][first][Name]": {"one"},
+	}
+
+	var dst query
+	require.NoError(t, UnmarshalDeepObject(&dst, "p", params))
+	assert.Equal(t, query{
+		Filter: map[string]map[labelName]string{
+			"labels": {"env": "prod", "team": "core"},
+			"owner":  {"name": "joe"},
+		},
+		Extra: map[string]interface{}{
+			"a": map[string]interface{}{
+				"b": map[string]interface{}{"c": "deep"},
+			},
+			"d": "shallow",
+		},
+		ByID: map[int]map[string]InnerObject{
+			7: {"first": {ID: 1, Name: "one"}},
+		},
+	}, dst)
+
+	err := UnmarshalDeepObject(&dst, "p", url.Values{"p[byId][x][first][ID]": {"1"}})
+	assert.Error(t, err)
+}
+
+func TestDeepObjectTimeSlices(t *testing.T) {
+	type aliasedDate types.Date
+	type times struct {
+		Times  []time.Time    `json:"times"`
+		Dates  []types.Date   `json:"dates"`
+		OptDts []*types.Date  `json:"optDates"`
+		Alias  []aliasedDate  `json:"alias"`
+		Inner  []InnerObject  `json:"inner"`
+		Binder []MockBinder   `json:"binder"`
+		Nested [][]types.Date `json:"nested"`
+	}
+	t1 := time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC)
+	d1 := types.Date{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}
+	params := url.Values{
+		"p[times][0]":       {"2020-01-01T10:30:00Z"},
+		"p[times][1]":       {"2020-01-02"},
+		"p[dates][0]":       {"2020-01-02"},
+		"p[optDates][0]":    {"2020-01-02"},
+		"p[alias][0]":       {"2020-01-02"},
+		"p[inner][0][Name]": {"a"},
+		"p[inner][1][ID]":   {"2"},
+		"p[binder][0]":      {"2020-01-02"},
+		"p[nested][0][0]":   {"2020-01-02"},
+	}
+
+	var dst times
+	require.NoError(t, UnmarshalDeepObject(&dst, "p", params))
+	assert.Equal(t, times{
+		Times:  []time.Time{t1, d1.Time},
+		Dates:  []types.Date{d1},
+		OptDts: []*types.Date{&d1},
+		Alias:  []aliasedDate{aliasedDate(d1)},
+		Inner:  []InnerObject{{Name: "a"}, {ID: 2}},
+		Binder: []MockBinder{{Time: d1.Time}},
+		Nested: [][]types.Date{{d1}},
+	}, dst)
+
+	err := UnmarshalDeepObject(&dst, "p", url.Values{"p[dates][0]": {"2020-01-02T00:00:00Z"}})
+	assert.Error(t, err)
+}
+
+func TestDeepObjectLenientBool(t *testing.T) {
+	type flags struct {
+		A bool   `json:"a"`
+		B *bool  `json:"b"`
+		C []bool `json:"c"`
+	}
+	params := url.Values{
+		"p[a]":    {"on"},
+		"p[b]":    {"no"},
+		"p[c][0]": {"yes"},
+		"p[c][1]": {"0"},
+	}
+
+	var dst flags
+	assert.Error(t, UnmarshalDeepObject(&dst, "p", params))
+
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&dst, "p", params, UnmarshalDeepObjectOptions{LenientBool: true}))
+	b := false
+	assert.Equal(t, flags{A: true, B: &b, C: []bool{true, false}}, dst)
+}
+
+func TestDeepObjectPartial(t *testing.T) {
+	params := url.Values{
+		"p[i]":          {"12"},
+		"p[b]":          {"notabool"},
+		"p[as][0]":      {"hello"},
+		"p[as][2]":      {"world"},
+		"p[o][Name]":    {"Joe"},
+		"p[o][ID]":      {"x"},
+		"p[m][one]":     {"1"},
+		"p[m][two]":     {"two"},
+		"p[unknown]":    {"?"},
+		"p[f]":          {"1.5", "2.5"},
+		"other[ignore]": {"me"},
+	}
+
+	var strict AllFields
+	assert.Error(t, UnmarshalDeepObject(&strict, "p", params))
+
+	var dst AllFields
+	skipped, err := UnmarshalDeepObjectPartial(&dst, "p", params, UnmarshalDeepObjectOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 12, dst.I)
+	assert.Equal(t, "Joe", dst.O.Name)
+	assert.Equal(t, map[string]int{"one": 1}, dst.M)
+
+	var fields []string
+	for _, s := range skipped {
+		fields = append(fields, s.Field)
+		assert.Error(t, s.Err)
+	}
+	assert.Equal(t, []string{"p[f]", "p[as]", "p[b]", "p[m][two]", "p[o][ID]", "p[unknown]"}, fields)
+
+	_, err = UnmarshalDeepObjectPartial(new(chan int), "p", params, UnmarshalDeepObjectOptions{})
+	assert.Error(t, err)
+}
+
+func TestMarshalDeepObjectCycles(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Next *node  `json:"next,omitempty"`
+	}
+
+	// Shared, but acyclic, pointers are fine.
+	leaf := &node{Name: "leaf"}
+	shared := struct {
+		A *node `json:"a"`
+		B *node `json:"b"`
+	}{A: leaf, B: leaf}
+	marshaled, err := MarshalDeepObject(shared, "p")
+	require.NoError(t, err)
+	assert.Equal(t, "p[a][name]=leaf&p[b][name]=leaf", marshaled)
+
+	cyclic := &node{Name: "a", Next: &node{Name: "b"}}
+	cyclic.Next.Next = cyclic
+	_, err = MarshalDeepObject(cyclic, "p")
+	assert.ErrorContains(t, err, "cycle")
+
+	m := map[string]interface{}{"x": 1}
+	m["self"] = m
+	_, err = MarshalDeepObject(m, "p")
+	assert.ErrorContains(t, err, "cycle")
+
+	// Deep, but acyclic, structures are bounded by MaxDepth.
+	deep := &node{Name: "0"}
+	cur := deep
+	for i := 1; i < 10; i++ {
+		cur.Next = &node{Name: strconv.Itoa(i)}
+		cur = cur.Next
+	}
+	_, err = MarshalDeepObjectWithOptions(deep, "p", MarshalDeepObjectOptions{MaxDepth: 5})
+	assert.ErrorContains(t, err, "maximum depth")
+	marshaled, err = MarshalDeepObjectWithOptions(deep, "p", MarshalDeepObjectOptions{MaxDepth: 10})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(marshaled, "[next][next][next][next][next][next][next][next][next][name]=9"))
+
+	// A slice which contains itself isn't caught as a cycle, but it's
+	// bounded by MaxDepth, or rejected as a nested array, in every style.
+	self := []interface{}{nil}
+	self[0] = self
+	_, err = MarshalDeepObjectWithOptions(map[string]interface{}{"s": self}, "p", MarshalDeepObjectOptions{})
+	assert.ErrorContains(t, err, "maximum depth")
+	for _, style := range []DeepObjectArrayStyle{DeepObjectArrayBrackets, DeepObjectArrayRepeat} {
+		_, err = MarshalDeepObjectWithOptions(self, "p", MarshalDeepObjectOptions{ArrayStyle: style})
+		assert.ErrorContains(t, err, "can only be marshaled with indexed subscripts")
+	}
+	_, err = MarshalDeepObjectWithOptions([][]int{{1}}, "p", MarshalDeepObjectOptions{ArrayStyle: DeepObjectArrayRepeat})
+	assert.ErrorContains(t, err, "can only be marshaled with indexed subscripts")
+
+	// Repeated fields count towards the depth, though their path doesn't
+	// grow.
+	_, err = MarshalDeepObjectWithOptions(map[string][]string{"as": {"a"}}, "p",
+		MarshalDeepObjectOptions{ArrayStyle: DeepObjectArrayRepeat, MaxDepth: 1})
+	assert.ErrorContains(t, err, "maximum depth")
+}
+
+func TestMarshalDeepObjectLikeJSON(t *testing.T) {
+	type Embedded struct {
+		E      string `json:"e"`
+		Shadow string `json:"shadow"`
+	}
+	type object struct {
+		Embedded
+		Shadow  string            `json:"shadow"`
+		Big     int64             `json:"big"`
+		Skipped string            `json:"-"`
+		Empty   string            `json:"empty,omitempty"`
+		Bytes   []byte            `json:"bytes"`
+		Keys    map[int]string    `json:"keys"`
+		Time    time.Time         `json:"time"`
+		hidden  string            //nolint:unused
+		Generic map[string]string `json:"generic"`
+	}
+	src := object{
+		Embedded: Embedded{E: "embedded", Shadow: "inner"},
+		Shadow:   "outer",
+		Big:      10000000,
+		Skipped:  "skipped",
+		Bytes:    []byte("hi"),
+		Keys:     map[int]string{2: "two", 1: "one"},
+		Time:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Generic:  map[string]string{"b": "2", "a": "1"},
+	}
+	marshaled, err := MarshalDeepObject(src, "p")
+	require.NoError(t, err)
+	assert.Equal(t, "p[big]=10000000&p[bytes]=aGk=&p[e]=embedded&p[generic][a]=1&p[generic][b]=2&"+
+		"p[keys][1]=one&p[keys][2]=two&p[shadow]=outer&p[time]=2020-01-01T00:00:00Z", marshaled)
+}
+
+func TestMarshalDeepObjectArrayStyles(t *testing.T) {
+	src := struct {
+		As    []string      `json:"as"`
+		Inner []InnerObject `json:"inner"`
+	}{
+		As:    []string{"hello", "world"},
+		Inner: []InnerObject{{Name: "a", ID: 1}},
+	}
+
+	marshaled, err := MarshalDeepObjectWithOptions(src, "p", MarshalDeepObjectOptions{ArrayStyle: DeepObjectArrayIndexed})
+	require.NoError(t, err)
+	assert.Equal(t, "p[as][0]=hello&p[as][1]=world&p[inner][0][ID]=1&p[inner][0][Name]=a", marshaled)
+
+	for _, style := range []DeepObjectArrayStyle{DeepObjectArrayBrackets, DeepObjectArrayRepeat} {
+		_, err = MarshalDeepObjectWithOptions(src, "p", MarshalDeepObjectOptions{ArrayStyle: style})
+		assert.EqualError(t, err, "error traversing object: error traversing field 'inner': arrays of objects or arrays can only be marshaled with indexed subscripts")
+	}
+
+	type primitives struct {
+		As []string `json:"as"`
+		Ns []int    `json:"ns"`
+	}
+	for _, tc := range []struct {
+		style    DeepObjectArrayStyle
+		expected string
+		policy   DeepObjectDuplicatePolicy
+	}{
+		{DeepObjectArrayIndexed, "p[as][0]=hello&p[as][1]=world&p[ns][0]=1&p[ns][1]=2", DeepObjectDuplicateError},
+		{DeepObjectArrayBrackets, "p[as][]=hello&p[as][]=world&p[ns][]=1&p[ns][]=2", DeepObjectDuplicateError},
+		{DeepObjectArrayRepeat, "p[as]=hello&p[as]=world&p[ns]=1&p[ns]=2", DeepObjectDuplicateAppend},
+	} {
+		in := primitives{As: src.As, Ns: []int{1, 2}}
+		marshaled, err := MarshalDeepObjectWithOptions(in, "p", MarshalDeepObjectOptions{ArrayStyle: tc.style})
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, marshaled)
+
+		params, err := url.ParseQuery(marshaled)
+		require.NoError(t, err)
+		var out primitives
+		require.NoError(t, UnmarshalDeepObjectWithOptions(&out, "p", params, UnmarshalDeepObjectOptions{DuplicatePolicy: tc.policy}))
+		assert.Equal(t, in, out, marshaled)
+	}
+}
+
+type deepObjectColor int
+
+func (c deepObjectColor) String() string {
+	return [...]string{"red", "green", "blue"}[c]
+}
+
+type deepObjectLevel string
+
+func (l *deepObjectLevel) String() string {
+	return strings.ToUpper(string(*l))
+}
+
+func TestMarshalDeepObjectStringer(t *testing.T) {
+	src := struct {
+		Color   deepObjectColor            `json:"color"`
+		Colors  []deepObjectColor          `json:"colors"`
+		ByName  map[string]deepObjectColor `json:"byName"`
+		Level   deepObjectLevel            `json:"level"`
+		Optimal *deepObjectColor           `json:"optimal"`
+		Date    MockBinder                 `json:"date"`
+	}{
+		Color:  1,
+		Colors: []deepObjectColor{0, 2},
+		ByName: map[string]deepObjectColor{"sky": 2},
+		Level:  "debug",
+		Date:   MockBinder{Time: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	blue := deepObjectColor(2)
+	src.Optimal = &blue
+
+	// The pointer receiver is only usable when the value is addressable.
+	marshaled, err := MarshalDeepObject(&src, "p")
+	require.NoError(t, err)
+	assert.Equal(t, "p[byName][sky]=blue&p[color]=green&p[colors][0]=red&p[colors][1]=blue&"+
+		"p[date]=2020-02-01&p[level]=DEBUG&p[optimal]=blue", marshaled)
+}
+
+func TestObjectForm(t *testing.T) {
+	src := struct {
+		Role      string      `json:"role"`
+		FirstName string      `json:"firstName"`
+		Age       *int        `json:"age,omitempty"`
+		Since     types.Date  `json:"since"`
+		Inner     InnerObject `json:"-"`
+	}{
+		Role:      "admin",
+		FirstName: "Alex Smith",
+		Since:     types.Date{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	marshaled, err := MarshalObjectForm(src, "p")
+	require.NoError(t, err)
+	assert.Equal(t, "p=firstName,Alex+Smith,role,admin,since,2020-01-02", marshaled)
+
+	params, err := url.ParseQuery(marshaled)
+	require.NoError(t, err)
+	dst := src
+	dst.Role, dst.FirstName, dst.Since = "", "", types.Date{}
+	require.NoError(t, UnmarshalObjectForm(&dst, "p", params))
+	assert.Equal(t, src, dst)
+
+	var m map[string]int
+	require.NoError(t, UnmarshalObjectForm(&m, "p", url.Values{"p": {"a,1,b,2"}}))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+
+	assert.Error(t, UnmarshalObjectForm(&m, "p", url.Values{"p": {"a,1,b"}}))
+	assert.Error(t, UnmarshalObjectForm(&m, "p", url.Values{"p": {"a,1", "b,2"}}))
+
+	_, err = MarshalObjectForm(AllFields{}, "p")
+	assert.Error(t, err)
+}
+
+func TestDottedForm(t *testing.T) {
+	type Address struct {
+		City    string `json:"city"`
+		ZipCode *int   `json:"zip,omitempty"`
+	}
+	type Filter struct {
+		Role    string   `json:"role"`
+		Tags    []string `json:"tags"`
+		Address Address  `json:"address"`
+		Limits  map[string]int
+	}
+	src := Filter{
+		Role:    "admin",
+		Tags:    []string{"a b", "c"},
+		Address: Address{City: "Paris"},
+		Limits:  map[string]int{"max": 10},
+	}
+
+	marshaled, err := MarshalDottedForm(src, "filter")
+	require.NoError(t, err)
+	assert.Equal(t, "Limits.max=10&address.city=Paris&role=admin&tags=a+b&tags=c", marshaled)
+
+	styled, err := StyleParamWithOptions("form", "filter", src, StyleParamOptions{
+		ParamLocation: ParamLocationQuery,
+		Explode:       true,
+		DottedKeys:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, marshaled, styled)
+
+	params, err := url.ParseQuery(marshaled + "&page=2")
+	require.NoError(t, err)
+	var dst Filter
+	require.NoError(t, UnmarshalDottedForm(&dst, "filter", params))
+	assert.Equal(t, src, dst)
+
+	var m map[string]interface{}
+	require.NoError(t, UnmarshalDottedForm(&m, "filter", url.Values{"a.b": {"1"}, "c": {"2"}}))
+	assert.Equal(t, map[string]interface{}{"a": map[string]interface{}{"b": "1"}, "c": "2"}, m)
+
+	// Nothing of ours in the query leaves the destination alone.
+	dst = Filter{Role: "user"}
+	require.NoError(t, UnmarshalDottedForm(&dst, "filter", url.Values{"page": {"2"}}))
+	assert.Equal(t, Filter{Role: "user"}, dst)
+
+	assert.Error(t, UnmarshalDottedForm(&dst, "filter", url.Values{"role": {"a"}, "role.x": {"b"}}))
+
+	_, err = MarshalDottedForm(map[string]string{"a.b": "c"}, "filter")
+	assert.Error(t, err)
+	_, err = MarshalDottedForm("primitive", "filter")
+	assert.Error(t, err)
+}
+
+func TestDeepObjectPath(t *testing.T) {
+	tests := []struct {
+		key       string
+		paramName string
+		path      []string
+		err       bool
+	}{
+		{key: "p[a]", paramName: "p", path: []string{"a"}},
+		{key: "p[a][b][]", paramName: "p", path: []string{"a", "b", ""}},
+		{key: "pp[a]", paramName: "p"},
+		{key: "p", paramName: "p"},
+		{key: "filter[x][a]", paramName: "filter[x]", path: []string{"a"}},
+		{key: "filter%5Bx%5D[a]", paramName: "filter[x]", path: []string{"a"}},
+		{key: "filter[x][a]", paramName: "filter", path: []string{"x", "a"}},
+		{key: "p[[a]]", paramName: "p", err: true},
+		{key: "p[a]b", paramName: "p", err: true},
+		{key: "p[a][b", paramName: "p", err: true},
+	}
+	for _, tt := range tests {
+		path, err := deepObjectPath(tt.key, tt.paramName)
+		if tt.err {
+			assert.Error(t, err, tt.key)
+			continue
+		}
+		require.NoError(t, err, tt.key)
+		assert.Equal(t, tt.path, path, tt.key)
+	}
+}
+
+func TestDeepObjectBracketedName(t *testing.T) {
+	params := url.Values{
+		"filter[x][Name]": {"a"},
+		"filter[x][ID]":   {"1"},
+		"filter[Name]":    {"b"},
+	}
+	var dst InnerObject
+	require.NoError(t, UnmarshalDeepObject(&dst, "filter[x]", params))
+	assert.Equal(t, InnerObject{Name: "a", ID: 1}, dst)
+
+	assert.Error(t, UnmarshalDeepObject(&dst, "p", url.Values{"p[Name]x": {"a"}}))
+}
+
+func TestDeepObjectDuplicatePolicy(t *testing.T) {
+	params := url.Values{
+		"p[Name]": {"first", "last"},
+		"p[ID]":   {"1"},
+	}
+
+	var dst InnerObject
+	err := UnmarshalDeepObject(&dst, "p", params)
+	var dupErr *DuplicateKeyError
+	require.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, "p[Name]", dupErr.Key)
+	assert.Equal(t, []string{"first", "last"}, dupErr.Values)
+
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&dst, "p", params, UnmarshalDeepObjectOptions{DuplicatePolicy: DeepObjectDuplicateFirstWins}))
+	assert.Equal(t, InnerObject{Name: "first", ID: 1}, dst)
+
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&dst, "p", params, UnmarshalDeepObjectOptions{DuplicatePolicy: DeepObjectDuplicateLastWins}))
+	assert.Equal(t, InnerObject{Name: "last", ID: 1}, dst)
+
+	// The literal and escaped parameter names refer to the same field.
+	escaped := url.Values{
+		"f[x][Name]":     {"literal"},
+		"f%5Bx%5D[Name]": {"escaped"},
+	}
+	require.ErrorAs(t, UnmarshalDeepObject(&dst, "f[x]", escaped), &dupErr)
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&dst, "f[x]", escaped, UnmarshalDeepObjectOptions{DuplicatePolicy: DeepObjectDuplicateFirstWins}))
+	assert.Equal(t, "escaped", dst.Name)
+
+	type lists struct {
+		Repeat   []string               `json:"repeat"`
+		Brackets []int                  `json:"brackets"`
+		Free     map[string]interface{} `json:"free"`
+		Objects  []InnerObject          `json:"objects"`
+		Single   map[string]string      `json:"single"`
+	}
+	appendParams := url.Values{
+		"p[repeat]":           {"a", "b"},
+		"p[brackets][]":       {"1", "2", "3"},
+		"p[free][k]":          {"x", "y"},
+		"p[objects][0][Name]": {"o"},
+		"p[single][k]":        {"v"},
+	}
+	var l lists
+	require.ErrorAs(t, UnmarshalDeepObject(&l, "p", appendParams), &dupErr)
+	require.NoError(t, UnmarshalDeepObjectWithOptions(&l, "p", appendParams, UnmarshalDeepObjectOptions{DuplicatePolicy: DeepObjectDuplicateAppend}))
+	assert.Equal(t, lists{
+		Repeat:   []string{"a", "b"},
+		Brackets: []int{1, 2, 3},
+		Free:     map[string]interface{}{"k": []interface{}{"x", "y"}},
+		Objects:  []InnerObject{{Name: "o"}},
+		Single:   map[string]string{"k": "v"},
+	}, l)
+
+	// A field can't be both a value and an object.
+	err = UnmarshalDeepObjectWithOptions(&l, "p", url.Values{
+		"p[single]":    {"v"},
+		"p[single][k]": {"v"},
+	}, UnmarshalDeepObjectOptions{DuplicatePolicy: DeepObjectDuplicateLastWins})
+	assert.ErrorContains(t, err, "both as a value and as an object")
+}
+
+func TestDeepObjectOverflow(t *testing.T) {
+	type Limits struct {
+		Small int8   `json:"small"`
+		Port  uint16 `json:"port"`
+	}
+
+	var dst Limits
+	require.NoError(t, UnmarshalDeepObject(&dst, "p", url.Values{"p[small]": {"-5"}, "p[port]": {"8080"}}))
+	assert.Equal(t, Limits{Small: -5, Port: 8080}, dst)
+
+	err := UnmarshalDeepObject(&dst, "p", url.Values{"p[small]": {"300"}})
+	var overflowErr *OverflowError
+	require.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, "p[small]", overflowErr.Field)
+	assert.Equal(t, "127", overflowErr.Max)
+
+	err = UnmarshalDeepObject(&dst, "p", url.Values{"p[port]": {"70000"}})
+	require.ErrorAs(t, err, &overflowErr)
+	assert.Equal(t, "p[port]", overflowErr.Field)
+	assert.Contains(t, err.Error(), "value '70000' of field 'p[port]' is out of range for uint16")
+
+	err = UnmarshalDeepObject(&dst, "p", url.Values{"p[port]": {"x"}})
+	assert.ErrorContains(t, err, "expected a valid int, got x")
+}
@@ -0,0 +1,174 @@
+// Package deepobjecttest provides helpers for checking, in tests, that
+// parameter types survive being marshaled as a deepObject and bound again.
+package deepobjecttest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Difference describes a value which changed during the round trip.
+type Difference struct {
+	// Path locates the value within the object, using deepObject subscripts
+	// for the json field names, such as "[o][id]". It is empty for the
+	// top-level value.
+	Path string
+	// Want is the original value, or nil if it was missing.
+	Want interface{}
+	// Got is the value after the round trip, or nil if it was missing.
+	Got interface{}
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: want %v, got %v", d.Path, d.Want, d.Got)
+}
+
+// Result describes the outcome of a round trip.
+type Result struct {
+	// Query is the marshaled deepObject.
+	Query string
+	// Differences lists every value which changed. It is empty when the
+	// object survived the round trip.
+	Differences []Difference
+}
+
+// RoundTrip marshals v as the deepObject parameter paramName, parses the
+// result as a query string, unmarshals it into a new value of the same type
+// as v and compares the two. An error is returned if any step fails, or if
+// v is nil or a nil pointer, which has nothing to marshal.
+func RoundTrip(v interface{}, paramName string) (Result, error) {
+	var result Result
+	want := reflect.ValueOf(v)
+	if !want.IsValid() || (want.Kind() == reflect.Ptr && want.IsNil()) {
+		return result, fmt.Errorf("cannot round trip nil %T", v)
+	}
+	query, err := runtime.MarshalDeepObject(v, paramName)
+	if err != nil {
+		return result, fmt.Errorf("marshaling: %w", err)
+	}
+	result.Query = query
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return result, fmt.Errorf("parsing query %q: %w", query, err)
+	}
+
+	got := reflect.New(want.Type())
+	if err := runtime.UnmarshalDeepObject(got.Interface(), paramName, params); err != nil {
+		return result, fmt.Errorf("unmarshaling query %q: %w", query, err)
+	}
+
+	diff(&result.Differences, "", want, got.Elem())
+	return result, nil
+}
+
+// AssertRoundTrip reports a test error for every difference after a round
+// trip of v, and returns whether it survived unchanged.
+func AssertRoundTrip(t testing.TB, v interface{}, paramName string) bool {
+	t.Helper()
+	result, err := RoundTrip(v, paramName)
+	if err != nil {
+		t.Errorf("deepObject round trip of %T failed: %s", v, err)
+		return false
+	}
+	for _, d := range result.Differences {
+		t.Errorf("deepObject round trip of %T via %q changed %s", v, result.Query, d)
+	}
+	return len(result.Differences) == 0
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func diff(out *[]Difference, path string, want, got reflect.Value) {
+	if !want.IsValid() || !got.IsValid() {
+		if want.IsValid() != got.IsValid() {
+			*out = append(*out, Difference{Path: path, Want: valueOf(want), Got: valueOf(got)})
+		}
+		return
+	}
+
+	if want.Type().ConvertibleTo(timeType) && want.Kind() == reflect.Struct {
+		wt := want.Convert(timeType).Interface().(time.Time)
+		gt := got.Convert(timeType).Interface().(time.Time)
+		if !wt.Equal(gt) {
+			*out = append(*out, Difference{Path: path, Want: wt, Got: gt})
+		}
+		return
+	}
+
+	switch want.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if want.IsNil() || got.IsNil() {
+			if want.IsNil() != got.IsNil() {
+				*out = append(*out, Difference{Path: path, Want: valueOf(want), Got: valueOf(got)})
+			}
+			return
+		}
+		diff(out, path, want.Elem(), got.Elem())
+	case reflect.Struct:
+		t := want.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() || t.Field(i).Tag.Get("json") == "-" {
+				continue
+			}
+			diff(out, path+"["+fieldName(t.Field(i))+"]", want.Field(i), got.Field(i))
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, k := range append(want.MapKeys(), got.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			k := keys[name]
+			diff(out, path+"["+name+"]", want.MapIndex(k), got.MapIndex(k))
+		}
+	case reflect.Slice, reflect.Array:
+		n := want.Len()
+		if got.Len() > n {
+			n = got.Len()
+		}
+		for i := 0; i < n; i++ {
+			var w, g reflect.Value
+			if i < want.Len() {
+				w = want.Index(i)
+			}
+			if i < got.Len() {
+				g = got.Index(i)
+			}
+			diff(out, fmt.Sprintf("%s[%d]", path, i), w, g)
+		}
+	default:
+		if !reflect.DeepEqual(want.Interface(), got.Interface()) {
+			*out = append(*out, Difference{Path: path, Want: want.Interface(), Got: got.Interface()})
+		}
+	}
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// fieldName returns the json name of a struct field. Fields tagged
+// `json:"-"` are skipped before getting here, so a name of "-" is one given
+// as `json:"-,"`.
+func fieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return f.Name
+}
@@ -0,0 +1,77 @@
+package deepobjecttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inner struct {
+	Name string `json:"name"`
+	ID   int    `json:"id"`
+}
+
+type params struct {
+	Count  int               `json:"count"`
+	Tags   []string          `json:"tags"`
+	Inner  *inner            `json:"inner,omitempty"`
+	Labels map[string]string `json:"labels"`
+	Since  time.Time         `json:"since"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	p := params{
+		Count:  3,
+		Tags:   []string{"a", "b"},
+		Inner:  &inner{Name: "joe", ID: 7},
+		Labels: map[string]string{"env": "prod"},
+		Since:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	result, err := RoundTrip(p, "p")
+	require.NoError(t, err)
+	assert.Empty(t, result.Differences)
+	assert.True(t, AssertRoundTrip(t, p, "p"))
+}
+
+func TestRoundTripDifferences(t *testing.T) {
+	// Empty slices come back as nil, which isn't considered a difference,
+	// but plus signs aren't escaped, so they come back as spaces.
+	p := struct {
+		Name  string   `json:"name"`
+		Empty []string `json:"empty"`
+	}{
+		Name:  "a+b",
+		Empty: []string{},
+	}
+	result, err := RoundTrip(p, "p")
+	require.NoError(t, err)
+	assert.Equal(t, []Difference{
+		{Path: "[name]", Want: "a+b", Got: "a b"},
+	}, result.Differences)
+
+	// Values which break the query syntax are reported as errors.
+	_, err = RoundTrip(struct {
+		Name string `json:"name"`
+	}{Name: "100%"}, "p")
+	assert.Error(t, err)
+}
+
+func TestRoundTripSkipsIgnoredFields(t *testing.T) {
+	type secretParams struct {
+		A      string `json:"a"`
+		Secret string `json:"-"`
+	}
+	result, err := RoundTrip(&secretParams{A: "x", Secret: "y"}, "p")
+	require.NoError(t, err)
+	assert.Empty(t, result.Differences)
+}
+
+func TestRoundTripNil(t *testing.T) {
+	_, err := RoundTrip(nil, "p")
+	assert.Error(t, err)
+
+	_, err = RoundTrip((*params)(nil), "p")
+	assert.Error(t, err)
+}
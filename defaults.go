@@ -0,0 +1,75 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ApplyDefaults sets the fields of the struct dest points to which were left
+// unset by binding to the values given by their `default` tags, so that a
+// parameters struct can carry the defaults from its schema:
+//
+//	type ListParams struct {
+//		Limit *int      `form:"limit" default:"10"`
+//		Tags  *[]string `form:"tags" default:"new,open"`
+//	}
+//
+// A field is considered unset when it's a nil pointer, slice or map. Other
+// fields are left alone, as their zero value can't be told apart from one
+// the client sent, such as ?enabled=false; BindQuery, BindHeaders and
+// BindRequestParams bind their default tags themselves, when the parameter
+// is absent. Defaults use the unexploded form style, so arrays are comma
+// separated, as are the keys and values of maps.
+func ApplyDefaults(dest interface{}) (err error) {
+	defer recoverPanic(&err, "error applying defaults")
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("ApplyDefaults requires a pointer to a struct")
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		def, found := t.Field(i).Tag.Lookup("default")
+		field := v.Field(i)
+		if !found || !field.CanSet() || !isNilField(field) {
+			continue
+		}
+		if err := bindDefault(t.Field(i).Name, def, field); err != nil {
+			return fmt.Errorf("error applying default of field '%s': %w", t.Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// isNilField tells whether field is a nil pointer, slice or map.
+func isNilField(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return field.IsNil()
+	default:
+		return false
+	}
+}
+
+// bindDefault binds a default value in the unexploded form style to the
+// settable value dest.
+func bindDefault(name string, value string, dest reflect.Value) error {
+	switch dest.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dest.Type().Elem())
+		if err := bindDefault(name, value, elem.Elem()); err != nil {
+			return err
+		}
+		dest.Set(elem)
+		return nil
+	case reflect.Slice:
+		return bindSplitPartsToDestinationArray(strings.Split(value, ","), dest.Addr().Interface(), bindStringOptions{})
+	case reflect.Map:
+		return bindSplitPartsToDestinationMap(name, strings.Split(value, ","), false, dest.Addr().Interface(), bindStringOptions{})
+	default:
+		return BindStringToObject(value, dest.Addr().Interface())
+	}
+}
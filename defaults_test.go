@@ -0,0 +1,81 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	type Params struct {
+		Limit   *int              `form:"limit" default:"10"`
+		Offset  int               `form:"offset" default:"5"`
+		Tags    *[]string         `form:"tags" default:"new,open"`
+		Labels  map[string]string `form:"labels" default:"env,prod"`
+		Sort    *string           `form:"sort" default:"name"`
+		Missing *int              `form:"missing"`
+		hidden  *int              `default:"1"`
+	}
+
+	sort := "date"
+	params := Params{Sort: &sort}
+	require.NoError(t, ApplyDefaults(&params))
+	require.NotNil(t, params.Limit)
+	assert.Equal(t, 10, *params.Limit)
+	// Value fields may have been sent as their zero value.
+	assert.Equal(t, 0, params.Offset)
+	assert.Equal(t, &[]string{"new", "open"}, params.Tags)
+	assert.Equal(t, map[string]string{"env": "prod"}, params.Labels)
+	assert.Equal(t, "date", *params.Sort)
+	assert.Nil(t, params.Missing)
+	assert.Nil(t, params.hidden)
+
+	type Invalid struct {
+		Limit *int `default:"ten"`
+	}
+	assert.ErrorContains(t, ApplyDefaults(&Invalid{}), "field 'Limit'")
+	assert.Error(t, ApplyDefaults(Params{}))
+}
+
+func TestBindDefaultTags(t *testing.T) {
+	type Params struct {
+		Enabled bool      `query:"enabled" default:"true"`
+		Limit   int       `query:"limit" default:"10"`
+		Tags    *[]string `query:"tags,explode=false" default:"new,open"`
+		Sort    *string   `query:"sort"`
+	}
+
+	// Values the client sent, even zero ones, are kept.
+	r := httptest.NewRequest(http.MethodGet, "/?enabled=false&limit=0", nil)
+	params, err := BindQuery[Params](r)
+	require.NoError(t, err)
+	require.NoError(t, ApplyDefaults(&params))
+	assert.False(t, params.Enabled)
+	assert.Equal(t, 0, params.Limit)
+	assert.Equal(t, &[]string{"new", "open"}, params.Tags)
+	assert.Nil(t, params.Sort)
+
+	params, err = BindQuery[Params](httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	assert.True(t, params.Enabled)
+	assert.Equal(t, 10, params.Limit)
+
+	type Headers struct {
+		Limit     *int   `header:"X-Limit" default:"25"`
+		RequestID string `header:"X-Request-ID" default:"none"`
+	}
+	headers, err := BindHeaders[Headers](httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.NotNil(t, headers.Limit)
+	assert.Equal(t, 25, *headers.Limit)
+	assert.Equal(t, "none", headers.RequestID)
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Limit", "5")
+	headers, err = BindHeaders[Headers](r)
+	require.NoError(t, err)
+	assert.Equal(t, 5, *headers.Limit)
+}
@@ -0,0 +1,72 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// InvalidDestinationError is returned when a parameter is bound to a
+// destination which can't hold it, such as one which isn't a pointer.
+type InvalidDestinationError struct {
+	ParamName string
+	// Type is the type of the destination, which is nil for a nil interface.
+	Type   reflect.Type
+	Reason string
+}
+
+func (e *InvalidDestinationError) Error() string {
+	if e.ParamName == "" {
+		return fmt.Sprintf("invalid destination of type %v: %s", e.Type, e.Reason)
+	}
+	return fmt.Sprintf("invalid destination of type %v for parameter '%s': %s", e.Type, e.ParamName, e.Reason)
+}
+
+// checkDestination makes sure dest is a non-nil pointer, and, for optional
+// parameters, which are left alone when absent, a pointer to a pointer, so
+// that binding can't panic on it.
+func checkDestination(paramName string, dest interface{}, optional bool) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return &InvalidDestinationError{ParamName: paramName, Type: reflect.TypeOf(dest),
+			Reason: "it must be a non-nil pointer"}
+	}
+	if optional && v.Elem().Kind() != reflect.Ptr {
+		return &InvalidDestinationError{ParamName: paramName, Type: reflect.TypeOf(dest),
+			Reason: "optional parameters must be bound to a pointer to a pointer"}
+	}
+	return nil
+}
+
+// recoverPanic turns a panic while binding or styling a parameter, such as
+// one from reflection on a value of an unexpected type, or from a Binder,
+// into an error, so that bad input can't take a server down. It's deferred
+// by each of the exported binders, with the context to give the error.
+func recoverPanic(err *error, format string, args ...interface{}) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%s: panic: %v", fmt.Sprintf(format, args...), r)
+	}
+}
+
+// bindOptional binds a parameter to the value of a types.Optional, through a
+// pointer to a pointer, as bind expects for optional parameters, marking it
+// set when the parameter is present. Required parameters which are absent
+// fail with a *RequiredParamError.
+func bindOptional(o types.OptionalValue, required bool, paramName string, location ParamLocation,
+	bind func(dest interface{}) error) error {
+	value := reflect.ValueOf(o.OptionalValuePtr())
+	ptr := reflect.New(value.Type())
+	if err := bind(ptr.Interface()); err != nil {
+		return err
+	}
+	if ptr.Elem().IsNil() {
+		if required {
+			return &RequiredParamError{ParamName: paramName, Location: location}
+		}
+		return nil
+	}
+	value.Elem().Set(ptr.Elem().Elem())
+	o.MarkSet()
+	return nil
+}
@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panickyBinder struct{}
+
+func (p *panickyBinder) Bind(string) error {
+	panic("unexpected input")
+}
+
+func TestBindersDontPanic(t *testing.T) {
+	type hidden struct {
+		secret int
+		Name   string `json:"name"`
+	}
+	var i int
+	var m map[string]string
+	q := url.Values{"p": {"1"}, "p[secret]": {"1"}, "p[name]": {"a"}}
+	header := http.Header{"P": {"1"}}
+
+	for name, dest := range map[string]interface{}{
+		"nil":         nil,
+		"non-pointer": i,
+		"nil pointer": (*int)(nil),
+		"map":         m,
+		"struct":      hidden{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var destErr *InvalidDestinationError
+			assert.ErrorAs(t, BindStyledParameterWithOptions("simple", "p", "1", dest, BindStyledParameterOptions{}), &destErr)
+			assert.ErrorAs(t, BindQueryParameter("form", true, true, "p", q, dest), &destErr)
+			assert.ErrorAs(t, BindHeaderParameter("simple", "P", header, dest, BindHeaderParameterOptions{Required: true}), &destErr)
+			assert.ErrorAs(t, BindStringToObject("1", dest), &destErr)
+			assert.ErrorAs(t, UnmarshalDeepObject(dest, "p", q), &destErr)
+			assert.ErrorAs(t, BindFreeFormQuery(q, dest, FreeFormQueryOptions{}), &destErr)
+			assert.ErrorAs(t, BindForm(dest, q, nil, nil), &destErr)
+		})
+	}
+
+	// Optional parameters need a pointer to a pointer.
+	err := BindQueryParameter("form", true, false, "p", q, &i)
+	var destErr *InvalidDestinationError
+	require.ErrorAs(t, err, &destErr)
+	assert.EqualError(t, err,
+		"invalid destination of type *int for parameter 'p': optional parameters must be bound to a pointer to a pointer")
+	assert.ErrorAs(t, BindHeaderParameter("simple", "P", header, &i, BindHeaderParameterOptions{}), &destErr)
+
+	// Unexported fields aren't bound.
+	var h hidden
+	assert.Error(t, UnmarshalDeepObject(&h, "p", q))
+	assert.Zero(t, h.secret)
+
+	// Panics, such as those of Binders, are returned as errors.
+	var p panickyBinder
+	assert.EqualError(t, BindStyledParameterWithOptions("simple", "p", "1", &p, BindStyledParameterOptions{}),
+		"error binding parameter 'p': panic: unexpected input")
+	assert.EqualError(t, BindStringToObject("1", &p), "error binding string '1': panic: unexpected input")
+}
@@ -0,0 +1,125 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// discriminator selects the concrete type of an interface destination, such
+// as one generated for a oneOf union, by the value of one of its properties.
+type discriminator struct {
+	propertyName string
+	mapping      map[string]reflect.Type
+}
+
+var (
+	discriminatorsMu sync.RWMutex
+	discriminators   = make(map[reflect.Type]discriminator)
+)
+
+// RegisterDiscriminator makes object parameters bindable to the interface
+// which iface points to, such as (*Pet)(nil), by styled parameter and
+// deepObject binding. The value of the property propertyName selects the
+// concrete type from mapping, which maps each value to a value of the type,
+// such as Cat{} or &Cat{}, when it's the pointer which implements the
+// interface. Concrete types are bound like any other object, so they should
+// have a field for the discriminator property, too:
+//
+//	RegisterDiscriminator((*Pet)(nil), "petType", map[string]interface{}{
+//		"cat": Cat{},
+//		"dog": Dog{},
+//	})
+//
+// Like RegisterParamStyle, it's meant to be called from an init function,
+// and panics if the interface is already registered, or a type in the
+// mapping doesn't implement it.
+func RegisterDiscriminator(iface interface{}, propertyName string, mapping map[string]interface{}) {
+	it := reflect.TypeOf(iface)
+	if it == nil || it.Kind() != reflect.Ptr || it.Elem().Kind() != reflect.Interface {
+		panic("runtime: RegisterDiscriminator requires a pointer to an interface, such as (*Pet)(nil)")
+	}
+	it = it.Elem()
+
+	d := discriminator{
+		propertyName: propertyName,
+		mapping:      make(map[string]reflect.Type, len(mapping)),
+	}
+	for value, concrete := range mapping {
+		ct := reflect.TypeOf(concrete)
+		if ct == nil || !ct.Implements(it) {
+			panic(fmt.Sprintf("runtime: RegisterDiscriminator type %v for %q doesn't implement %v", ct, value, it))
+		}
+		d.mapping[value] = ct
+	}
+
+	discriminatorsMu.Lock()
+	defer discriminatorsMu.Unlock()
+	if _, dup := discriminators[it]; dup {
+		panic(fmt.Sprintf("runtime: RegisterDiscriminator called twice for %v", it))
+	}
+	discriminators[it] = d
+}
+
+// lookupDiscriminator returns the discriminator registered for the
+// interface type t.
+func lookupDiscriminator(t reflect.Type) (discriminator, bool) {
+	discriminatorsMu.RLock()
+	defer discriminatorsMu.RUnlock()
+	d, found := discriminators[t]
+	return d, found
+}
+
+// newValue allocates a value of the concrete type selected by the
+// discriminator value. It returns a pointer to the struct to bind the object
+// to, and the value to assign to the interface once it's bound.
+func (d discriminator) newValue(paramName string, value string) (target reflect.Value, result reflect.Value, err error) {
+	ct, found := d.mapping[value]
+	if !found {
+		return reflect.Value{}, reflect.Value{}, fmt.Errorf("parameter '%s' has unknown value '%s' for discriminator '%s'",
+			paramName, value, d.propertyName)
+	}
+	if ct.Kind() == reflect.Ptr {
+		target = reflect.New(ct.Elem())
+		return target, target, nil
+	}
+	target = reflect.New(ct)
+	return target, target.Elem(), nil
+}
+
+// bindDiscriminatedObject binds the properties of an object parameter, split
+// up as for bindSplitPartsToDestinationStruct, to the interface value v.
+func bindDiscriminatedObject(paramName string, parts []string, explode bool, d discriminator, v reflect.Value) error {
+	var value string
+	var found bool
+	if explode {
+		for _, part := range parts {
+			var key string
+			if key, value, found = strings.Cut(part, "="); found && key == d.propertyName {
+				break
+			}
+			found = false
+		}
+	} else {
+		for i := 0; i+1 < len(parts); i += 2 {
+			if parts[i] == d.propertyName {
+				value, found = parts[i+1], true
+				break
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("parameter '%s' is missing discriminator '%s'", paramName, d.propertyName)
+	}
+
+	target, result, err := d.newValue(paramName, value)
+	if err != nil {
+		return err
+	}
+	if err := bindSplitPartsToDestinationStruct(paramName, parts, explode, target.Interface()); err != nil {
+		return err
+	}
+	v.Set(result)
+	return nil
+}
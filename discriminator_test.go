@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPet interface {
+	petName() string
+}
+
+type testCat struct {
+	PetType string `json:"petType"`
+	Name    string `json:"name"`
+	Lives   int    `json:"lives,string"`
+}
+
+func (c testCat) petName() string { return c.Name }
+
+type testDog struct {
+	PetType string `json:"petType"`
+	Name    string `json:"name"`
+	Breed   string `json:"breed"`
+}
+
+func (d *testDog) petName() string { return d.Name }
+
+func init() {
+	RegisterDiscriminator((*testPet)(nil), "petType", map[string]interface{}{
+		"cat": testCat{},
+		"dog": &testDog{},
+	})
+}
+
+func TestBindDiscriminator(t *testing.T) {
+	var pet testPet
+	require.NoError(t, BindStyledParameterWithOptions("simple", "pet", "petType,cat,name,Tom,lives,9", &pet,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath}))
+	assert.Equal(t, testCat{PetType: "cat", Name: "Tom", Lives: 9}, pet)
+
+	require.NoError(t, BindStyledParameterWithOptions("matrix", "pet", ";name=Rex;petType=dog;breed=Pug", &pet,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath, Explode: true}))
+	assert.Equal(t, &testDog{PetType: "dog", Name: "Rex", Breed: "Pug"}, pet)
+
+	var obj struct {
+		Pet      testPet  `json:"pet"`
+		Optional *testPet `json:"optional"`
+	}
+	require.NoError(t, UnmarshalDeepObject(&obj, "o", url.Values{
+		"o[pet][petType]":      {"dog"},
+		"o[pet][name]":         {"Rex"},
+		"o[optional][petType]": {"cat"},
+		"o[optional][lives]":   {"3"},
+	}))
+	assert.Equal(t, &testDog{PetType: "dog", Name: "Rex"}, obj.Pet)
+	require.NotNil(t, obj.Optional)
+	assert.Equal(t, testCat{PetType: "cat", Lives: 3}, *obj.Optional)
+
+	err := BindStyledParameterWithOptions("simple", "pet", "petType,fish,name,Nemo", &pet,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath})
+	assert.EqualError(t, err, "parameter 'pet' has unknown value 'fish' for discriminator 'petType'")
+	err = BindStyledParameterWithOptions("simple", "pet", "name,Nemo", &pet,
+		BindStyledParameterOptions{ParamLocation: ParamLocationPath})
+	assert.EqualError(t, err, "parameter 'pet' is missing discriminator 'petType'")
+	err = UnmarshalDeepObject(&obj, "o", url.Values{"o[pet][name]": {"Rex"}})
+	assert.ErrorContains(t, err, "parameter 'o' is missing discriminator 'petType'")
+}
+
+func TestRegisterDiscriminatorPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterDiscriminator(testPet(nil), "petType", nil)
+	})
+	assert.Panics(t, func() {
+		// testDog's methods have a pointer receiver.
+		RegisterDiscriminator((*interface{ petName() string })(nil), "petType", map[string]interface{}{
+			"dog": testDog{},
+		})
+	})
+	assert.Panics(t, func() {
+		RegisterDiscriminator((*testPet)(nil), "petType", map[string]interface{}{"cat": testCat{}})
+	})
+}
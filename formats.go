@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// FormatCodec parses and formats the values of a string format, such as
+// "ulid", which the runtime doesn't know, as the Go type it's generated as.
+// It's made by NewFormatCodec and registered by RegisterFormat.
+type FormatCodec struct {
+	typ    reflect.Type
+	parse  func(s string) (interface{}, error)
+	format func(v interface{}) (string, error)
+}
+
+// NewFormatCodec returns a FormatCodec for values of type T, which are
+// parsed by parse and formatted by format.
+func NewFormatCodec[T any](parse func(s string) (T, error), format func(v T) (string, error)) FormatCodec {
+	if parse == nil || format == nil {
+		panic("runtime: NewFormatCodec parse and format must not be nil")
+	}
+	return FormatCodec{
+		typ: reflect.TypeOf((*T)(nil)).Elem(),
+		parse: func(s string) (interface{}, error) {
+			return parse(s)
+		},
+		format: func(v interface{}) (string, error) {
+			return format(v.(T))
+		},
+	}
+}
+
+// Type returns the Go type of the values of the format.
+func (c FormatCodec) Type() reflect.Type {
+	return c.typ
+}
+
+// Parse parses a value of the format, returning a value of its Type.
+func (c FormatCodec) Parse(s string) (interface{}, error) {
+	return c.parse(s)
+}
+
+// Format formats a value of the format's Type.
+func (c FormatCodec) Format(v interface{}) (string, error) {
+	return c.format(v)
+}
+
+var (
+	formatsMu     sync.RWMutex
+	formatsByName = make(map[string]FormatCodec)
+	formatsByType = make(map[reflect.Type]FormatCodec)
+)
+
+func init() {
+	// Passwords redact themselves when they're printed, so parameters and
+	// form fields of them must be revealed.
+	RegisterFormat("password", NewFormatCodec(
+		func(s string) (types.Password, error) {
+			return types.Password(s), nil
+		},
+		func(p types.Password) (string, error) {
+			return p.Reveal(), nil
+		}))
+}
+
+// RegisterFormat makes a codec available under the name of its format, so
+// that parameters, deepObject properties and form fields of its Go type are
+// parsed and serialized by it, rather than by their kind or the interfaces
+// they implement:
+//
+//	func init() {
+//		runtime.RegisterFormat("ulid", runtime.NewFormatCodec(ulid.Parse,
+//			func(id ulid.ULID) (string, error) { return id.String(), nil }))
+//	}
+//
+// Like RegisterParamStyle, it's meant to be called from an init function,
+// and panics if the name or the Go type is taken by another registration,
+// or if the codec is the zero FormatCodec.
+func RegisterFormat(name string, codec FormatCodec) {
+	if codec.typ == nil {
+		panic("runtime: RegisterFormat codec must be made by NewFormatCodec")
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if _, dup := formatsByName[name]; dup {
+		panic(fmt.Sprintf("runtime: RegisterFormat called twice for format %q", name))
+	}
+	if _, dup := formatsByType[codec.typ]; dup {
+		panic(fmt.Sprintf("runtime: RegisterFormat called twice for type %s", codec.typ))
+	}
+	formatsByName[name] = codec
+	formatsByType[codec.typ] = codec
+}
+
+// LookupFormat returns the codec registered for the format name.
+func LookupFormat(name string) (FormatCodec, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	codec, found := formatsByName[name]
+	return codec, found
+}
+
+// lookupFormatType returns the codec registered for values of type t.
+func lookupFormatType(t reflect.Type) (FormatCodec, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	codec, found := formatsByType[t]
+	return codec, found
+}
+
+// isFormatType tells whether values of type t are parsed and formatted by a
+// registered codec, and so are primitives.
+func isFormatType(t reflect.Type) bool {
+	_, found := lookupFormatType(t)
+	return found
+}
+
+// bindFormat parses src with the codec registered for the type of v, which
+// must be settable, and sets v. It returns false when there's no codec.
+func bindFormat(src string, v reflect.Value) (bool, error) {
+	codec, found := lookupFormatType(v.Type())
+	if !found {
+		return false, nil
+	}
+	parsed, err := codec.parse(src)
+	if err != nil {
+		return true, fmt.Errorf("error parsing '%s' as %s: %w", src, codec.typ, err)
+	}
+	if parsed == nil {
+		// T is an interface, and the value is nil.
+		v.Set(reflect.Zero(v.Type()))
+	} else {
+		v.Set(reflect.ValueOf(parsed))
+	}
+	return true, nil
+}
+
+// formatValue formats v with the codec registered for its type. It returns
+// false when there's no codec.
+func formatValue(v reflect.Value) (string, bool, error) {
+	if !v.IsValid() {
+		return "", false, nil
+	}
+	codec, found := lookupFormatType(v.Type())
+	if !found {
+		return "", false, nil
+	}
+	s, err := codec.format(v.Interface())
+	return s, true, err
+}
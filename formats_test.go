@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// color is a struct, which would be bound as an object, but for its format.
+type color struct {
+	R, G, B uint8
+}
+
+// sku is a string, which would be bound as is, but for its format.
+type sku string
+
+func init() {
+	RegisterFormat("hex-color", NewFormatCodec(
+		func(s string) (color, error) {
+			var c color
+			if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+				return c, errors.New("expected #rrggbb")
+			}
+			return c, nil
+		},
+		func(c color) (string, error) {
+			return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), nil
+		}))
+	RegisterFormat("sku", NewFormatCodec(
+		func(s string) (sku, error) {
+			if len(s) != 6 {
+				return "", errors.New("expected 6 characters")
+			}
+			return sku("SKU-" + s), nil
+		},
+		func(s sku) (string, error) {
+			return string(s)[len("SKU-"):], nil
+		}))
+}
+
+func TestRegisterFormat(t *testing.T) {
+	codec, found := LookupFormat("hex-color")
+	require.True(t, found)
+	parsed, err := codec.Parse("#ff8000")
+	require.NoError(t, err)
+	assert.Equal(t, color{255, 128, 0}, parsed)
+
+	assert.PanicsWithValue(t, `runtime: RegisterFormat called twice for format "sku"`, func() {
+		RegisterFormat("sku", NewFormatCodec(func(s string) (int, error) { return 0, nil },
+			func(int) (string, error) { return "", nil }))
+	})
+	assert.Panics(t, func() { RegisterFormat("zero", FormatCodec{}) })
+}
+
+func TestFormatParameters(t *testing.T) {
+	styled, err := StyleParamWithLocation("simple", false, "color", ParamLocationPath, color{255, 128, 0})
+	require.NoError(t, err)
+	assert.Equal(t, "%23ff8000", styled)
+
+	var c color
+	require.NoError(t, BindStyledParameterWithOptions("simple", "color", styled, &c, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	}))
+	assert.Equal(t, color{255, 128, 0}, c)
+
+	styled, err = StyleParamWithLocation("form", true, "skus", ParamLocationQuery, []sku{"SKU-abc123", "SKU-def456"})
+	require.NoError(t, err)
+	assert.Equal(t, "skus=abc123&skus=def456", styled)
+
+	var skus []sku
+	q := url.Values{"skus": {"abc123", "def456"}, "color": {"#00ff00"}}
+	require.NoError(t, BindQueryParameter("form", true, true, "skus", q, &skus))
+	assert.Equal(t, []sku{"SKU-abc123", "SKU-def456"}, skus)
+
+	var optional *color
+	require.NoError(t, BindQueryParameter("form", true, false, "color", q, &optional))
+	assert.Equal(t, &color{0, 255, 0}, optional)
+
+	err = BindQueryParameter("form", true, true, "skus", url.Values{"skus": {"abc"}}, &skus)
+	assert.EqualError(t, err, "error setting array element: error parsing 'abc' as runtime.sku: expected 6 characters")
+}
+
+func TestFormatDeepObject(t *testing.T) {
+	type filter struct {
+		Color color `json:"color"`
+		SKUs  []sku `json:"skus"`
+	}
+
+	marshaled, err := MarshalDeepObject(map[string][]sku{"skus": {"SKU-abc123"}}, "f")
+	require.NoError(t, err)
+	assert.Equal(t, "f[skus][0]=abc123", marshaled)
+
+	var dst filter
+	params := url.Values{"f[color]": {"#010203"}, "f[skus][0]": {"abc123"}}
+	require.NoError(t, UnmarshalDeepObject(&dst, "f", params))
+	assert.Equal(t, filter{Color: color{1, 2, 3}, SKUs: []sku{"SKU-abc123"}}, dst)
+
+	form, err := MarshalForm(&filter{Color: color{1, 2, 3}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#010203"}, form["color"])
+
+	var bound filter
+	require.NoError(t, BindForm(&bound, form, nil, nil))
+	assert.Equal(t, color{1, 2, 3}, bound.Color)
+}
+
+func TestFormatPassword(t *testing.T) {
+	type login struct {
+		User     string         `json:"user"`
+		Password types.Password `json:"password"`
+	}
+
+	form, err := MarshalForm(&login{User: "gaben", Password: "hunter2"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hunter2"}, form["password"])
+
+	var bound login
+	require.NoError(t, BindForm(&bound, form, nil, nil))
+	assert.Equal(t, "hunter2", bound.Password.Reveal())
+
+	styled, err := StyleParamWithLocation("form", true, "token", ParamLocationQuery, types.Password("s3cret"))
+	require.NoError(t, err)
+	assert.Equal(t, "token=s3cret", styled)
+
+	// Bodies of other encodings send the secret too.
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteXML(w, http.StatusOK, login{User: "gaben", Password: "hunter2"}, nil))
+	assert.Contains(t, w.Body.String(), "<Password>hunter2</Password>")
+	bound = login{}
+	require.NoError(t, BindXML(newBodyRequest("application/xml", w.Body.String()), &bound))
+	assert.Equal(t, "hunter2", bound.Password.Reveal())
+
+	w = httptest.NewRecorder()
+	require.NoError(t, WriteYAML(w, http.StatusOK, login{User: "gaben", Password: "hunter2"}, nil))
+	assert.Equal(t, "user: gaben\npassword: hunter2\n", w.Body.String())
+}
@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// MarshalJSONQueryParam serializes a query parameter which is declared with
+// `content: application/json` rather than a style, producing a single
+// paramName=value pair, where value is the escaped JSON encoding of value.
+func MarshalJSONQueryParam(paramName string, value interface{}) (string, error) {
+	// HTML escaping would only make the query longer, since the value is
+	// query escaped anyway.
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(value); err != nil {
+		return "", fmt.Errorf("failed to marshal parameter '%s' as JSON: %w", paramName, err)
+	}
+	return url.QueryEscape(paramName) + "=" + url.QueryEscape(strings.TrimSuffix(buf.String(), "\n")), nil
+}
+
+// BindJSONQueryParam binds a query parameter which is declared with
+// `content: application/json` into dest, by unmarshaling its value as JSON.
+// Optional parameters are passed in as a pointer to a pointer, as with
+// BindQueryParameter, and are left untouched when absent.
+func BindJSONQueryParam(paramName string, required bool, queryParams url.Values, dest interface{}) (err error) {
+	defer recoverPanic(&err, "error binding query parameter '%s'", paramName)
+	if o, ok := dest.(types.OptionalValue); ok {
+		return bindOptional(o, required, paramName, ParamLocationQuery, func(dest interface{}) error {
+			return BindJSONQueryParam(paramName, false, queryParams, dest)
+		})
+	}
+	if err := checkDestination(paramName, dest, !required); err != nil {
+		return err
+	}
+	values, found := queryParams[paramName]
+	if !found {
+		if required {
+			return &RequiredParamError{ParamName: paramName, Location: ParamLocationQuery}
+		}
+		return nil
+	}
+	if len(values) != 1 {
+		return fmt.Errorf("multiple values for single value parameter '%s'", paramName)
+	}
+	if err := json.Unmarshal([]byte(values[0]), dest); err != nil {
+		return fmt.Errorf("error unmarshaling parameter '%s' as JSON: %w", paramName, err)
+	}
+	return validate(paramName, dest)
+}
@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONQueryParam(t *testing.T) {
+	type Filter struct {
+		Tags  []string `json:"tags"`
+		Limit int      `json:"limit"`
+	}
+	src := Filter{Tags: []string{"a&b", "c"}, Limit: 10}
+
+	marshaled, err := MarshalJSONQueryParam("filter", src)
+	require.NoError(t, err)
+	assert.Equal(t, "filter=%7B%22tags%22%3A%5B%22a%26b%22%2C%22c%22%5D%2C%22limit%22%3A10%7D", marshaled)
+
+	queryParams, err := url.ParseQuery(marshaled)
+	require.NoError(t, err)
+
+	var dst Filter
+	require.NoError(t, BindJSONQueryParam("filter", true, queryParams, &dst))
+	assert.Equal(t, src, dst)
+
+	var optional *Filter
+	require.NoError(t, BindJSONQueryParam("filter", false, queryParams, &optional))
+	assert.Equal(t, &src, optional)
+
+	var missing *Filter
+	require.NoError(t, BindJSONQueryParam("other", false, queryParams, &missing))
+	assert.Nil(t, missing)
+	assert.Error(t, BindJSONQueryParam("other", true, queryParams, &dst))
+
+	assert.Error(t, BindJSONQueryParam("filter", true, url.Values{"filter": {"{", "}"}}, &dst))
+	assert.Error(t, BindJSONQueryParam("filter", true, url.Values{"filter": {"{"}}, &dst))
+}
@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// msgpackContentType is the media type of MessagePack bodies.
+const msgpackContentType = "application/msgpack"
+
+func init() {
+	h := &codec.MsgpackHandle{}
+	// Strings are written as such, rather than as raw bytes, and read back
+	// as strings, and maps decode as they do from JSON.
+	h.WriteExt = true
+	h.RawToString = true
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	// application/x-msgpack is as common as the registered media type.
+	RegisterBodyCodec(msgpackContentType, handleCodec{h})
+	RegisterBodyCodec("application/x-msgpack", handleCodec{h})
+}
+
+// BindMsgpack decodes the MessagePack body of r into dst, as
+// BindMsgpackWithOptions does.
+func BindMsgpack(r *http.Request, dst interface{}) error {
+	return BindMsgpackWithOptions(r, dst, BodyOptions{})
+}
+
+// BindMsgpackWithOptions decodes the MessagePack body of r, as declared by
+// the application/msgpack media type, into dst with the codec registered
+// for it, as BindCBORWithOptions does for CBOR. Handlers which bind bodies
+// with BindBodyWithOptions accept MessagePack as they accept JSON.
+func BindMsgpackWithOptions(r *http.Request, dst interface{}, opts BodyOptions) error {
+	return bindCodecBody(r, dst, msgpackContentType, opts)
+}
+
+// WriteMsgpack writes a response with the given status and headers, whose
+// body is v encoded as MessagePack, as WriteCBOR does for CBOR. The
+// Content-Type is application/msgpack, unless headers set another.
+func WriteMsgpack(w http.ResponseWriter, status int, v interface{}, headers http.Header) error {
+	return writeCodecBody(w, status, v, headers, msgpackContentType)
+}
+
+// MarshalMsgpack encodes v as MessagePack, such as for the body of a request.
+func MarshalMsgpack(v interface{}) ([]byte, error) {
+	return marshalCodecBody(v, msgpackContentType)
+}
@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func TestBindMsgpack(t *testing.T) {
+	// {"name": "Rex"}, as encoded by another implementation.
+	var pet xmlPet
+	body := []byte{0x81, 0xa4, 'n', 'a', 'm', 'e', 0xa3, 'R', 'e', 'x'}
+	require.NoError(t, BindMsgpack(newBodyRequest("application/msgpack", body), &pet))
+	assert.Equal(t, xmlPet{Name: "Rex"}, pet)
+
+	err := BindMsgpack(newBodyRequest("application/msgpack", []byte{0x81, 0xa2, 'i', 'd', 0x01}), &xmlPet{})
+	assert.EqualError(t, err, "validation failed: name is required")
+
+	err = BindMsgpack(newBodyRequest("application/msgpack", []byte{0x81, 0xa4}), &xmlPet{})
+	assert.ErrorContains(t, err, "error decoding MSGPACK body: ")
+
+	var generic map[string]interface{}
+	require.NoError(t, BindMsgpack(newBodyRequest("application/msgpack", body), &generic))
+	assert.Equal(t, map[string]interface{}{"name": "Rex"}, generic)
+}
+
+func TestWriteMsgpack(t *testing.T) {
+	reading := cborReading{
+		Sensor: "t1",
+		Value:  21.5,
+		Day:    types.Date{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteMsgpack(w, http.StatusOK, reading, nil))
+	assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+
+	var bound cborReading
+	require.NoError(t, BindMsgpack(newBodyRequest("application/msgpack", w.Body.Bytes()), &bound))
+	assert.Equal(t, reading, bound)
+
+	data, err := MarshalMsgpack(xmlPet{ID: 1, Name: "Rex"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x82, 0xa2, 'i', 'd', 0x01, 0xa4, 'n', 'a', 'm', 'e', 0xa3, 'R', 'e', 'x'}, data)
+}
@@ -0,0 +1,588 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// defaultMultipartMemory is the number of bytes of a multipart form which
+// are held in memory, beyond which files are stored in temporary files.
+const defaultMultipartMemory = 32 << 20
+
+var (
+	fileType  = reflect.TypeOf(types.File{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// BindMultipartForm parses the multipart/form-data body of r, unless it's
+// been parsed already, and binds its parts to the fields of the struct dst
+// points to, as a multipart requestBody describes them. Each field is bound
+// to the parts named by its form tag, or else by its json tag or its name:
+//
+//	type UploadBody struct {
+//		Title    string       `form:"title"`
+//		Avatar   types.File   `form:"avatar"`
+//		Photos   types.Files  `form:"photos"`
+//		Tags     []string     `form:"tags"`
+//		Metadata *PhotoMeta   `form:"metadata"`
+//	}
+//
+// Files bind to types.File, or to types.Files or []types.File when their
+// part is repeated, and the content of a part binds to []byte. Arrays are
+// given as repeated parts, each bound to an element, and objects, such as
+// structs and maps, as parts holding JSON. Other fields bind a single part
+// as a parameter would. Fields without parts are left alone, and pointer
+// fields are only allocated when they have parts. When all the fields bind,
+// a Validatable struct is validated.
+func BindMultipartForm(r *http.Request, dst interface{}) error {
+	// Without limits, the form is parsed by r.ParseMultipartForm, whose
+	// temporary files are removed by the server.
+	_, err := BindMultipartFormWithOptions(r, dst, MultipartOptions{})
+	return err
+}
+
+// MultipartOptions defines optional arguments for
+// BindMultipartFormWithOptions and MarshalMultipartWithOptions.
+type MultipartOptions struct {
+	// Encodings are the encodings of the parts, by name, as given by the
+	// encoding map of a multipart media type in the spec. A JSON
+	// ContentType, such as application/json or application/merge-patch+json,
+	// gives a whole field as a single JSON part, while for files it lists
+	// the media types their parts may have, such as "image/png, image/*". A
+	// Style gives a field as it's styled as a query parameter, with each
+	// value in a part, Explode defaulting to whether the style is form, as
+	// in the spec, or deepObject. Headers are written with the parts of a
+	// field.
+	Encodings map[string]RequestBodyEncoding
+	// MaxMemory is the number of bytes of the body which are held in
+	// memory, beyond which files are stored in temporary files. It's 32 MB
+	// when it's 0.
+	MaxMemory int64
+	// TempDir is the directory of the temporary files, which is the default
+	// of os.CreateTemp when it's empty.
+	TempDir string
+	// MaxParts, when it's positive, limits the number of parts.
+	MaxParts int
+	// MaxPartSize, when it's positive, limits the size of every part.
+	MaxPartSize int64
+}
+
+// BindMultipartFormWithOptions works like BindMultipartForm, binding the
+// parts as their encodings in opts say. The headers of file parts are kept
+// by types.File, but those of other parts are dropped by multipart.Form.
+//
+// When a TempDir or limits on the parts are set, the body is read by the
+// binder itself rather than by http.Request.ParseMultipartForm, so that
+// r.MultipartForm doesn't hold its parts. Exceeding the limits fails with a
+// *LimitExceededError.
+//
+// Files which don't fit in MaxMemory are stored in temporary files, which
+// are removed by cleanup, once the files are no longer needed:
+//
+//	cleanup, err := runtime.BindMultipartFormWithOptions(r, &body, opts)
+//	defer cleanup()
+//
+// cleanup is never nil, and when binding fails, the files are removed
+// already.
+func BindMultipartFormWithOptions(r *http.Request, dst interface{}, opts MultipartOptions) (cleanup func() error, err error) {
+	noop := func() error { return nil }
+	var remove func() error
+	defer func() {
+		switch {
+		case remove == nil:
+			cleanup = noop
+		case err != nil:
+			_ = remove()
+			cleanup = noop
+		default:
+			cleanup = remove
+		}
+	}()
+	defer recoverPanic(&err, "error binding multipart form")
+	v, err := multipartDestination(dst)
+	if err != nil {
+		return nil, err
+	}
+	values, files, remove, err := readMultipartRequest(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := bindMultipartStruct(v, values, files, opts.Encodings); err != nil {
+		return nil, err
+	}
+	return nil, validate("", dst)
+}
+
+// readMultipartRequest returns the values and files of the multipart body
+// of r, parsing it unless it's been parsed already, and a function which
+// removes the temporary files of the files it's parsed.
+func readMultipartRequest(r *http.Request, opts MultipartOptions) (map[string][]string, map[string][]types.File, func() error, error) {
+	if r.MultipartForm == nil && (opts.TempDir != "" || opts.MaxParts > 0 || opts.MaxPartSize > 0) {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing multipart form: %w", err)
+		}
+		values, files, paths, err := readMultipartForm(mr, opts)
+		if err != nil {
+			_ = removeTempFiles(paths)
+			return nil, nil, nil, err
+		}
+		return values, files, func() error { return removeTempFiles(paths) }, nil
+	}
+
+	remove := func() error { return nil }
+	if r.MultipartForm == nil {
+		maxMemory := opts.MaxMemory
+		if maxMemory <= 0 {
+			maxMemory = defaultMultipartMemory
+		}
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing multipart form: %w", err)
+		}
+		remove = r.MultipartForm.RemoveAll
+	}
+	return r.MultipartForm.Value, multipartFormFiles(r.MultipartForm), remove, nil
+}
+
+// multipartDestination checks that dst points to a struct, and returns it.
+func multipartDestination(dst interface{}) (reflect.Value, error) {
+	if err := checkDestination("", dst, false); err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.Indirect(reflect.ValueOf(dst))
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("binding a multipart form requires a pointer to a struct, not %T", dst)
+	}
+	return v, nil
+}
+
+// multipartFormFiles returns the files of form as types.File.
+func multipartFormFiles(form *multipart.Form) map[string][]types.File {
+	files := make(map[string][]types.File, len(form.File))
+	for name, headers := range form.File {
+		files[name] = make([]types.File, len(headers))
+		for i, header := range headers {
+			files[name][i].InitFromMultipart(header)
+		}
+	}
+	return files
+}
+
+// bindMultipartStruct binds the values and files of the parts of a form to
+// the fields of the struct v, as their encodings say.
+func bindMultipartStruct(v reflect.Value, values map[string][]string, files map[string][]types.File, encodings map[string]RequestBodyEncoding) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := multipartFieldName(t.Field(i))
+		if !ok || !v.Field(i).CanSet() {
+			continue
+		}
+		var err error
+		if enc := encodings[name]; enc.Style != "" {
+			err = bindStyledMultipartField(v.Field(i), name, enc, values)
+		} else {
+			err = bindMultipartField(v.Field(i), values[name], files[name], enc.ContentType)
+		}
+		if err != nil {
+			return fmt.Errorf("error binding part '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// multipartFieldName returns the name of the parts of a field, given by its
+// form tag, or else by its json tag or its name. It returns false for fields
+// which are skipped with a "-" tag.
+func multipartFieldName(sf reflect.StructField) (string, bool) {
+	tag, ok := sf.Tag.Lookup("form")
+	if !ok {
+		tag = sf.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return sf.Name, true
+}
+
+// bindStyledMultipartField binds a field whose parts are styled as a query
+// parameter, so that the parts of a deepObject are named by its keys.
+func bindStyledMultipartField(v reflect.Value, name string, enc RequestBodyEncoding, values url.Values) error {
+	p := paramTag{
+		name:     name,
+		style:    enc.Style,
+		explode:  multipartExplode(enc),
+		required: enc.Required != nil && *enc.Required,
+	}
+	var err error
+	bindField(p, v, func(p paramTag, dest interface{}) {
+		err = BindQueryParameterWithOptions(p.style, p.name, values, dest,
+			BindQueryParameterOptions{Explode: p.explode, Required: p.required})
+	})
+	return err
+}
+
+// multipartExplode returns whether a styled multipart field is exploded,
+// which, as in the spec, defaults to whether its style is form, or
+// deepObject, which can only be exploded.
+func multipartExplode(enc RequestBodyEncoding) bool {
+	if enc.Explode != nil {
+		return *enc.Explode
+	}
+	return enc.Style == "form" || enc.Style == "deepObject"
+}
+
+// bindMultipartField binds the values and files of the parts of a field,
+// allocating it when it's a pointer. The content type of its encoding, when
+// it's given, is the JSON media type of the whole field, or lists the media
+// types of its files.
+func bindMultipartField(v reflect.Value, values []string, files []types.File, contentType string) error {
+	if len(values) == 0 && len(files) == 0 {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		elem := reflect.New(v.Type().Elem())
+		if err := bindMultipartField(elem.Elem(), values, files, contentType); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t == fileType:
+		if len(files) != 1 {
+			return fmt.Errorf("expected a single file, got %d files", len(files))
+		}
+		if err := checkPartContentType(files[0], contentType); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(files[0]))
+		return nil
+	case t.Kind() == reflect.Slice && t.Elem() == fileType:
+		result := reflect.MakeSlice(t, len(files), len(files))
+		for i, file := range files {
+			if err := checkPartContentType(file, contentType); err != nil {
+				return err
+			}
+			result.Index(i).Set(reflect.ValueOf(file))
+		}
+		v.Set(result)
+		return nil
+	case t == bytesType:
+		content, err := singlePartContent(values, files)
+		if err != nil {
+			return err
+		}
+		v.SetBytes(content)
+		return nil
+	case isJSONMediaType(contentType):
+		content, err := singlePartContent(values, files)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(content, v.Addr().Interface())
+	case t.Kind() == reflect.Slice && !isBinderSlice(t) && !isFormatType(t):
+		// Arrays are given as repeated parts, whether values or files.
+		n := len(values) + len(files)
+		result := reflect.MakeSlice(t, n, n)
+		for i := 0; i < n; i++ {
+			var err error
+			if i < len(values) {
+				err = bindMultipartField(result.Index(i), values[i:i+1], nil, contentType)
+			} else {
+				err = bindMultipartField(result.Index(i), nil, files[i-len(values):i-len(values)+1], contentType)
+			}
+			if err != nil {
+				return fmt.Errorf("error binding element %d: %w", i, err)
+			}
+		}
+		v.Set(result)
+		return nil
+	case t.Kind() == reflect.Map || isObjectDestination(t):
+		// Objects are given as JSON.
+		content, err := singlePartContent(values, files)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(content, v.Addr().Interface())
+	default:
+		if len(values) != 1 {
+			return fmt.Errorf("expected a single value, got %d values and %d files", len(values), len(files))
+		}
+		return BindStringToObject(values[0], v.Addr().Interface())
+	}
+}
+
+// isJSONMediaType tells whether contentType is a JSON media type, such as
+// application/json or application/problem+json.
+func isJSONMediaType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == jsonContentType || strings.HasSuffix(mediaType, "+json")
+}
+
+// checkPartContentType checks that the Content-Type of a file part, which
+// is application/octet-stream when it's absent, is one of the comma
+// separated media types accepted, which may be ranges such as image/*. Any
+// media type is accepted when accepted is empty.
+func checkPartContentType(file types.File, accepted string) error {
+	if accepted == "" {
+		return nil
+	}
+	mediaType := "application/octet-stream"
+	if contentType := file.ContentType(); contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("invalid Content-Type of file '%s': %w", file.Filename(), err)
+		}
+	}
+	for _, accept := range strings.Split(accepted, ",") {
+		accept, _, _ = strings.Cut(accept, ";")
+		accept = strings.ToLower(strings.TrimSpace(accept))
+		if accept == "*/*" || accept == mediaType ||
+			strings.HasSuffix(accept, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(accept, "*")) {
+			return nil
+		}
+	}
+	return fmt.Errorf("file '%s' is %s, not %s", file.Filename(), mediaType, accepted)
+}
+
+// singlePartContent returns the content of a single part, which may be a
+// value, or a file when the part had a file name.
+func singlePartContent(values []string, files []types.File) ([]byte, error) {
+	if len(values)+len(files) != 1 {
+		return nil, fmt.Errorf("expected a single part, got %d parts", len(values)+len(files))
+	}
+	if len(values) == 1 {
+		return []byte(values[0]), nil
+	}
+	return files[0].Bytes()
+}
+
+// MarshalMultipart writes the fields of the struct v points to as the parts
+// of a multipart/form-data body to dst, and returns the Content-Type of the
+// body, with its boundary. It's the counterpart of BindMultipartForm, so
+// parts are named and written as it binds them: files as file parts, whose
+// content is streamed from them, arrays as repeated parts, objects as
+// application/json parts, and other values as they're styled as
+// parameters. Nil pointers, and zero fields tagged omitempty, are left out.
+func MarshalMultipart(dst io.Writer, v interface{}) (string, error) {
+	return MarshalMultipartWithOptions(dst, v, MultipartOptions{})
+}
+
+// MarshalMultipartWithOptions works like MarshalMultipart, writing the parts
+// as their encodings in opts say. Files without a Content-Type of their own
+// take that of their encoding, when it's a single media type.
+func MarshalMultipartWithOptions(dst io.Writer, v interface{}, opts MultipartOptions) (_ string, err error) {
+	defer recoverPanic(&err, "error marshaling multipart form")
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("marshaling a multipart form requires a struct, not %T", v)
+	}
+
+	mw := multipart.NewWriter(dst)
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, ok := multipartFieldName(sf)
+		field := rv.Field(i)
+		if !ok || !field.CanInterface() {
+			continue
+		}
+		if field.IsZero() && hasOmitEmpty(sf) {
+			continue
+		}
+		if enc := opts.Encodings[name]; enc.Style != "" {
+			err = writeStyledMultipartField(mw, name, field, enc)
+		} else {
+			err = writeMultipartField(mw, name, field, enc)
+		}
+		if err != nil {
+			return "", fmt.Errorf("error writing part '%s': %w", name, err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+	return mw.FormDataContentType(), nil
+}
+
+// hasOmitEmpty tells whether the form or json tag of a field has the
+// omitempty option.
+func hasOmitEmpty(sf reflect.StructField) bool {
+	tag, ok := sf.Tag.Lookup("form")
+	if !ok {
+		tag = sf.Tag.Get("json")
+	}
+	_, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeMultipartField writes the parts of a field, as bindMultipartField
+// binds them, with the headers of its encoding.
+func writeMultipartField(mw *multipart.Writer, name string, v reflect.Value, enc RequestBodyEncoding) error {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		return writeMultipartField(mw, name, v.Elem(), enc)
+	}
+
+	t := v.Type()
+	switch {
+	case t == fileType:
+		file := v.Interface().(types.File)
+		contentType := file.ContentType()
+		if contentType == "" {
+			contentType = singleMediaType(enc.ContentType, "application/octet-stream")
+		}
+		w, err := createMultipartPart(mw, name, file.Filename(), contentType, enc.Headers)
+		if err != nil {
+			return err
+		}
+		_, err = file.WriteTo(w)
+		return err
+	case t == bytesType:
+		contentType := singleMediaType(enc.ContentType, "application/octet-stream")
+		w, err := createMultipartPart(mw, name, "", contentType, enc.Headers)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(v.Bytes())
+		return err
+	case isJSONMediaType(enc.ContentType):
+		return writeJSONPart(mw, name, v, enc.ContentType, enc.Headers)
+	case t.Kind() == reflect.Slice && !isBinderSlice(t) && !isFormatType(t):
+		for i := 0; i < v.Len(); i++ {
+			if err := writeMultipartField(mw, name, v.Index(i), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	case t.Kind() == reflect.Map || isObjectDestination(t):
+		return writeJSONPart(mw, name, v, jsonContentType, enc.Headers)
+	default:
+		s, err := StyleParamOptions{}.valueToString(v)
+		if err != nil {
+			return err
+		}
+		w, err := createMultipartPart(mw, name, "", enc.ContentType, enc.Headers)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, s)
+		return err
+	}
+}
+
+// writeStyledMultipartField writes a field styled as a query parameter, as
+// its encoding says, with each of its values in a part named by its key.
+func writeStyledMultipartField(mw *multipart.Writer, name string, v reflect.Value, enc RequestBodyEncoding) error {
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.IsNil() {
+		return nil
+	}
+	styled, err := StyleParamWithOptions(enc.Style, name, v.Interface(), StyleParamOptions{
+		ParamLocation: ParamLocationQuery,
+		Explode:       multipartExplode(enc),
+		OmitNil:       true,
+	})
+	if errors.Is(err, ErrOmitParam) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// The pairs are split by hand, rather than parsed as url.Values, to
+	// keep the order of the parts.
+	for _, pair := range strings.Split(styled, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		if key, err = url.QueryUnescape(key); err != nil {
+			return err
+		}
+		if value, err = url.QueryUnescape(value); err != nil {
+			return err
+		}
+		w, err := createMultipartPart(mw, key, "", enc.ContentType, enc.Headers)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSONPart writes v as a JSON part of the given media type.
+func writeJSONPart(mw *multipart.Writer, name string, v reflect.Value, contentType string, headers map[string]string) error {
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	w, err := createMultipartPart(mw, name, "", contentType, headers)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// singleMediaType returns the content type of an encoding when it's a
+// single media type, rather than a list or a range such as image/*, or else
+// fallback.
+func singleMediaType(contentType, fallback string) string {
+	if contentType == "" || strings.ContainsAny(contentType, ",*") {
+		return fallback
+	}
+	return contentType
+}
+
+// createMultipartPart creates a form part with the given headers, named
+// name, and with a file name and Content-Type unless they're empty. As in
+// the spec, a Content-Type among the headers is ignored.
+func createMultipartPart(mw *multipart.Writer, name, filename, contentType string, headers map[string]string) (io.Writer, error) {
+	h := make(textproto.MIMEHeader)
+	for key, value := range headers {
+		h.Set(key, value)
+	}
+	h.Set("Content-Disposition", multipartDisposition(name, filename))
+	h.Del("Content-Type")
+	if contentType != "" {
+		h.Set("Content-Type", contentType)
+	}
+	return mw.CreatePart(h)
+}
+
+// multipartDisposition returns the Content-Disposition of a form part,
+// escaping its names as multipart.Writer.CreateFormFile does.
+func multipartDisposition(name, filename string) string {
+	disposition := `form-data; name="` + quoteEscaper.Replace(name) + `"`
+	if filename != "" {
+		disposition += `; filename="` + quoteEscaper.Replace(filename) + `"`
+	}
+	return disposition
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
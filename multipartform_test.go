@@ -0,0 +1,253 @@
+package runtime
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type photoMeta struct {
+	Camera string `json:"camera"`
+	ISO    int    `json:"iso"`
+}
+
+type uploadBody struct {
+	Title    string            `form:"title"`
+	Count    *int              `json:"count,omitempty"`
+	Avatar   types.File        `form:"avatar"`
+	Photos   types.Files       `form:"photos"`
+	Tags     []string          `form:"tags"`
+	Metadata *photoMeta        `form:"metadata"`
+	Labels   map[string]string `form:"labels"`
+	Extra    []photoMeta       `form:"extra"`
+	Raw      []byte            `form:"raw"`
+	Missing  *string           `form:"missing"`
+	Skipped  string            `form:"-"`
+}
+
+func (b uploadBody) Validate() error {
+	if b.Title == "invalid" {
+		return errors.New("invalid title")
+	}
+	return nil
+}
+
+type multipartPart struct {
+	name, filename, contentType, content string
+}
+
+func newMultipartRequest(t *testing.T, parts ...multipartPart) *http.Request {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		h := make(textproto.MIMEHeader)
+		disposition := `form-data; name="` + p.name + `"`
+		if p.filename != "" {
+			disposition += `; filename="` + p.filename + `"`
+		}
+		h.Set("Content-Disposition", disposition)
+		if p.contentType != "" {
+			h.Set("Content-Type", p.contentType)
+		}
+		w, err := mw.CreatePart(h)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(p.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+	r := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestBindMultipartFormRequest(t *testing.T) {
+	r := newMultipartRequest(t,
+		multipartPart{name: "title", content: "Holiday"},
+		multipartPart{name: "count", content: "2"},
+		multipartPart{name: "avatar", filename: "me.png", contentType: "image/png", content: "png"},
+		multipartPart{name: "photos", filename: "a.jpg", content: "a"},
+		multipartPart{name: "photos", filename: "b.jpg", content: "b"},
+		multipartPart{name: "tags", content: "beach"},
+		multipartPart{name: "tags", content: "sun"},
+		multipartPart{name: "metadata", contentType: "application/json", content: `{"camera":"X100","iso":200}`},
+		multipartPart{name: "labels", filename: "labels.json", contentType: "application/json", content: `{"a":"b"}`},
+		multipartPart{name: "extra", content: `{"iso":100}`},
+		multipartPart{name: "extra", content: `{"iso":400}`},
+		multipartPart{name: "raw", filename: "raw.bin", content: "\x00\x01"},
+		multipartPart{name: "Skipped", content: "x"},
+	)
+
+	var body uploadBody
+	require.NoError(t, BindMultipartForm(r, &body))
+	assert.Equal(t, "Holiday", body.Title)
+	assert.Equal(t, 2, *body.Count)
+	assert.Equal(t, "me.png", body.Avatar.Filename())
+	assert.Equal(t, "image/png", body.Avatar.ContentType())
+	assert.Equal(t, []string{"a.jpg", "b.jpg"}, body.Photos.Filenames())
+	assert.Equal(t, []string{"beach", "sun"}, body.Tags)
+	assert.Equal(t, &photoMeta{Camera: "X100", ISO: 200}, body.Metadata)
+	assert.Equal(t, map[string]string{"a": "b"}, body.Labels)
+	assert.Equal(t, []photoMeta{{ISO: 100}, {ISO: 400}}, body.Extra)
+	assert.Equal(t, []byte{0, 1}, body.Raw)
+	assert.Nil(t, body.Missing)
+	assert.Empty(t, body.Skipped)
+
+	content, err := body.Photos[1].Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "b", string(content))
+}
+
+func TestBindMultipartFormRequestErrors(t *testing.T) {
+	var body uploadBody
+	r := newMultipartRequest(t, multipartPart{name: "count", content: "two"})
+	assert.ErrorContains(t, BindMultipartForm(r, &body), "error binding part 'count'")
+
+	r = newMultipartRequest(t, multipartPart{name: "title", content: "a"}, multipartPart{name: "title", content: "b"})
+	assert.EqualError(t, BindMultipartForm(r, &body),
+		"error binding part 'title': expected a single value, got 2 values and 0 files")
+
+	r = newMultipartRequest(t, multipartPart{name: "avatar", content: "not a file"})
+	assert.EqualError(t, BindMultipartForm(r, &body), "error binding part 'avatar': expected a single file, got 0 files")
+
+	r = newMultipartRequest(t, multipartPart{name: "title", content: "invalid"})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, BindMultipartForm(r, &body), &validationErr)
+
+	r = httptest.NewRequest(http.MethodPost, "/upload", nil)
+	assert.ErrorContains(t, BindMultipartForm(r, &body), "error parsing multipart form")
+
+	assert.EqualError(t, BindMultipartForm(r, &[]string{}),
+		"binding a multipart form requires a pointer to a struct, not *[]string")
+}
+
+func TestMarshalMultipart(t *testing.T) {
+	count := 2
+	body := uploadBody{
+		Title:    `Holiday "2024"`,
+		Count:    &count,
+		Avatar:   types.NewFileFromReader(strings.NewReader("png"), "me.png", "image/png", -1),
+		Photos:   types.Files{types.NewFileFromReader(strings.NewReader("a"), "a.jpg", "", 1)},
+		Tags:     []string{"beach", "sun"},
+		Metadata: &photoMeta{Camera: "X100", ISO: 200},
+		Extra:    []photoMeta{{ISO: 100}, {ISO: 400}},
+		Raw:      []byte{0, 1},
+		Skipped:  "x",
+	}
+
+	var buf bytes.Buffer
+	contentType, err := MarshalMultipart(&buf, &body)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(contentType, "multipart/form-data; boundary="))
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	r.Header.Set("Content-Type", contentType)
+	require.NoError(t, r.ParseMultipartForm(1<<20))
+	assert.Equal(t, "image/png", r.MultipartForm.File["avatar"][0].Header.Get("Content-Type"))
+	assert.Equal(t, "application/octet-stream", r.MultipartForm.File["photos"][0].Header.Get("Content-Type"))
+	assert.NotContains(t, r.MultipartForm.Value, "Skipped")
+	assert.NotContains(t, r.MultipartForm.Value, "missing")
+
+	var bound uploadBody
+	require.NoError(t, BindMultipartForm(r, &bound))
+	assert.Equal(t, body.Title, bound.Title)
+	assert.Equal(t, 2, *bound.Count)
+	assert.Equal(t, "me.png", bound.Avatar.Filename())
+	assert.Equal(t, []string{"a.jpg"}, bound.Photos.Filenames())
+	assert.Equal(t, body.Tags, bound.Tags)
+	assert.Equal(t, body.Metadata, bound.Metadata)
+	assert.Equal(t, body.Extra, bound.Extra)
+	assert.Equal(t, body.Raw, bound.Raw)
+	content, err := bound.Avatar.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(content))
+
+	_, err = MarshalMultipart(&buf, []string{})
+	assert.EqualError(t, err, "marshaling a multipart form requires a struct, not []string")
+}
+
+type encodedUploadBody struct {
+	Avatar   types.File        `form:"avatar"`
+	Metadata photoMeta         `form:"metadata"`
+	Tags     []string          `form:"tags"`
+	Filter   map[string]string `form:"filter"`
+	Notes    []string          `form:"notes"`
+}
+
+func TestMultipartEncodings(t *testing.T) {
+	explode := false
+	opts := MultipartOptions{Encodings: map[string]RequestBodyEncoding{
+		"avatar":   {ContentType: "image/png, image/jpeg", Headers: map[string]string{"Content-ID": "<avatar>"}},
+		"metadata": {ContentType: "application/vnd.photo+json", Headers: map[string]string{"Content-Type": "text/plain"}},
+		"tags":     {Style: "pipeDelimited", Explode: &explode},
+		"filter":   {Style: "deepObject"},
+		"notes":    {ContentType: "application/json"},
+	}}
+	body := encodedUploadBody{
+		Avatar:   types.NewFileFromReader(strings.NewReader("png"), "me.png", "image/png", -1),
+		Metadata: photoMeta{Camera: "X100", ISO: 200},
+		Tags:     []string{"beach", "sun set"},
+		Filter:   map[string]string{"kind": "photo"},
+		Notes:    []string{"one", "two"},
+	}
+
+	var buf bytes.Buffer
+	contentType, err := MarshalMultipartWithOptions(&buf, &body, opts)
+	require.NoError(t, err)
+	_, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+
+	mr := multipart.NewReader(bytes.NewReader(buf.Bytes()), params["boundary"])
+	var parts []multipartPart
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		if part.FormName() == "avatar" {
+			assert.Equal(t, "<avatar>", part.Header.Get("Content-ID"))
+		}
+		parts = append(parts, multipartPart{part.FormName(), part.FileName(), part.Header.Get("Content-Type"), string(content)})
+	}
+	assert.Equal(t, []multipartPart{
+		{"avatar", "me.png", "image/png", "png"},
+		{"metadata", "", "application/vnd.photo+json", `{"camera":"X100","iso":200}`},
+		{"tags", "", "", "beach|sun set"},
+		{"filter[kind]", "", "", "photo"},
+		{"notes", "", "application/json", `["one","two"]`},
+	}, parts)
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", &buf)
+	r.Header.Set("Content-Type", contentType)
+	var bound encodedUploadBody
+	_, err = BindMultipartFormWithOptions(r, &bound, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "<avatar>", bound.Avatar.Header().Get("Content-ID"))
+	assert.Equal(t, body.Metadata, bound.Metadata)
+	assert.Equal(t, body.Tags, bound.Tags)
+	assert.Equal(t, body.Filter, bound.Filter)
+	assert.Equal(t, body.Notes, bound.Notes)
+
+	r = newMultipartRequest(t, multipartPart{name: "avatar", filename: "me.gif", contentType: "image/gif", content: "gif"})
+	_, err = BindMultipartFormWithOptions(r, &bound, opts)
+	assert.EqualError(t, err, "error binding part 'avatar': file 'me.gif' is image/gif, not image/png, image/jpeg")
+
+	opts.Encodings["avatar"] = RequestBodyEncoding{ContentType: "image/*"}
+	_, err = BindMultipartFormWithOptions(r, &bound, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "me.gif", bound.Avatar.Filename())
+}
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// LimitExceededError is returned when a request body exceeds one of the
+// limits of its options, such as those of MultipartOptions or BodyOptions,
+// which is best answered with the status StatusCode returns, 413 Request
+// Entity Too Large. For multipart bodies, exceeding MaxPartSize matches
+// ErrPartTooLarge with errors.Is, and exceeding the other limits matches
+// ErrMultipartTooLarge.
+type LimitExceededError struct {
+	// Limit is the name of the option which was exceeded, such as
+	// "MaxParts".
+	Limit string
+	// Max is the value of the limit.
+	Max int64
+	// Part is the name of the part which exceeded it, unless it's a limit
+	// of the whole body.
+	Part string
+}
+
+func (e *LimitExceededError) Error() string {
+	if e.Part != "" {
+		return fmt.Sprintf("part '%s' exceeds the limit %s=%d", e.Part, e.Limit, e.Max)
+	}
+	return fmt.Sprintf("request body exceeds the limit %s=%d", e.Limit, e.Max)
+}
+
+// StatusCode returns http.StatusRequestEntityTooLarge.
+func (e *LimitExceededError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// Is matches ErrPartTooLarge or ErrMultipartTooLarge, depending on the
+// multipart limit which was exceeded.
+func (e *LimitExceededError) Is(target error) bool {
+	switch e.Limit {
+	case "MaxPartSize":
+		return target == ErrPartTooLarge
+	case "MaxParts", "MaxMemory", "MaxTotalSize":
+		return target == ErrMultipartTooLarge
+	default:
+		return false
+	}
+}
+
+// readMultipartForm reads the parts of a multipart body, as
+// multipart.Reader.ReadForm does, but within the limits of opts. Values, and
+// files while they fit in MaxMemory, are held in memory, and other files
+// are stored in temporary files in TempDir, whose paths are returned so
+// that they can be removed, even when it fails.
+func readMultipartForm(mr *multipart.Reader, opts MultipartOptions) (map[string][]string, map[string][]types.File, []string, error) {
+	maxMemory := opts.MaxMemory
+	if maxMemory <= 0 {
+		maxMemory = defaultMultipartMemory
+	}
+	memory := maxMemory
+	values := make(map[string][]string)
+	files := make(map[string][]types.File)
+	var paths []string
+
+	for count := 0; ; count++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return values, files, paths, nil
+		}
+		if err != nil {
+			return nil, nil, paths, fmt.Errorf("error reading multipart body: %w", err)
+		}
+		if opts.MaxParts > 0 && count == opts.MaxParts {
+			return nil, nil, paths, &LimitExceededError{Limit: "MaxParts", Max: int64(opts.MaxParts)}
+		}
+		name := part.FormName()
+		var r io.Reader = part
+		if opts.MaxPartSize > 0 {
+			r = &limitedReader{r: part, remaining: opts.MaxPartSize,
+				err: &LimitExceededError{Limit: "MaxPartSize", Max: opts.MaxPartSize, Part: name}}
+		}
+
+		var buf bytes.Buffer
+		n, err := io.CopyN(&buf, r, memory+1)
+		if err != nil && err != io.EOF {
+			return nil, nil, paths, fmt.Errorf("error reading part '%s': %w", name, err)
+		}
+		if n <= memory {
+			memory -= n
+			if part.FileName() == "" {
+				values[name] = append(values[name], buf.String())
+			} else {
+				files[name] = append(files[name], types.NewFileFromPart(part.Header, buf.Bytes(), "", n))
+			}
+			continue
+		}
+		if part.FileName() == "" {
+			return nil, nil, paths, &LimitExceededError{Limit: "MaxMemory", Max: maxMemory, Part: name}
+		}
+
+		// The file doesn't fit in memory, so it's stored in a temporary file.
+		f, err := os.CreateTemp(opts.TempDir, "multipart-")
+		if err != nil {
+			return nil, nil, paths, fmt.Errorf("error storing part '%s': %w", name, err)
+		}
+		paths = append(paths, f.Name())
+		size, err := io.Copy(f, io.MultiReader(&buf, r))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, nil, paths, fmt.Errorf("error storing part '%s': %w", name, err)
+		}
+		files[name] = append(files[name], types.NewFileFromPart(part.Header, nil, f.Name(), size))
+	}
+}
+
+// removeTempFiles removes the temporary files of readMultipartForm,
+// returning the first error removing them.
+func removeTempFiles(paths []string) error {
+	var err error
+	for _, path := range paths {
+		if removeErr := os.Remove(path); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+			err = removeErr
+		}
+	}
+	return err
+}
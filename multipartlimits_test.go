@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindMultipartFormLimits(t *testing.T) {
+	parts := []multipartPart{
+		{name: "title", content: "Holiday"},
+		{name: "avatar", filename: "me.png", contentType: "image/png", content: "small"},
+		{name: "photos", filename: "a.jpg", contentType: "image/jpeg", content: strings.Repeat("a", 100)},
+		{name: "photos", filename: "b.jpg", contentType: "image/jpeg", content: strings.Repeat("b", 100)},
+	}
+
+	// Files which don't fit in memory are stored in TempDir until they're
+	// cleaned up.
+	dir := t.TempDir()
+	r := newMultipartRequest(t, parts...)
+	var body uploadBody
+	cleanup, err := BindMultipartFormWithOptions(r, &body, MultipartOptions{MaxMemory: 150, TempDir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, "Holiday", body.Title)
+	assert.Equal(t, "me.png", body.Avatar.Filename())
+	assert.Equal(t, "image/png", body.Avatar.ContentType())
+	require.Len(t, body.Photos, 2)
+	content, err := body.Photos[1].Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("b", 100), string(content))
+	assert.Equal(t, int64(100), body.Photos[1].FileSize())
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, cleanup())
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, tc := range []struct {
+		opts     MultipartOptions
+		expected string
+	}{
+		{MultipartOptions{MaxParts: 3}, "request body exceeds the limit MaxParts=3"},
+		{MultipartOptions{MaxPartSize: 50}, "error reading part 'photos': part 'photos' exceeds the limit MaxPartSize=50"},
+		{MultipartOptions{MaxMemory: 5, TempDir: dir}, "part 'title' exceeds the limit MaxMemory=5"},
+	} {
+		_, err := BindMultipartFormWithOptions(newMultipartRequest(t, parts...), &uploadBody{}, tc.opts)
+		assert.EqualError(t, err, tc.expected)
+		var limitErr *LimitExceededError
+		require.ErrorAs(t, err, &limitErr)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, limitErr.StatusCode())
+	}
+	_, err = BindMultipartFormWithOptions(newMultipartRequest(t, parts...), &uploadBody{}, MultipartOptions{MaxPartSize: 50})
+	assert.ErrorIs(t, err, ErrPartTooLarge)
+	_, err = BindMultipartFormWithOptions(newMultipartRequest(t, parts...), &uploadBody{}, MultipartOptions{MaxParts: 1})
+	assert.ErrorIs(t, err, ErrMultipartTooLarge)
+
+	// Temporary files are removed when binding fails.
+	cleanup, err = BindMultipartFormWithOptions(newMultipartRequest(t, parts...), &uploadBody{},
+		MultipartOptions{MaxMemory: 110, TempDir: dir, MaxParts: 3})
+	assert.ErrorIs(t, err, ErrMultipartTooLarge)
+	require.NotNil(t, cleanup)
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// And so they are when the struct fails to bind or validate.
+	cleanup, err = BindMultipartFormWithOptions(newMultipartRequest(t, parts...), &struct {
+		Title int `form:"title"`
+	}{}, MultipartOptions{MaxMemory: 110, TempDir: dir})
+	assert.Error(t, err)
+	require.NoError(t, cleanup())
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,142 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// MultipartResponseWriter writes a multipart response, such as
+// multipart/mixed or multipart/related, one part at a time, so that an
+// endpoint may return a document along with its attachments, streaming
+// each part to the client as it's written:
+//
+//	mw, err := runtime.NewMultipartResponseWriter(w, "multipart/mixed", nil)
+//	if err != nil {
+//		...
+//	}
+//	if err := mw.WriteJSON(invoice, nil); err != nil {
+//		...
+//	}
+//	if err := mw.WriteFile(receipt, nil); err != nil {
+//		...
+//	}
+//	return mw.Close()
+type MultipartResponseWriter struct {
+	w           http.ResponseWriter
+	mw          *multipart.Writer
+	contentType string
+	wroteHeader bool
+}
+
+// NewMultipartResponseWriter returns a MultipartResponseWriter of w with the
+// given multipart media type, and parameters other than the boundary, such
+// as the type and start parameters of multipart/related.
+func NewMultipartResponseWriter(w http.ResponseWriter, mediaType string, params map[string]string) (*MultipartResponseWriter, error) {
+	if !strings.HasPrefix(strings.ToLower(mediaType), "multipart/") {
+		return nil, fmt.Errorf("a multipart response requires a multipart media type, not '%s'", mediaType)
+	}
+	mw := multipart.NewWriter(w)
+	withBoundary := make(map[string]string, len(params)+1)
+	for key, value := range params {
+		withBoundary[key] = value
+	}
+	withBoundary["boundary"] = mw.Boundary()
+	contentType := mime.FormatMediaType(mediaType, withBoundary)
+	if contentType == "" {
+		return nil, fmt.Errorf("invalid multipart media type '%s' or parameters", mediaType)
+	}
+	return &MultipartResponseWriter{w: w, mw: mw, contentType: contentType}, nil
+}
+
+// ContentType returns the Content-Type of the response, with its boundary.
+func (m *MultipartResponseWriter) ContentType() string {
+	return m.contentType
+}
+
+// WriteHeader sets the Content-Type of the response and writes its status.
+// It's written as 200 OK with the first part unless it's called before.
+func (m *MultipartResponseWriter) WriteHeader(status int) {
+	if m.wroteHeader {
+		return
+	}
+	m.wroteHeader = true
+	m.w.Header().Set("Content-Type", m.contentType)
+	m.w.WriteHeader(status)
+}
+
+// CreatePart starts a part with the given Content-Type, unless it's empty,
+// and other headers, and returns the writer of its content, which may be
+// written until the next part is started. The previous part is flushed to
+// the client first, when w is an http.Flusher.
+func (m *MultipartResponseWriter) CreatePart(contentType string, header textproto.MIMEHeader) (io.Writer, error) {
+	m.WriteHeader(http.StatusOK)
+	m.flush()
+	h := make(textproto.MIMEHeader, len(header)+1)
+	for key, values := range header {
+		h[key] = values
+	}
+	if contentType != "" {
+		h.Set("Content-Type", contentType)
+	}
+	return m.mw.CreatePart(h)
+}
+
+// WriteJSON writes v as an application/json part, with the given headers.
+func (m *MultipartResponseWriter) WriteJSON(v interface{}, header textproto.MIMEHeader) error {
+	w, err := m.CreatePart(jsonContentType, header)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// WriteFile writes file as an attachment part with its Content-Type, which
+// defaults to application/octet-stream, and its name, streaming its content
+// rather than reading it into memory first.
+func (m *MultipartResponseWriter) WriteFile(file types.File, header textproto.MIMEHeader) error {
+	contentType := file.ContentType()
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h := make(textproto.MIMEHeader, len(header)+1)
+	for key, values := range header {
+		h[key] = values
+	}
+	if h.Get("Content-Disposition") == "" {
+		disposition := "attachment"
+		if filename := file.Filename(); filename != "" {
+			disposition = mime.FormatMediaType("attachment", map[string]string{"filename": filename})
+		}
+		h.Set("Content-Disposition", disposition)
+	}
+	w, err := m.CreatePart(contentType, h)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteTo(w)
+	return err
+}
+
+// Close writes the closing boundary of the response, and flushes it.
+func (m *MultipartResponseWriter) Close() error {
+	m.WriteHeader(http.StatusOK)
+	if err := m.mw.Close(); err != nil {
+		return err
+	}
+	m.flush()
+	return nil
+}
+
+func (m *MultipartResponseWriter) flush() {
+	if f, ok := m.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
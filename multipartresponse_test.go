@@ -0,0 +1,65 @@
+package runtime
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+func TestMultipartResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	mw, err := NewMultipartResponseWriter(rec, "multipart/related", map[string]string{
+		"type":  "application/json",
+		"start": "<invoice>",
+	})
+	require.NoError(t, err)
+	mw.WriteHeader(http.StatusCreated)
+	require.NoError(t, mw.WriteJSON(photoMeta{Camera: "X100", ISO: 200}, textproto.MIMEHeader{"Content-Id": {"<invoice>"}}))
+	receipt := types.NewFileFromReader(strings.NewReader("%PDF"), "reçu.pdf", "application/pdf", 4)
+	require.NoError(t, mw.WriteFile(receipt, nil))
+	w, err := mw.CreatePart("text/plain", nil)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "thanks")
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.True(t, rec.Flushed)
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, mw.ContentType(), rec.Header().Get("Content-Type"))
+	assert.Equal(t, "multipart/related", mediaType)
+	assert.Equal(t, "application/json", params["type"])
+	assert.Equal(t, "<invoice>", params["start"])
+
+	mr := multipart.NewReader(rec.Body, params["boundary"])
+	var parts []multipartPart
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, multipartPart{part.Header.Get("Content-ID"), part.FileName(), part.Header.Get("Content-Type"), string(content)})
+	}
+	assert.Equal(t, []multipartPart{
+		{"<invoice>", "", "application/json", `{"camera":"X100","iso":200}` + "\n"},
+		{"", "reçu.pdf", "application/pdf", "%PDF"},
+		{"", "", "text/plain", "thanks"},
+	}, parts)
+
+	_, err = NewMultipartResponseWriter(rec, "application/json", nil)
+	assert.EqualError(t, err, "a multipart response requires a multipart media type, not 'application/json'")
+}
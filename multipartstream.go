@@ -0,0 +1,183 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// ErrMultipartTooLarge is matched by the *LimitExceededError of a multipart
+// body which exceeds a limit of its own, such as MaxTotalSize.
+var ErrMultipartTooLarge = errors.New("multipart body exceeds the maximum size")
+
+// ErrPartTooLarge is matched by the *LimitExceededError of a multipart part
+// which is larger than MaxPartSize.
+var ErrPartTooLarge = errors.New("multipart part exceeds the maximum size")
+
+// MultipartFilePart is a file part of a MultipartStream, whose content is
+// read from it as it arrives.
+type MultipartFilePart struct {
+	io.Reader
+	// FieldName is the name of the form field of the part.
+	FieldName string
+	// FileName is the name of the file, as given by the client, sanitized by
+	// types.SanitizeFilename.
+	FileName string
+	Header   textproto.MIMEHeader
+}
+
+// ContentType returns the Content-Type of the part.
+func (p MultipartFilePart) ContentType() string {
+	return p.Header.Get("Content-Type")
+}
+
+// MultipartStreamOptions defines optional arguments for NewMultipartStream.
+type MultipartStreamOptions struct {
+	// OnFile is called with each file part, in order, so that its content
+	// may be streamed, such as to object storage, rather than held in memory
+	// or stored in temporary files. Whatever it doesn't read of the part is
+	// discarded once it returns. Its errors stop the stream. File parts are
+	// an error when it's nil.
+	OnFile func(part MultipartFilePart) error
+	// MaxPartSize, when it's positive, limits the size of every part, both
+	// the values, which are held in memory, and the files.
+	MaxPartSize int64
+	// MaxTotalSize, when it's positive, limits the size of the whole body.
+	MaxTotalSize int64
+}
+
+// MultipartStream reads a multipart/form-data body one part at a time,
+// handing file parts to a callback as they arrive, while still binding the
+// other parts to a struct, as BindMultipartForm does.
+type MultipartStream struct {
+	reader *multipart.Reader
+	opts   MultipartStreamOptions
+	// body limits the size of the body, when there's a MaxTotalSize.
+	body *limitedReader
+}
+
+// NewMultipartStream returns a MultipartStream of the body of r, which
+// must be a multipart/form-data body that hasn't been read yet.
+func NewMultipartStream(r *http.Request, opts MultipartStreamOptions) (*MultipartStream, error) {
+	s := &MultipartStream{opts: opts}
+	if opts.MaxTotalSize > 0 {
+		body := r.Body
+		s.body = &limitedReader{r: body, remaining: opts.MaxTotalSize, err: &LimitExceededError{Limit: "MaxTotalSize", Max: opts.MaxTotalSize}}
+		r.Body = io.NopCloser(s.body)
+		defer func() { r.Body = body }()
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("error reading multipart body: %w", err)
+	}
+	s.reader = reader
+	return s, nil
+}
+
+// Bind reads the parts of the body in turn, calling OnFile with the file
+// parts, and binds the values of the others to the fields of the struct dst
+// points to once they've all been read, as BindMultipartForm does. Fields of
+// files are left alone. When all the fields bind, a Validatable struct is
+// validated.
+func (s *MultipartStream) Bind(dst interface{}) (err error) {
+	defer recoverPanic(&err, "error binding multipart stream")
+	v, err := multipartDestination(dst)
+	if err != nil {
+		return err
+	}
+
+	form := &multipart.Form{Value: make(map[string][]string)}
+	for {
+		part, err := s.reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			err = fmt.Errorf("error reading multipart body: %w", err)
+		} else {
+			err = s.readPart(part, form)
+		}
+		if err != nil {
+			// The multipart reader may report the body being cut short by
+			// its limit as a malformed part instead.
+			if s.body != nil && s.body.exceeded && !errors.Is(err, ErrMultipartTooLarge) {
+				return fmt.Errorf("error reading multipart body: %w", s.body.err)
+			}
+			return err
+		}
+	}
+
+	if err := bindMultipartStruct(v, form.Value, nil, nil); err != nil {
+		return err
+	}
+	return validate("", dst)
+}
+
+// readPart hands a file part to OnFile, or adds the value of another part
+// to form.
+func (s *MultipartStream) readPart(part *multipart.Part, form *multipart.Form) error {
+	defer func() { _ = part.Close() }()
+	name := part.FormName()
+	var r io.Reader = part
+	if s.opts.MaxPartSize > 0 {
+		r = &limitedReader{r: part, remaining: s.opts.MaxPartSize,
+			err: &LimitExceededError{Limit: "MaxPartSize", Max: s.opts.MaxPartSize, Part: name}}
+	}
+
+	if part.FileName() == "" {
+		value, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("error reading part '%s': %w", name, err)
+		}
+		form.Value[name] = append(form.Value[name], string(value))
+		return nil
+	}
+
+	if s.opts.OnFile == nil {
+		return fmt.Errorf("file part '%s' can't be handled without an OnFile callback", name)
+	}
+	err := s.opts.OnFile(MultipartFilePart{
+		Reader:    r,
+		FieldName: name,
+		FileName:  types.SanitizeFilename(part.FileName()),
+		Header:    part.Header,
+	})
+	if err != nil {
+		return fmt.Errorf("error handling file part '%s': %w", name, err)
+	}
+	// Discard the rest of the part, so that the next can be read, while
+	// still enforcing the limits.
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return fmt.Errorf("error reading part '%s': %w", name, err)
+	}
+	return nil
+}
+
+// limitedReader is like io.LimitedReader, but fails with err, rather than
+// io.EOF, once more than remaining bytes are read.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	err       error
+	exceeded  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n = int(l.remaining)
+		l.remaining = 0
+		l.exceeded = true
+		return n, l.err
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,88 @@
+package runtime
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartStream(t *testing.T) {
+	r := newMultipartRequest(t,
+		multipartPart{name: "title", content: "Holiday"},
+		multipartPart{name: "photos", filename: "a.jpg", contentType: "image/jpeg", content: "aaaa"},
+		multipartPart{name: "tags", content: "beach"},
+		multipartPart{name: "photos", filename: "b.jpg", content: "bbbb"},
+		multipartPart{name: "tags", content: "sun"},
+		multipartPart{name: "metadata", content: `{"iso":200}`},
+	)
+
+	var stored []string
+	stream, err := NewMultipartStream(r, MultipartStreamOptions{
+		OnFile: func(part MultipartFilePart) error {
+			if part.FileName == "b.jpg" {
+				// The rest of the part is discarded.
+				stored = append(stored, part.FieldName+"/"+part.FileName)
+				return nil
+			}
+			content, err := io.ReadAll(part)
+			stored = append(stored, part.FieldName+"/"+part.FileName+":"+part.ContentType()+":"+string(content))
+			return err
+		},
+		MaxPartSize:  16,
+		MaxTotalSize: 4096,
+	})
+	require.NoError(t, err)
+
+	var body uploadBody
+	require.NoError(t, stream.Bind(&body))
+	assert.Equal(t, []string{"photos/a.jpg:image/jpeg:aaaa", "photos/b.jpg"}, stored)
+	assert.Equal(t, "Holiday", body.Title)
+	assert.Equal(t, []string{"beach", "sun"}, body.Tags)
+	assert.Equal(t, &photoMeta{ISO: 200}, body.Metadata)
+	assert.Empty(t, body.Photos)
+}
+
+func TestMultipartStreamLimits(t *testing.T) {
+	parts := []multipartPart{
+		{name: "title", content: "Holiday"},
+		{name: "photos", filename: "a.jpg", content: strings.Repeat("a", 100)},
+	}
+	discard := func(MultipartFilePart) error { return nil }
+
+	stream, err := NewMultipartStream(newMultipartRequest(t, parts...), MultipartStreamOptions{
+		OnFile:      discard,
+		MaxPartSize: 50,
+	})
+	require.NoError(t, err)
+	err = stream.Bind(&uploadBody{})
+	assert.ErrorIs(t, err, ErrPartTooLarge)
+	assert.EqualError(t, err, "error reading part 'photos': part 'photos' exceeds the limit MaxPartSize=50")
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, limitErr.StatusCode())
+
+	stream, err = NewMultipartStream(newMultipartRequest(t, parts...), MultipartStreamOptions{
+		OnFile:       discard,
+		MaxTotalSize: 200,
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, stream.Bind(&uploadBody{}), ErrMultipartTooLarge)
+
+	// Without a callback, file parts are an error.
+	stream, err = NewMultipartStream(newMultipartRequest(t, parts...), MultipartStreamOptions{})
+	require.NoError(t, err)
+	assert.EqualError(t, stream.Bind(&uploadBody{}),
+		"file part 'photos' can't be handled without an OnFile callback")
+
+	errStorage := errors.New("storage is down")
+	stream, err = NewMultipartStream(newMultipartRequest(t, parts...), MultipartStreamOptions{
+		OnFile: func(MultipartFilePart) error { return errStorage },
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, stream.Bind(&uploadBody{}), errStorage)
+}
@@ -0,0 +1,215 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ndjsonContentType is the media type of newline-delimited JSON bodies.
+const ndjsonContentType = "application/x-ndjson"
+
+// defaultNDJSONLineSize is the default NDJSONReaderOptions.MaxLineSize.
+const defaultNDJSONLineSize = 1 << 20
+
+// NDJSONWriterOptions defines optional arguments for
+// NewNDJSONWriterWithOptions.
+type NDJSONWriterOptions struct {
+	// WriteTimeout, when it's positive, limits the time each record may
+	// take to be written to the client, so that a client which stops
+	// reading fails the stream rather than blocking it forever. It's
+	// ignored when the ResponseWriter can't set write deadlines.
+	WriteTimeout time.Duration
+}
+
+// NDJSONWriter writes a collection as a newline-delimited JSON response, one
+// record per line, flushing each record to the client as it's written:
+//
+//	nw := runtime.NewNDJSONWriter(w, r)
+//	for rows.Next() {
+//		...
+//		if err := nw.Write(event); err != nil {
+//			return err
+//		}
+//	}
+//
+// Writes block while the client is slower than the handler, so records
+// aren't buffered beyond what the connection holds.
+type NDJSONWriter struct {
+	w           http.ResponseWriter
+	rc          *http.ResponseController
+	r           *http.Request
+	opts        NDJSONWriterOptions
+	wroteHeader bool
+}
+
+// NewNDJSONWriter returns an NDJSONWriter of w, as
+// NewNDJSONWriterWithOptions does.
+func NewNDJSONWriter(w http.ResponseWriter, r *http.Request) *NDJSONWriter {
+	return NewNDJSONWriterWithOptions(w, r, NDJSONWriterOptions{})
+}
+
+// NewNDJSONWriterWithOptions returns an NDJSONWriter of w, the response to
+// r, whose records stop being written once r's context is done, such as
+// when the client disconnects.
+func NewNDJSONWriterWithOptions(w http.ResponseWriter, r *http.Request, opts NDJSONWriterOptions) *NDJSONWriter {
+	return &NDJSONWriter{w: w, rc: http.NewResponseController(w), r: r, opts: opts}
+}
+
+// WriteHeader writes the status of the response, with the Content-Type
+// application/x-ndjson unless another was set. It's written as 200 OK with
+// the first record unless it's called before.
+func (n *NDJSONWriter) WriteHeader(status int) {
+	if n.wroteHeader {
+		return
+	}
+	n.wroteHeader = true
+	if n.w.Header().Get("Content-Type") == "" {
+		n.w.Header().Set("Content-Type", ndjsonContentType)
+	}
+	n.w.WriteHeader(status)
+}
+
+// Write writes v as a record, encoded as JSON on a line of its own, and
+// flushes it to the client. It fails without writing when v can't be
+// encoded, or when the context of the request is done.
+func (n *NDJSONWriter) Write(v interface{}) error {
+	if err := n.r.Context().Err(); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error encoding NDJSON record: %w", err)
+	}
+	n.WriteHeader(http.StatusOK)
+	if n.opts.WriteTimeout > 0 {
+		if err := n.rc.SetWriteDeadline(time.Now().Add(n.opts.WriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		defer func() {
+			_ = n.rc.SetWriteDeadline(time.Time{})
+		}()
+	}
+	if _, err := n.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return n.Flush()
+}
+
+// Flush flushes the records written so far to the client, when w supports
+// it.
+func (n *NDJSONWriter) Flush() error {
+	if err := n.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// NDJSONReaderOptions defines optional arguments for
+// NewNDJSONReaderWithOptions and ReadNDJSONWithOptions.
+type NDJSONReaderOptions struct {
+	// MaxLineSize limits the size of each record, which fails with a
+	// *LimitExceededError beyond it. It's 1 MiB when it's zero.
+	MaxLineSize int
+}
+
+// NDJSONReader reads the records of a newline-delimited JSON body, one at a
+// time, so that a collection is handled without holding all of it in
+// memory.
+type NDJSONReader struct {
+	sc          *bufio.Scanner
+	maxLineSize int
+	line        int
+}
+
+// NewNDJSONReader returns an NDJSONReader of r, as
+// NewNDJSONReaderWithOptions does.
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	return NewNDJSONReaderWithOptions(r, NDJSONReaderOptions{})
+}
+
+// NewNDJSONReaderWithOptions returns an NDJSONReader of r, such as the body
+// of a request or of a response.
+func NewNDJSONReaderWithOptions(r io.Reader, opts NDJSONReaderOptions) *NDJSONReader {
+	maxLineSize := opts.MaxLineSize
+	if maxLineSize <= 0 {
+		maxLineSize = defaultNDJSONLineSize
+	}
+	sc := bufio.NewScanner(r)
+	// The buffer holds the line ending too, which may be \r\n.
+	bufSize := maxLineSize + 2
+	if bufSize > 4096 {
+		bufSize = 4096
+	}
+	sc.Buffer(make([]byte, 0, bufSize), maxLineSize+2)
+	return &NDJSONReader{sc: sc, maxLineSize: maxLineSize}
+}
+
+// Decode decodes the next record into dst, skipping blank lines, and
+// validates it when it's Validatable. It returns io.EOF when there are no
+// more records, and errors name the line of the record which failed.
+func (n *NDJSONReader) Decode(dst interface{}) error {
+	for n.sc.Scan() {
+		n.line++
+		line := n.sc.Bytes()
+		if len(line) > n.maxLineSize {
+			return n.lineTooLong()
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(line, dst); err != nil {
+			return fmt.Errorf("error decoding NDJSON record on line %d: %w", n.line, err)
+		}
+		if err := validate("", dst); err != nil {
+			return fmt.Errorf("invalid NDJSON record on line %d: %w", n.line, err)
+		}
+		return nil
+	}
+	err := n.sc.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		n.line++
+		return n.lineTooLong()
+	}
+	if err != nil {
+		return fmt.Errorf("error reading NDJSON body: %w", err)
+	}
+	return io.EOF
+}
+
+func (n *NDJSONReader) lineTooLong() error {
+	return fmt.Errorf("error reading NDJSON record on line %d: %w", n.line,
+		&LimitExceededError{Limit: "MaxLineSize", Max: int64(n.maxLineSize)})
+}
+
+// ReadNDJSON calls fn with each record of a newline-delimited JSON body, as
+// ReadNDJSONWithOptions does.
+func ReadNDJSON[T any](r io.Reader, fn func(record T) error) error {
+	return ReadNDJSONWithOptions(r, fn, NDJSONReaderOptions{})
+}
+
+// ReadNDJSONWithOptions decodes each record of a newline-delimited JSON
+// body, such as the body of an application/x-ndjson request, as an
+// NDJSONReader does, and calls fn with it as it's read. It stops at the
+// first error, of a record or of fn, and returns it.
+func ReadNDJSONWithOptions[T any](r io.Reader, fn func(record T) error, opts NDJSONReaderOptions) error {
+	nr := NewNDJSONReaderWithOptions(r, opts)
+	for {
+		var record T
+		err := nr.Decode(&record)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
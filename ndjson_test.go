@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	w := httptest.NewRecorder()
+	nw := NewNDJSONWriterWithOptions(w, r, NDJSONWriterOptions{WriteTimeout: time.Second})
+	require.NoError(t, nw.Write(xmlPet{ID: 1, Name: "Rex"}))
+	assert.True(t, w.Flushed)
+	require.NoError(t, nw.Write(xmlPet{ID: 2, Name: "Fido"}))
+	assert.Error(t, nw.Write(func() {}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "{\"id\":1,\"name\":\"Rex\"}\n{\"id\":2,\"name\":\"Fido\"}\n", w.Body.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w = httptest.NewRecorder()
+	nw = NewNDJSONWriter(w, r.WithContext(ctx))
+	assert.ErrorIs(t, nw.Write(xmlPet{ID: 1}), context.Canceled)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestNDJSONWriterBackpressure(t *testing.T) {
+	record := map[string]string{"data": strings.Repeat("x", 64<<10)}
+	done := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nw := NewNDJSONWriterWithOptions(w, r, NDJSONWriterOptions{WriteTimeout: 50 * time.Millisecond})
+		for i := 0; i < 4096; i++ {
+			if err := nw.Write(record); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}))
+	defer srv.Close()
+
+	// The client never reads the body, so the writes stall until they time
+	// out.
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the writes weren't stopped by the client")
+	}
+}
+
+func TestNDJSONReader(t *testing.T) {
+	body := "{\"id\":1,\"name\":\"Rex\"}\r\n\n  \n{\"id\":2,\"name\":\"Fido\"}"
+	nr := NewNDJSONReader(strings.NewReader(body))
+	var pet xmlPet
+	require.NoError(t, nr.Decode(&pet))
+	assert.Equal(t, xmlPet{ID: 1, Name: "Rex"}, pet)
+	require.NoError(t, nr.Decode(&pet))
+	assert.Equal(t, xmlPet{ID: 2, Name: "Fido"}, pet)
+	assert.Equal(t, io.EOF, nr.Decode(&pet))
+
+	nr = NewNDJSONReader(strings.NewReader("{\"id\":1,\"name\":\"Rex\"}\n{\"id\":2}\n"))
+	require.NoError(t, nr.Decode(&xmlPet{}))
+	assert.EqualError(t, nr.Decode(&xmlPet{}), "invalid NDJSON record on line 2: validation failed: name is required")
+
+	nr = NewNDJSONReader(strings.NewReader("{\"id\":1,\"name\":\"Rex\"} {}\n"))
+	assert.ErrorContains(t, nr.Decode(&xmlPet{}), "error decoding NDJSON record on line 1: ")
+}
+
+func TestReadNDJSON(t *testing.T) {
+	body := "{\"id\":1,\"name\":\"Rex\"}\n{\"id\":2,\"name\":\"Fido\"}\n"
+	var pets []xmlPet
+	require.NoError(t, ReadNDJSON(strings.NewReader(body), func(pet xmlPet) error {
+		pets = append(pets, pet)
+		return nil
+	}))
+	assert.Equal(t, []xmlPet{{ID: 1, Name: "Rex"}, {ID: 2, Name: "Fido"}}, pets)
+
+	errStop := errors.New("stop")
+	calls := 0
+	err := ReadNDJSON(strings.NewReader(body), func(*xmlPet) error {
+		calls++
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+
+	// A line of exactly MaxLineSize bytes fits, and a longer one doesn't.
+	line := `{"id":1,"name":"` + strings.Repeat("x", 100) + `"}`
+	opts := NDJSONReaderOptions{MaxLineSize: len(line)}
+	require.NoError(t, ReadNDJSONWithOptions(strings.NewReader(line+"\r\n"), func(xmlPet) error { return nil }, opts))
+	err = ReadNDJSONWithOptions(strings.NewReader(body+`{"id":3,"name":"`+strings.Repeat("x", 101)+`"}`+"\n"),
+		func(xmlPet) error { return nil }, opts)
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.EqualError(t, err, "error reading NDJSON record on line 3: request body exceeds the limit MaxLineSize=118")
+}
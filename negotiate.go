@@ -0,0 +1,141 @@
+package runtime
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NotAcceptableError is returned by NegotiateContentType when none of the
+// offered content types is acceptable to the client, which is best
+// answered with the status StatusCode returns, 406 Not Acceptable.
+type NotAcceptableError struct {
+	// Accept is the Accept header of the request.
+	Accept string
+	// Offered are the content types which were offered.
+	Offered []string
+}
+
+func (e *NotAcceptableError) Error() string {
+	return fmt.Sprintf("none of %s is acceptable to '%s'", strings.Join(e.Offered, ", "), e.Accept)
+}
+
+// StatusCode returns http.StatusNotAcceptable.
+func (e *NotAcceptableError) StatusCode() int {
+	return http.StatusNotAcceptable
+}
+
+// mediaRange is a media range of an Accept header, such as text/* or
+// application/json;version=2, with its quality.
+type mediaRange struct {
+	typ, subtype string
+	params       map[string]string
+	q            float64
+}
+
+// specificity ranks how specifically a media range matches: */* is the
+// least specific, then type/*, then type/subtype, and then type/subtype
+// with parameters, more of them being more specific.
+func (m mediaRange) specificity() int {
+	switch {
+	case m.typ == "*":
+		return 0
+	case m.subtype == "*":
+		return 1
+	default:
+		return 2 + len(m.params)
+	}
+}
+
+// matches tells whether the media range includes the media type of the
+// given type, subtype and parameters.
+func (m mediaRange) matches(typ, subtype string, params map[string]string) bool {
+	if m.typ != "*" && m.typ != typ || m.subtype != "*" && m.subtype != subtype {
+		return false
+	}
+	for key, value := range m.params {
+		if !strings.EqualFold(params[key], value) {
+			return false
+		}
+	}
+	return true
+}
+
+// NegotiateContentType returns the content type among offered, in the
+// order the server prefers them, which is best for the Accept header of r,
+// following RFC 9110. Each offered type takes the quality of the most
+// specific media range which matches it, such as text/html over text/*,
+// and the one of the highest quality is returned, ties going to the more
+// specific match and then to the earlier offer. Types whose quality is 0
+// are never returned. A bare *, which isn't a valid media range but is sent
+// by some clients, is taken as */*. When the request has no Accept header,
+// the first offered type is returned, and when none is acceptable, the
+// error is a *NotAcceptableError.
+func NegotiateContentType(r *http.Request, offered []string) (string, error) {
+	accept := strings.Join(r.Header.Values("Accept"), ",")
+	if strings.TrimSpace(accept) == "" && len(offered) > 0 {
+		return offered[0], nil
+	}
+	ranges := parseAccept(accept)
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, offer := range offered {
+		mediaType, params, err := mime.ParseMediaType(offer)
+		if err != nil {
+			continue
+		}
+		typ, subtype, _ := strings.Cut(mediaType, "/")
+		q, specificity := 0.0, -1
+		for _, m := range ranges {
+			if m.specificity() > specificity && m.matches(typ, subtype, params) {
+				q, specificity = m.q, m.specificity()
+			}
+		}
+		if q > bestQ || q == bestQ && q > 0 && specificity > bestSpecificity {
+			best, bestQ, bestSpecificity = offer, q, specificity
+		}
+	}
+	if bestQ == 0 {
+		return "", &NotAcceptableError{Accept: accept, Offered: offered}
+	}
+	return best, nil
+}
+
+// parseAccept parses the media ranges of an Accept header, skipping those
+// which are malformed.
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		typ, subtype, found := strings.Cut(mediaType, "/")
+		if mediaType == "*" {
+			// A bare *, as some clients send, is taken as */*.
+			subtype, found = "*", true
+		}
+		if !found || typ == "*" && subtype != "*" {
+			continue
+		}
+		m := mediaRange{typ: typ, subtype: subtype, q: 1}
+		if q, ok := params["q"]; ok {
+			m.q, err = strconv.ParseFloat(q, 64)
+			if err != nil || m.q < 0 || m.q > 1 {
+				continue
+			}
+			delete(params, "q")
+		}
+		if len(params) > 0 {
+			m.params = params
+		}
+		ranges = append(ranges, m)
+	}
+	return ranges
+}
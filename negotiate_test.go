@@ -0,0 +1,59 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offered := []string{"application/json", "application/xml", "text/html", "text/plain; charset=utf-8"}
+	for _, tc := range []struct {
+		accept, expected string
+	}{
+		{"", "application/json"},
+		{"*/*", "application/json"},
+		{"application/xml", "application/xml"},
+		{"text/*", "text/html"},
+		{"text/*;q=0.5, text/plain;q=0.8", "text/plain; charset=utf-8"},
+		{"application/json;q=0.5, application/xml", "application/xml"},
+		{"application/*;q=0.9, application/json;q=0.2", "application/xml"},
+		{"*/*;q=0.1, TEXT/HTML", "text/html"},
+		{"text/plain;charset=UTF-8", "text/plain; charset=utf-8"},
+		{"*/*, application/json;q=0", "application/xml"},
+		{"invalid, application/xml;q=0.3", "application/xml"},
+		{"*", "application/json"},
+		{"*;q=0.1, text/html", "text/html"},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.accept != "" {
+			r.Header.Set("Accept", tc.accept)
+		}
+		contentType, err := NegotiateContentType(r, offered)
+		require.NoError(t, err, tc.accept)
+		assert.Equal(t, tc.expected, contentType, tc.accept)
+	}
+
+	// Accept headers may be given more than once.
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept", "image/png;q=0.5")
+	r.Header.Add("Accept", "text/html")
+	contentType, err := NegotiateContentType(r, offered)
+	require.NoError(t, err)
+	assert.Equal(t, "text/html", contentType)
+
+	for _, accept := range []string{"image/png", "text/plain;charset=latin1", "application/*;q=0, text/*;q=0"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", accept)
+		_, err := NegotiateContentType(r, offered)
+		var notAcceptable *NotAcceptableError
+		require.ErrorAs(t, err, &notAcceptable, accept)
+		assert.Equal(t, http.StatusNotAcceptable, notAcceptable.StatusCode())
+	}
+	r.Header.Set("Accept", "image/png")
+	_, err = NegotiateContentType(r, []string{"application/json", "text/html"})
+	assert.EqualError(t, err, "none of application/json, text/html is acceptable to 'image/png'")
+}
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ParamStyler serializes a parameter value in a custom style, returning the
+// same kind of string StyleParamWithOptions does for the built in styles.
+type ParamStyler func(paramName string, value interface{}, opts StyleParamOptions) (string, error)
+
+// ParamBinder binds a parameter value in a custom style, as produced by the
+// matching ParamStyler, to dest. The value has already been unescaped.
+type ParamBinder func(paramName string, value string, dest interface{}, opts BindStyledParameterOptions) error
+
+type paramStyle struct {
+	styler ParamStyler
+	binder ParamBinder
+}
+
+var (
+	paramStylesMu sync.RWMutex
+	paramStyles   = make(map[string]paramStyle)
+)
+
+// builtinParamStyles are the styles defined by the OpenAPI specification,
+// which can't be replaced.
+var builtinParamStyles = map[string]bool{
+	"simple":         true,
+	"label":          true,
+	"matrix":         true,
+	"form":           true,
+	"spaceDelimited": true,
+	"pipeDelimited":  true,
+	"deepObject":     true,
+}
+
+// RegisterParamStyle makes a custom parameter style, such as a vendor
+// specific convention, available to StyleParamWithOptions,
+// BindStyledParameterWithOptions and BindQueryParameter under the given name.
+// Like sql.Register, it's meant to be called from an init function, and
+// panics if the name is taken, either by a built in style or by another
+// registration, or if the styler or binder are nil.
+func RegisterParamStyle(name string, styler ParamStyler, binder ParamBinder) {
+	if styler == nil || binder == nil {
+		panic("runtime: RegisterParamStyle styler and binder must not be nil")
+	}
+	if builtinParamStyles[name] {
+		panic(fmt.Sprintf("runtime: RegisterParamStyle can't replace the built in style %q", name))
+	}
+
+	paramStylesMu.Lock()
+	defer paramStylesMu.Unlock()
+	if _, dup := paramStyles[name]; dup {
+		panic(fmt.Sprintf("runtime: RegisterParamStyle called twice for style %q", name))
+	}
+	paramStyles[name] = paramStyle{styler: styler, binder: binder}
+}
+
+// lookupParamStyle returns the custom style registered under name.
+func lookupParamStyle(name string) (paramStyle, bool) {
+	paramStylesMu.RLock()
+	defer paramStylesMu.RUnlock()
+	s, found := paramStyles[name]
+	return s, found
+}
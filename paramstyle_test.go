@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// A bracketed CSV style: [a,b,c]
+	RegisterParamStyle("bracketedCSV",
+		func(paramName string, value interface{}, opts StyleParamOptions) (string, error) {
+			v := reflect.ValueOf(value)
+			if v.Kind() != reflect.Slice {
+				return "", fmt.Errorf("bracketedCSV parameter '%s' must be an array", paramName)
+			}
+			parts := make([]string, v.Len())
+			for i := range parts {
+				s, err := primitiveToString(v.Index(i).Interface())
+				if err != nil {
+					return "", err
+				}
+				parts[i] = escapeParameterString(s, opts.ParamLocation)
+			}
+			prefix := ""
+			if opts.ParamLocation == ParamLocationQuery {
+				prefix = paramName + "="
+			}
+			return prefix + "[" + strings.Join(parts, ",") + "]", nil
+		},
+		func(paramName string, value string, dest interface{}, opts BindStyledParameterOptions) error {
+			if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+				return fmt.Errorf("bracketedCSV parameter '%s' must be bracketed", paramName)
+			}
+			return bindSplitPartsToDestinationArray(strings.Split(value[1:len(value)-1], ","), dest, bindStringOptions{})
+		})
+}
+
+func TestRegisterParamStyle(t *testing.T) {
+	styled, err := StyleParamWithLocation("bracketedCSV", false, "ids", ParamLocationPath, []int{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, "[1,2,3]", styled)
+
+	var ids []int
+	err = BindStyledParameterWithOptions("bracketedCSV", "ids", styled, &ids, BindStyledParameterOptions{
+		ParamLocation: ParamLocationPath,
+		Required:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	styled, err = StyleParamWithLocation("bracketedCSV", false, "ids", ParamLocationQuery, []int{4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, "ids=[4,5]", styled)
+
+	query, err := url.ParseQuery(styled)
+	require.NoError(t, err)
+	var optionalIDs *[]int
+	require.NoError(t, BindQueryParameter("bracketedCSV", false, false, "ids", query, &optionalIDs))
+	require.NotNil(t, optionalIDs)
+	assert.Equal(t, []int{4, 5}, *optionalIDs)
+
+	assert.Error(t, BindQueryParameter("bracketedCSV", false, true, "other", query, &ids))
+
+	_, err = StyleParamWithLocation("bracketedCSV", false, "id", ParamLocationPath, 5)
+	var styleErr *StyleError
+	assert.ErrorAs(t, err, &styleErr)
+
+	noop := func(string, string, interface{}, BindStyledParameterOptions) error { return nil }
+	styler := func(string, interface{}, StyleParamOptions) (string, error) { return "", nil }
+	assert.Panics(t, func() { RegisterParamStyle("bracketedCSV", styler, noop) })
+	assert.Panics(t, func() { RegisterParamStyle("simple", styler, noop) })
+	assert.Panics(t, func() { RegisterParamStyle("other", nil, noop) })
+}
@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+// paramTag is a parameter described by a struct tag, such as
+// `query:"tags,style=pipeDelimited,explode=false,required"`.
+type paramTag struct {
+	name     string
+	style    string
+	explode  bool
+	required bool
+	// def is the default tag of the field, which is bound when an optional
+	// query or header parameter is absent.
+	def string
+}
+
+// parseParamTag parses the struct tag of a parameter. The style and whether
+// it's required default to those of defaults, and explode, unless it's
+// given, to whether the style is form, as in the OpenAPI specification.
+func parseParamTag(tag string, defaults paramTag) (paramTag, error) {
+	parts := strings.Split(tag, ",")
+	p := defaults
+	p.name = parts[0]
+	var explode *bool
+	for _, opt := range parts[1:] {
+		key, value, hasValue := strings.Cut(opt, "=")
+		switch {
+		case key == "style" && hasValue:
+			p.style = value
+		case key == "explode":
+			e := true
+			if hasValue {
+				var err error
+				if e, err = strconv.ParseBool(value); err != nil {
+					return p, fmt.Errorf("invalid explode option '%s'", value)
+				}
+			}
+			explode = &e
+		case opt == "required":
+			p.required = true
+		default:
+			return p, fmt.Errorf("unknown option '%s'", opt)
+		}
+	}
+	if explode != nil {
+		p.explode = *explode
+	} else {
+		p.explode = p.style == "form"
+	}
+	return p, nil
+}
+
+// bindTaggedFields calls bindField for each settable field of the struct dst
+// points to which has the tag key. Fields tagged without a name take the
+// name of the field.
+func bindTaggedFields(dst interface{}, key string, defaults paramTag, bind func(p paramTag, dest interface{})) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding %s parameters requires a pointer to a struct, not %T", key, dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		tag, found := t.Field(i).Tag.Lookup(key)
+		if !found || tag == "-" || !v.Field(i).CanSet() {
+			continue
+		}
+		p, err := parseParamTag(tag, defaults)
+		if err != nil {
+			return fmt.Errorf("error parsing %s tag of field '%s': %w", key, t.Field(i).Name, err)
+		}
+		if p.name == "" {
+			p.name = t.Field(i).Name
+		}
+		p.def = t.Field(i).Tag.Get("default")
+		bindField(p, v.Field(i), bind)
+	}
+	return nil
+}
+
+// bindField calls bind with the parsed tag and the address of field.
+// Optional parameters are passed as a pointer to a pointer, as
+// BindQueryParameter expects, which is only assigned to a field which isn't
+// a pointer itself if it's bound, unless the field is a types.Optional.
+func bindField(p paramTag, field reflect.Value, bind func(p paramTag, dest interface{})) {
+	if _, ok := field.Addr().Interface().(types.OptionalValue); ok || p.required || field.Kind() == reflect.Ptr {
+		bind(p, field.Addr().Interface())
+		return
+	}
+	ptr := reflect.New(reflect.PtrTo(field.Type()))
+	bind(p, ptr.Interface())
+	if !ptr.Elem().IsNil() {
+		field.Set(ptr.Elem().Elem())
+	}
+}
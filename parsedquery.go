@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// ParsedQuery is the query of a request, parsed once to be shared by the
+// bindings of all of its parameters, which record the keys they consume.
+type ParsedQuery struct {
+	values url.Values
+
+	mu       sync.Mutex
+	consumed consumedKeys
+}
+
+type parsedQueryKey struct{}
+
+// ParseQueryOnce parses the query of r, and caches it in the context of the
+// request it returns, so that binding many parameters of the request parses
+// it just once. BindQuery and BindRequestParams use the cached query when
+// there is one. A request which already carries a parsed query is returned
+// as it is:
+//
+//	func(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			r, _ = runtime.ParseQueryOnce(r)
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+func ParseQueryOnce(r *http.Request) (*http.Request, *ParsedQuery) {
+	if q, found := r.Context().Value(parsedQueryKey{}).(*ParsedQuery); found {
+		return r, q
+	}
+	q := &ParsedQuery{values: r.URL.Query()}
+	return r.WithContext(context.WithValue(r.Context(), parsedQueryKey{}, q)), q
+}
+
+// requestQuery returns the query of r cached by ParseQueryOnce, or parses
+// it when there's none.
+func requestQuery(r *http.Request) *ParsedQuery {
+	if q, found := r.Context().Value(parsedQueryKey{}).(*ParsedQuery); found {
+		return q
+	}
+	return &ParsedQuery{values: r.URL.Query()}
+}
+
+// Values returns the parsed query, which must not be modified.
+func (q *ParsedQuery) Values() url.Values {
+	return q.values
+}
+
+// BindParameter binds the query parameter paramName, as
+// BindQueryParameterWithOptions does, and records the keys it consumes.
+func (q *ParsedQuery) BindParameter(style string, paramName string, dest interface{},
+	opts BindQueryParameterOptions) error {
+	q.mu.Lock()
+	q.consumed.addQueryParam(style, opts.Explode, paramName, dest)
+	q.mu.Unlock()
+	return BindQueryParameterWithOptions(style, paramName, q.values, dest, opts)
+}
+
+// UnknownParams returns an *UnknownParamsError listing the keys of the query
+// which none of the parameters bound so far consumed, as
+// Bindings.UnknownQuery does, or nil if they were all consumed.
+func (q *ParsedQuery) UnknownParams() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if names := q.consumed.unknown(q.values); len(names) > 0 {
+		return &UnknownParamsError{Location: ParamLocationQuery, Names: names}
+	}
+	return nil
+}
+
+// record adds the keys consumed by b to those of the query.
+func (q *ParsedQuery) record(b *Bindings) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for name := range b.query.names {
+		q.consumed.add(name)
+	}
+	q.consumed.prefixes = append(q.consumed.prefixes, b.query.prefixes...)
+}
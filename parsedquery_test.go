@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryOnce(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets?limit=5&tags=a&tags=b&color=red&colour=blue", nil)
+	r, q := ParseQueryOnce(r)
+	r2, q2 := ParseQueryOnce(r)
+	assert.Same(t, r, r2)
+	assert.Same(t, q, q2)
+	assert.Equal(t, []string{"a", "b"}, q.Values()["tags"])
+
+	// Binding uses the cached query rather than parsing it again.
+	r.URL.RawQuery = ""
+
+	type ListParams struct {
+		Limit *int `query:"limit"`
+	}
+	params, err := BindQuery[ListParams](r)
+	require.NoError(t, err)
+	assert.Equal(t, 5, *params.Limit)
+
+	var requestParams struct {
+		Tags []string `param:"tags,in=query"`
+	}
+	require.NoError(t, BindRequestParamsFunc(r, &requestParams, nil))
+	assert.Equal(t, []string{"a", "b"}, requestParams.Tags)
+
+	var color *string
+	require.NoError(t, q.BindParameter("form", "color", &color, BindQueryParameterOptions{Explode: true}))
+	assert.Equal(t, "red", *color)
+
+	// Each binding records the keys it consumed.
+	err = q.UnknownParams()
+	var unknownErr *UnknownParamsError
+	require.ErrorAs(t, err, &unknownErr)
+	assert.Equal(t, []string{"colour"}, unknownErr.Names)
+}
@@ -0,0 +1,16 @@
+package runtime
+
+// BindPathParamsFunc binds path parameters to the fields of the struct dst
+// points to which have a path tag, as BindPathParams does, looking up the
+// value of each by its name with lookup. It lets routers which keep path
+// parameters to themselves, such as chi or gorilla/mux, share the same
+// parameters structs.
+func BindPathParamsFunc(dst interface{}, lookup func(name string) string) error {
+	var b Bindings
+	if err := bindTaggedFields(dst, "path", paramTag{style: "simple", required: true}, func(p paramTag, dest interface{}) {
+		b.Path(p.style, p.explode, p.name, lookup(p.name), dest)
+	}); err != nil {
+		return err
+	}
+	return b.Err()
+}
@@ -0,0 +1,67 @@
+//go:build go1.22
+
+package runtime
+
+import (
+	"net/http"
+)
+
+// BindPathParams binds the path parameters of r, as matched by the patterns
+// of http.ServeMux since Go 1.22, to the fields of the struct dst points to
+// which have a path tag. The tag names the wildcard, and may set the style,
+// which defaults to simple, and explode:
+//
+//	type PetPath struct {
+//		ID   int      `path:"id"`
+//		Tags []string `path:"tags,style=label,explode"`
+//	}
+//
+//	mux.HandleFunc("GET /pets/{id}/{tags}", func(w http.ResponseWriter, r *http.Request) {
+//		var p PetPath
+//		if err := runtime.BindPathParams(r, &p); err != nil {
+//			...
+//		}
+//	})
+//
+// Path parameters are always required. Every field is bound, and the errors
+// of those which fail are returned together as ParamErrors.
+func BindPathParams(r *http.Request, dst interface{}) error {
+	return BindPathParamsFunc(dst, r.PathValue)
+}
+
+// BindPath binds the path parameters of r to a new T, a struct whose fields
+// have path tags, as BindPathParams does. When they all bind, a Validatable
+// T is validated.
+func BindPath[T any](r *http.Request) (T, error) {
+	var params T
+	if err := BindPathParams(r, &params); err != nil {
+		return params, err
+	}
+	return params, validate("", &params)
+}
+
+// BindRequestParams binds the path, query, header and cookie parameters of
+// r to the fields of the struct dst points to which have a param tag, so
+// that all the parameters of an operation are bound by a single call. The
+// tag names the parameter, gives its location with the in option, and may
+// set its style, explode and required options, as for the tags of
+// BindQuery, BindHeaders and BindPathParams, with the defaults of its
+// location:
+//
+//	type GetPetParams struct {
+//		ID        int       `param:"id,in=path"`
+//		Fields    *[]string `param:"fields,in=query,explode=false"`
+//		RequestID string    `param:"X-Request-ID,in=header,required"`
+//		Session   *string   `param:"session,in=cookie"`
+//	}
+//
+// Path parameters are looked up with r.PathValue, and the query is parsed
+// once per request given ParseQueryOnce. The default tag of a query or
+// header field, such as `default:"10"`, is bound when its parameter is
+// absent, as for BindQuery. The tags of each struct type are only parsed
+// once. Every field is bound, and the errors of those which fail are
+// returned together as ParamErrors. When they all bind, a
+// Validatable struct is validated.
+func BindRequestParams(r *http.Request, dst interface{}) error {
+	return BindRequestParamsFunc(r, dst, r.PathValue)
+}
@@ -0,0 +1,90 @@
+//go:build go1.22
+
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindPathParams(t *testing.T) {
+	type PetPath struct {
+		ID      int      `path:"id"`
+		Tags    []string `path:"tags,style=label,explode"`
+		Ignored string
+	}
+
+	// The values a ServeMux pattern such as "GET /pets/{id}/{tags}" matches.
+	request := func(id, tags string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/pets/"+id+"/"+tags, nil)
+		r.SetPathValue("id", id)
+		r.SetPathValue("tags", tags)
+		return r
+	}
+
+	var params PetPath
+	require.NoError(t, BindPathParams(request("7", ".a.b"), &params))
+	assert.Equal(t, PetPath{ID: 7, Tags: []string{"a", "b"}}, params)
+
+	// Every field is bound, and all the failures are reported.
+	bindErr := BindPathParams(request("x", "a"), &params)
+	require.Error(t, bindErr)
+	var paramErrs ParamErrors
+	require.ErrorAs(t, bindErr, &paramErrs)
+	require.Len(t, paramErrs, 2)
+	assert.Equal(t, "id", paramErrs[0].ParamName)
+	assert.Equal(t, ParamLocationPath, paramErrs[0].Location)
+	assert.Equal(t, "tags", paramErrs[1].ParamName)
+
+	// Wildcards missing from the pattern are required.
+	r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	err := BindPathParams(r, &struct {
+		ID int `path:"id"`
+	}{})
+	var requiredErr *RequiredParamError
+	require.ErrorAs(t, err, &requiredErr)
+	assert.Equal(t, "id", requiredErr.ParamName)
+
+	assert.EqualError(t, BindPathParams(r, params),
+		"binding path parameters requires a pointer to a struct, not runtime.PetPath")
+}
+
+type petPathParams struct {
+	ID int `path:"id"`
+}
+
+func (p petPathParams) Validate() error {
+	if p.ID <= 0 {
+		return errors.New("id must be positive")
+	}
+	return nil
+}
+
+func TestBindPath(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets/7", nil)
+	r.SetPathValue("id", "7")
+	params, err := BindPath[petPathParams](r)
+	require.NoError(t, err)
+	assert.Equal(t, 7, params.ID)
+
+	r.SetPathValue("id", "-1")
+	_, err = BindPath[petPathParams](r)
+	assert.EqualError(t, err, "validation failed: id must be positive")
+}
+
+func TestBindRequestParamsPathValue(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/pets/7?limit=5", nil)
+	r.SetPathValue("id", "7")
+	var params struct {
+		ID    int  `param:"id,in=path"`
+		Limit *int `param:"limit,in=query"`
+	}
+	require.NoError(t, BindRequestParams(r, &params))
+	assert.Equal(t, 7, params.ID)
+	assert.Equal(t, 5, *params.Limit)
+}
@@ -0,0 +1,58 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// protobufContentType is the media type of protobuf bodies.
+const protobufContentType = "application/x-protobuf"
+
+func init() {
+	RegisterBodyCodec(protobufContentType, protobufCodec{})
+	RegisterBodyCodec("application/protobuf", protobufCodec{})
+}
+
+// protobufCodec is the BodyCodec of protobuf bodies, which encodes and
+// decodes proto.Message values in the protobuf wire format.
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("a protobuf body must be a proto.Message, not %T", v)
+	}
+	return proto.Marshal(m)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("a protobuf body must be decoded into a proto.Message, not %T", v)
+	}
+	return proto.Unmarshal(data, m)
+}
+
+// BindProtobuf decodes the protobuf body of r into dst, as
+// BindProtobufWithOptions does.
+func BindProtobuf(r *http.Request, dst proto.Message) error {
+	return BindProtobufWithOptions(r, dst, BodyOptions{})
+}
+
+// BindProtobufWithOptions decodes the protobuf body of r, as declared by the
+// application/x-protobuf media type, into dst, as BindCBORWithOptions does
+// for CBOR. dst is reset first, and validated when it's Validatable, as
+// messages generated with a Validate method are.
+func BindProtobufWithOptions(r *http.Request, dst proto.Message, opts BodyOptions) error {
+	return bindCodecBody(r, dst, protobufContentType, opts)
+}
+
+// WriteProtobuf writes a response with the given status and headers, whose
+// body is m encoded in the protobuf wire format. The Content-Type is
+// application/x-protobuf, unless headers set another. To offer protobuf
+// along with other content types, such as JSON, use WriteNegotiated.
+func WriteProtobuf(w http.ResponseWriter, status int, m proto.Message, headers http.Header) error {
+	return writeCodecBody(w, status, m, headers, protobufContentType)
+}
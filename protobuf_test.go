@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestBindProtobuf(t *testing.T) {
+	// StringValue{value: "Rex"} in the wire format.
+	body := []byte{0x0a, 0x03, 'R', 'e', 'x'}
+	var name wrapperspb.StringValue
+	require.NoError(t, BindProtobuf(newBodyRequest("application/x-protobuf", body), &name))
+	assert.Equal(t, "Rex", name.GetValue())
+
+	// The message is reset, rather than merged into.
+	require.NoError(t, BindProtobuf(newBodyRequest("application/x-protobuf", ""), &name))
+	assert.Equal(t, "", name.GetValue())
+
+	err := BindProtobuf(newBodyRequest("application/x-protobuf", []byte{0x0a, 0x05, 'R'}), &name)
+	assert.ErrorContains(t, err, "error decoding PROTOBUF body: ")
+
+	require.NoError(t, BindBody(newBodyRequest("application/protobuf", body), &name))
+	assert.Equal(t, "Rex", name.GetValue())
+
+	err = BindBody(newBodyRequest("application/protobuf", body), &xmlPet{})
+	assert.EqualError(t, err, "error decoding PROTOBUF body: a protobuf body must be decoded into a proto.Message, not *runtime.xmlPet")
+}
+
+func TestWriteProtobuf(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteProtobuf(w, http.StatusOK, wrapperspb.String("Rex"), nil))
+	assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+	assert.Equal(t, []byte{0x0a, 0x03, 'R', 'e', 'x'}, w.Body.Bytes())
+
+	err := WriteBody(httptest.NewRecorder(), http.StatusOK, "application/x-protobuf", xmlPet{}, nil)
+	assert.EqualError(t, err, "error encoding PROTOBUF body: a protobuf body must be a proto.Message, not runtime.xmlPet")
+}
+
+func TestWriteNegotiated(t *testing.T) {
+	offered := []string{"application/json", "application/x-protobuf"}
+	newRequest := func(accept string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/names/1", nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		return r
+	}
+
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteNegotiated(w, newRequest("application/x-protobuf, application/json;q=0.5"), http.StatusOK, offered, wrapperspb.String("Rex"), nil))
+	assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	var name wrapperspb.StringValue
+	require.NoError(t, proto.Unmarshal(w.Body.Bytes(), &name))
+	assert.Equal(t, "Rex", name.GetValue())
+
+	w = httptest.NewRecorder()
+	require.NoError(t, WriteNegotiated(w, newRequest(""), http.StatusOK, offered, xmlPet{ID: 1, Name: "Rex"}, nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `{"id":1,"name":"Rex"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	err := WriteNegotiated(w, newRequest("text/html"), http.StatusOK, offered, xmlPet{}, nil)
+	var notAcceptable *NotAcceptableError
+	assert.ErrorAs(t, err, &notAcceptable)
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,97 @@
+package runtime
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+)
+
+// ResponseOptions defines optional arguments for WriteJSONWithOptions and
+// WriteXMLWithOptions.
+type ResponseOptions struct {
+	// Indent pretty-prints the body, indenting each level with it, such as
+	// two spaces, when it isn't empty.
+	Indent string
+}
+
+// WriteJSON writes a response with the given status, whose body is v
+// encoded as JSON, as WriteJSONWithOptions does.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}, headers http.Header) error {
+	return WriteJSONWithOptions(w, status, v, headers, ResponseOptions{})
+}
+
+// WriteJSONWithOptions writes a response with the given status and headers,
+// whose body is v encoded as JSON. The Content-Type is application/json,
+// unless headers set another, such as application/problem+json. The body is
+// encoded as it's written, so an error encoding it is returned after the
+// status has been written. Statuses which have no body, such as 204 No
+// Content, are written without one.
+func WriteJSONWithOptions(w http.ResponseWriter, status int, v interface{}, headers http.Header, opts ResponseOptions) error {
+	if !writeResponseHeader(w, status, headers, jsonContentType) {
+		return nil
+	}
+	enc := json.NewEncoder(w)
+	if opts.Indent != "" {
+		enc.SetIndent("", opts.Indent)
+	}
+	return enc.Encode(v)
+}
+
+// WriteXML writes a response with the given status, whose body is v
+// encoded as XML, as WriteXMLWithOptions does.
+func WriteXML(w http.ResponseWriter, status int, v interface{}, headers http.Header) error {
+	return WriteXMLWithOptions(w, status, v, headers, ResponseOptions{})
+}
+
+// WriteXMLWithOptions writes a response with the given status and headers,
+// whose body is v encoded as XML, after the standard XML declaration, as
+// WriteJSONWithOptions does for JSON. The Content-Type is application/xml,
+// unless headers set another.
+func WriteXMLWithOptions(w http.ResponseWriter, status int, v interface{}, headers http.Header, opts ResponseOptions) error {
+	if !writeResponseHeader(w, status, headers, "application/xml") {
+		return nil
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if opts.Indent != "" {
+		enc.Indent("", opts.Indent)
+	}
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteText writes a response with the given status and headers, whose
+// body is text. The Content-Type is text/plain; charset=utf-8, unless
+// headers set another.
+func WriteText(w http.ResponseWriter, status int, text string, headers http.Header) error {
+	if !writeResponseHeader(w, status, headers, "text/plain; charset=utf-8") {
+		return nil
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+// writeResponseHeader sets the headers of a response, with a default
+// Content-Type, and writes its status. It tells whether the status allows a
+// body, unlike 1xx statuses, 204 No Content and 304 Not Modified, which are
+// written without a Content-Type.
+func writeResponseHeader(w http.ResponseWriter, status int, headers http.Header, contentType string) bool {
+	h := w.Header()
+	for key, values := range headers {
+		h[http.CanonicalHeaderKey(key)] = values
+	}
+	hasBody := status >= 200 && status != http.StatusNoContent && status != http.StatusNotModified
+	if !hasBody {
+		h.Del("Content-Type")
+	} else if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", contentType)
+	}
+	w.WriteHeader(status)
+	return hasBody
+}
@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type responsePet struct {
+	ID   int    `json:"id" xml:"id,attr"`
+	Name string `json:"name" xml:"name"`
+}
+
+func TestWriteJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteJSON(rec, http.StatusCreated, responsePet{ID: 1, Name: "Rex"}, http.Header{"x-request-id": {"abc"}}))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "abc", rec.Header().Get("X-Request-Id"))
+	assert.Equal(t, `{"id":1,"name":"Rex"}`+"\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	problem := http.Header{"Content-Type": {"application/problem+json"}}
+	require.NoError(t, WriteJSONWithOptions(rec, http.StatusBadRequest, map[string]string{"title": "Bad"}, problem, ResponseOptions{Indent: "  "}))
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "{\n  \"title\": \"Bad\"\n}\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, WriteJSON(rec, http.StatusNoContent, responsePet{}, nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+	assert.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	assert.Error(t, WriteJSON(rec, http.StatusOK, make(chan int), nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestWriteXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteXML(rec, http.StatusOK, responsePet{ID: 1, Name: "Rex"}, nil))
+	assert.Equal(t, "application/xml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<responsePet id="1"><name>Rex</name></responsePet>`+"\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, WriteXMLWithOptions(rec, http.StatusOK, responsePet{ID: 1, Name: "Rex"}, nil, ResponseOptions{Indent: "\t"}))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<responsePet id="1">`+"\n\t<name>Rex</name>\n</responsePet>\n", rec.Body.String())
+}
+
+func TestWriteText(t *testing.T) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteText(rec, http.StatusAccepted, "queued", nil))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "queued", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, WriteText(rec, http.StatusOK, "# Title", http.Header{"Content-Type": {"text/markdown"}}))
+	assert.Equal(t, "text/markdown", rec.Header().Get("Content-Type"))
+}
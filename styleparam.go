@@ -16,14 +16,17 @@ package runtime
 import (
 	"bytes"
 	"encoding"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/netip"
 	"net/url"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/oapi-codegen/runtime/types"
@@ -42,6 +45,21 @@ const (
 	ParamLocationCookie
 )
 
+func (l ParamLocation) String() string {
+	switch l {
+	case ParamLocationQuery:
+		return "query"
+	case ParamLocationPath:
+		return "path"
+	case ParamLocationHeader:
+		return "header"
+	case ParamLocationCookie:
+		return "cookie"
+	default:
+		return "undefined"
+	}
+}
+
 // StyleParam is used by older generated code, and must remain compatible
 // with that code. It is not to be used in new templates. Please see the
 // function below, which can specialize its output based on the location of
@@ -54,61 +72,220 @@ func StyleParam(style string, explode bool, paramName string, value interface{})
 // into a parameter based on style/explode definition, performing whatever
 // escaping is necessary based on parameter location
 func StyleParamWithLocation(style string, explode bool, paramName string, paramLocation ParamLocation, value interface{}) (string, error) {
+	return StyleParamWithOptions(style, paramName, value, StyleParamOptions{
+		ParamLocation: paramLocation,
+		Explode:       explode,
+	})
+}
+
+// StyleParamOptions defines optional arguments for StyleParamWithOptions
+type StyleParamOptions struct {
+	// ParamLocation tells us where the parameter is located in the request.
+	ParamLocation ParamLocation
+	// Whether the parameter should use exploded structure
+	Explode bool
+	// AllowReserved leaves the RFC 3986 reserved characters :/?#[]@!$&'()*+,;=
+	// in query parameter values unescaped, as with allowReserved: true in
+	// the spec.
+	AllowReserved bool
+	// TimeFormatter formats time.Time values, which are otherwise formatted
+	// as RFC3339 with nanoseconds.
+	TimeFormatter TimeFormatter
+	// OmitNil makes nil values, such as unset optional parameters, return
+	// ErrOmitParam instead of failing, so that the caller leaves the
+	// parameter out of the request.
+	OmitNil bool
+	// ByteEncoding encodes byte slices, as for format: byte in the spec.
+	// It defaults to base64.StdEncoding, as with encoding/json, but may be
+	// set to base64.URLEncoding, base64.RawURLEncoding, or any other
+	// variant.
+	ByteEncoding *base64.Encoding
+	// DottedKeys flattens nested objects of exploded form style parameters
+	// into dotted keys, as MarshalDottedForm does, rather than failing.
+	DottedKeys bool
+	// KeyLess orders the properties of object parameters. They are sorted by
+	// name when it's nil, so that the output is deterministic. To keep the
+	// properties in a given order instead, style an OrderedObject.
+	KeyLess func(a, b string) bool
+	// StrictDelimiters rejects array elements, and object properties and
+	// values, which contain a delimiter of the style, such as a comma in a
+	// simple style array, since they can't be told apart from the
+	// delimiter once the parameter is bound.
+	StrictDelimiters bool
+}
+
+// ObjectField is a property of an OrderedObject.
+type ObjectField struct {
+	Name  string
+	Value interface{}
+}
+
+// OrderedObject is an object parameter whose properties are styled in the
+// order given, rather than sorted, for example, when a request signature
+// depends on it.
+type OrderedObject []ObjectField
+
+// ErrOmitParam is returned by StyleParamWithOptions, when OmitNil is set, for
+// nil values, which the caller should leave out of the request entirely.
+var ErrOmitParam = errors.New("parameter omitted")
+
+// TimeFormatter formats time.Time parameter values.
+type TimeFormatter interface {
+	FormatTime(t time.Time) string
+}
+
+// TimeLayout is a TimeFormatter which formats times using a layout, as
+// understood by time.Time.Format, such as "2006-01-02 15:04".
+type TimeLayout string
+
+func (l TimeLayout) FormatTime(t time.Time) string {
+	return t.Format(string(l))
+}
+
+// ParseTime parses a time using the layout, as time.Parse does.
+func (l TimeLayout) ParseTime(s string) (time.Time, error) {
+	return time.Parse(string(l), s)
+}
+
+// UnixTimeFormatter is a TimeFormatter which formats times as the number of
+// seconds since the Unix epoch. It's also a TimeParser.
+type UnixTimeFormatter struct{}
+
+func (UnixTimeFormatter) FormatTime(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+func (UnixTimeFormatter) ParseTime(s string) (time.Time, error) {
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// UnixMilliTimeFormatter is a TimeFormatter which formats times as the
+// number of milliseconds since the Unix epoch. It's also a TimeParser.
+type UnixMilliTimeFormatter struct{}
+
+func (UnixMilliTimeFormatter) FormatTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
+
+func (UnixMilliTimeFormatter) ParseTime(s string) (time.Time, error) {
+	msec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(msec).UTC(), nil
+}
+
+// StyleError is returned when a parameter can't be styled. It describes the
+// parameter, and wraps the reason.
+type StyleError struct {
+	ParamName string
+	Location  ParamLocation
+	Style     string
+	Explode   bool
+	Err       error
+}
+
+func (e *StyleError) Error() string {
+	return fmt.Sprintf("error styling %s parameter '%s' (style=%s, explode=%t): %s",
+		e.Location, e.ParamName, e.Style, e.Explode, e.Err)
+}
+
+func (e *StyleError) Unwrap() error {
+	return e.Err
+}
+
+// StyleParamWithOptions turns the input value into a parameter based on its
+// style, as StyleParamWithLocation does, honoring the given options. Errors
+// are returned as a *StyleError, except for ErrOmitParam.
+func StyleParamWithOptions(style string, paramName string, value interface{}, opts StyleParamOptions) (_ string, err error) {
+	defer recoverPanic(&err, "error styling parameter '%s'", paramName)
+	result, err := styleParam(style, paramName, value, opts)
+	if err != nil && err != ErrOmitParam {
+		return "", &StyleError{
+			ParamName: paramName,
+			Location:  opts.ParamLocation,
+			Style:     style,
+			Explode:   opts.Explode,
+			Err:       err,
+		}
+	}
+	return result, err
+}
+
+func styleParam(style string, paramName string, value interface{}, opts StyleParamOptions) (string, error) {
 	t := reflect.TypeOf(value)
 	v := reflect.ValueOf(value)
 
+	if value == nil {
+		if opts.OmitNil {
+			return "", ErrOmitParam
+		}
+		return "", fmt.Errorf("value is nil")
+	}
+
 	// Things may be passed in by pointer, we need to dereference, so return
 	// error on nil.
 	if t.Kind() == reflect.Ptr {
 		if v.IsNil() {
+			if opts.OmitNil {
+				return "", ErrOmitParam
+			}
 			return "", fmt.Errorf("value is a nil pointer")
 		}
 		v = reflect.Indirect(v)
 		t = v.Type()
 	}
 
+	if custom, found := lookupParamStyle(style); found {
+		return custom.styler(paramName, value, opts)
+	}
+
+	if o, ok := value.(OrderedObject); ok {
+		return styleOrderedObject(style, paramName, o, opts)
+	}
+
+	// Types of registered formats are formatted by their codec.
+	if s, ok, err := formatValue(v); ok {
+		if err != nil {
+			return "", err
+		}
+		return stylePrimitive(style, paramName, s, opts)
+	}
+
 	// If the value implements encoding.TextMarshaler we use it for marshaling
 	// https://github.com/deepmap/oapi-codegen/issues/504
-	if tu, ok := value.(encoding.TextMarshaler); ok {
-		t := reflect.Indirect(reflect.ValueOf(value)).Type()
-		convertableToTime := t.ConvertibleTo(reflect.TypeOf(time.Time{}))
-		convertableToDate := t.ConvertibleTo(reflect.TypeOf(types.Date{}))
-
-		// Since both time.Time and types.Date implement encoding.TextMarshaler
-		// we should avoid calling theirs MarshalText()
-		if !convertableToTime && !convertableToDate {
-			b, err := tu.MarshalText()
-			if err != nil {
-				return "", fmt.Errorf("error marshaling '%s' as text: %s", value, err)
-			}
-
-			return stylePrimitive(style, explode, paramName, paramLocation, string(b))
+	if text, ok, err := marshalText(value); ok {
+		if err != nil {
+			return "", err
 		}
+		return stylePrimitive(style, paramName, text, opts)
 	}
 
 	switch t.Kind() {
 	case reflect.Slice:
-		n := v.Len()
-		sliceVal := make([]interface{}, n)
-		for i := 0; i < n; i++ {
-			sliceVal[i] = v.Index(i).Interface()
+		if t.Elem().Kind() == reflect.Uint8 && style != "deepObject" {
+			return stylePrimitive(style, paramName, value, opts)
 		}
-		return styleSlice(style, explode, paramName, paramLocation, sliceVal)
+		return styleSlice(style, paramName, v, opts)
 	case reflect.Struct:
-		return styleStruct(style, explode, paramName, paramLocation, value)
+		return styleStruct(style, paramName, value, opts)
 	case reflect.Map:
-		return styleMap(style, explode, paramName, paramLocation, value)
+		return styleMap(style, paramName, value, opts)
 	default:
-		return stylePrimitive(style, explode, paramName, paramLocation, value)
+		return stylePrimitive(style, paramName, value, opts)
 	}
 }
 
-func styleSlice(style string, explode bool, paramName string, paramLocation ParamLocation, values []interface{}) (string, error) {
+func styleSlice(style string, paramName string, values reflect.Value, opts StyleParamOptions) (string, error) {
 	if style == "deepObject" {
-		if !explode {
+		if !opts.Explode {
 			return "", errors.New("deepObjects must be exploded")
 		}
-		return MarshalDeepObject(values, paramName)
+		return MarshalDeepObject(values.Interface(), paramName)
 	}
 
 	var prefix string
@@ -119,35 +296,35 @@ func styleSlice(style string, explode bool, paramName string, paramLocation Para
 		separator = ","
 	case "label":
 		prefix = "."
-		if explode {
+		if opts.Explode {
 			separator = "."
 		} else {
 			separator = ","
 		}
 	case "matrix":
-		prefix = fmt.Sprintf(";%s=", paramName)
-		if explode {
+		prefix = ";" + paramName + "="
+		if opts.Explode {
 			separator = prefix
 		} else {
 			separator = ","
 		}
 	case "form":
-		prefix = fmt.Sprintf("%s=", paramName)
-		if explode {
-			separator = "&" + prefix
+		prefix = paramName + "="
+		if opts.Explode {
+			separator = formSeparator(opts.ParamLocation) + prefix
 		} else {
 			separator = ","
 		}
 	case "spaceDelimited":
-		prefix = fmt.Sprintf("%s=", paramName)
-		if explode {
+		prefix = paramName + "="
+		if opts.Explode {
 			separator = "&" + prefix
 		} else {
 			separator = " "
 		}
 	case "pipeDelimited":
-		prefix = fmt.Sprintf("%s=", paramName)
-		if explode {
+		prefix = paramName + "="
+		if opts.Explode {
 			separator = "&" + prefix
 		} else {
 			separator = "|"
@@ -156,52 +333,212 @@ func styleSlice(style string, explode bool, paramName string, paramLocation Para
 		return "", fmt.Errorf("unsupported style '%s'", style)
 	}
 
+	// Exploded matrix arrays may hold objects, each of which is styled as
+	// an unexploded object: ;item=id,1,name,a;item=id,2,name,b
+	if style == "matrix" && opts.Explode && isObjectKind(values.Type().Elem()) {
+		elemOpts := opts
+		elemOpts.Explode = false
+		var b strings.Builder
+		for i := 0; i < values.Len(); i++ {
+			part, err := styleParam("simple", paramName, values.Index(i).Interface(), elemOpts)
+			if err != nil {
+				return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
+			}
+			b.WriteString(prefix)
+			b.WriteString(part)
+		}
+		return b.String(), nil
+	}
+
 	// We're going to assume here that the array is one of simple types.
-	var err error
-	var part string
-	parts := make([]string, len(values))
-	for i, v := range values {
-		part, err = primitiveToString(v)
-		part = escapeParameterString(part, paramLocation)
-		parts[i] = part
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i := 0; i < values.Len(); i++ {
+		part, err := opts.valueToString(values.Index(i))
 		if err != nil {
 			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
+		if err := opts.checkDelimiters(style, false, part); err != nil {
+			return "", err
+		}
+		if i > 0 {
+			b.WriteString(separator)
+		}
+		b.WriteString(opts.escape(part))
 	}
-	return prefix + strings.Join(parts, separator), nil
+	return b.String(), nil
 }
 
-func sortedKeys(strMap map[string]string) []string {
-	keys := make([]string, len(strMap))
-	i := 0
-	for k := range strMap {
-		keys[i] = k
-		i++
+// checkDelimiters returns an error, under the StrictDelimiters option, when
+// the value contains one of the delimiters of the style.
+func (o StyleParamOptions) checkDelimiters(style string, object bool, value string) error {
+	if !o.StrictDelimiters {
+		return nil
+	}
+	delimiters := styleDelimiters(style, o.Explode, object)
+	if i := strings.IndexAny(value, delimiters); i >= 0 {
+		return fmt.Errorf("value '%s' contains the delimiter %q of the %s style", value, value[i], style)
+	}
+	return nil
+}
+
+// styleDelimiters returns the characters which delimit the elements of an
+// array, or the properties of an object, in the given style.
+func styleDelimiters(style string, explode bool, object bool) string {
+	var delimiters string
+	switch style {
+	case "simple":
+		delimiters = ","
+	case "label":
+		delimiters = ","
+		if explode {
+			delimiters = "."
+		}
+	case "matrix":
+		delimiters = ","
+		if explode {
+			delimiters = ";"
+		}
+	case "form", "spaceDelimited", "pipeDelimited":
+		// Exploded, each value is a separate query parameter.
+		if explode {
+			return ""
+		}
+		delimiters = styleDelimiter(style)
+	}
+	if object && explode {
+		delimiters += "="
+	}
+	return delimiters
+}
+
+// isObjectKind tells whether values of type t are styled as objects, rather
+// than as primitives.
+func isObjectKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Struct:
+		return !reflect.PtrTo(t).Implements(textMarshalerType) && !isFormatType(t) &&
+			!t.ConvertibleTo(timeType) && !t.ConvertibleTo(dateType) &&
+			t != urlType && t != netipAddrType && t != netipPrefixType && t != netipAddrPortType
+	default:
+		return false
+	}
+}
+
+// marshalKnownTypes is like the marshalKnownTypes function, but formats
+// times using the TimeFormatter, when one is set, and encodes byte slices
+// using the ByteEncoding.
+func (o StyleParamOptions) marshalKnownTypes(value interface{}) (string, bool) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return "", false
+	}
+	if o.TimeFormatter != nil && v.Type().ConvertibleTo(timeType) {
+		return o.TimeFormatter.FormatTime(v.Convert(timeType).Interface().(time.Time)), true
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		enc := o.ByteEncoding
+		if enc == nil {
+			enc = base64.StdEncoding
+		}
+		return enc.EncodeToString(v.Bytes()), true
+	}
+	return marshalKnownTypes(value)
+}
+
+// primitiveToString is like the primitiveToString function, but formats
+// times using the TimeFormatter, when one is set.
+func (o StyleParamOptions) primitiveToString(value interface{}) (string, error) {
+	if s, ok, err := formatValue(reflect.Indirect(reflect.ValueOf(value))); ok {
+		return s, err
+	}
+	if res, ok := o.marshalKnownTypes(value); ok {
+		return res, nil
+	}
+	return primitiveToString(value)
+}
+
+// valueToString is like primitiveToString, but formats values of the basic
+// kinds directly, without boxing them in an interface, so long as their type
+// has no methods which might change how they're formatted.
+func (o StyleParamOptions) valueToString(v reflect.Value) (string, error) {
+	e := v
+	if e.Kind() == reflect.Ptr && !e.IsNil() {
+		e = e.Elem()
+	}
+	if reflect.PtrTo(e.Type()).NumMethod() == 0 && !isFormatType(e.Type()) {
+		switch e.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+			return strconv.FormatInt(e.Int(), 10), nil
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+			return strconv.FormatUint(e.Uint(), 10), nil
+		case reflect.Float64:
+			return strconv.FormatFloat(e.Float(), 'f', -1, 64), nil
+		case reflect.Float32:
+			return strconv.FormatFloat(e.Float(), 'f', -1, 32), nil
+		case reflect.Bool:
+			return strconv.FormatBool(e.Bool()), nil
+		case reflect.String:
+			return e.String(), nil
+		}
 	}
-	sort.Strings(keys)
-	return keys
+	return o.primitiveToString(v.Interface())
 }
 
-// These are special cases. The value may be a date, time, or uuid,
-// in which case, marshal it into the correct format.
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	dateType          = reflect.TypeOf(types.Date{})
+	uuidType          = reflect.TypeOf(types.UUID{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	urlType           = reflect.TypeOf(url.URL{})
+	netipAddrType     = reflect.TypeOf(netip.Addr{})
+	netipPrefixType   = reflect.TypeOf(netip.Prefix{})
+	netipAddrPortType = reflect.TypeOf(netip.AddrPort{})
+)
+
+// These are special cases. The value may be a date, time, duration, uuid,
+// URL or IP address, in which case, marshal it into the correct format.
 func marshalKnownTypes(value interface{}) (string, bool) {
 	v := reflect.Indirect(reflect.ValueOf(value))
 	t := v.Type()
 
-	if t.ConvertibleTo(reflect.TypeOf(time.Time{})) {
-		tt := v.Convert(reflect.TypeOf(time.Time{}))
+	// These are matched exactly, since any integer type is convertible to
+	// time.Duration, and we don't want to format those as durations.
+	switch t {
+	case durationType:
+		return v.Interface().(time.Duration).String(), true
+	case urlType:
+		u := v.Interface().(url.URL)
+		return u.String(), true
+	case netipAddrType:
+		return v.Interface().(netip.Addr).String(), true
+	case netipPrefixType:
+		return v.Interface().(netip.Prefix).String(), true
+	case netipAddrPortType:
+		return v.Interface().(netip.AddrPort).String(), true
+	}
+
+	if t.ConvertibleTo(timeType) {
+		tt := v.Convert(timeType)
 		timeVal := tt.Interface().(time.Time)
 		return timeVal.Format(time.RFC3339Nano), true
 	}
 
-	if t.ConvertibleTo(reflect.TypeOf(types.Date{})) {
-		d := v.Convert(reflect.TypeOf(types.Date{}))
+	if t.ConvertibleTo(dateType) {
+		d := v.Convert(dateType)
 		dateVal := d.Interface().(types.Date)
 		return dateVal.Format(types.DateFormat), true
 	}
 
-	if t.ConvertibleTo(reflect.TypeOf(types.UUID{})) {
-		u := v.Convert(reflect.TypeOf(types.UUID{}))
+	// Other 16 byte arrays which marshal themselves, such as types.ULID,
+	// aren't UUIDs.
+	if t == uuidType || t.ConvertibleTo(uuidType) && !t.Implements(textMarshalerType) {
+		u := v.Convert(uuidType)
 		uuidVal := u.Interface().(types.UUID)
 		return uuidVal.String(), true
 	}
@@ -209,9 +546,43 @@ func marshalKnownTypes(value interface{}) (string, bool) {
 	return "", false
 }
 
-func styleStruct(style string, explode bool, paramName string, paramLocation ParamLocation, value interface{}) (string, error) {
-	if timeVal, ok := marshalKnownTypes(value); ok {
-		styledVal, err := stylePrimitive(style, explode, paramName, paramLocation, timeVal)
+// marshalText marshals the value using its encoding.TextMarshaler
+// implementation, which may be on the value or on a pointer to it. Since
+// both time.Time and types.Date implement encoding.TextMarshaler, known
+// types are left to marshalKnownTypes, which formats them as the spec
+// requires.
+func marshalText(value interface{}) (string, bool, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return "", false, nil
+	}
+	t := v.Type()
+	if t.ConvertibleTo(timeType) || t.ConvertibleTo(dateType) {
+		return "", false, nil
+	}
+
+	tm, ok := value.(encoding.TextMarshaler)
+	if !ok {
+		if !reflect.PtrTo(t).Implements(textMarshalerType) {
+			return "", false, nil
+		}
+		ptr := reflect.New(t)
+		ptr.Elem().Set(v)
+		tm, ok = ptr.Interface().(encoding.TextMarshaler)
+		if !ok {
+			return "", false, nil
+		}
+	}
+	b, err := tm.MarshalText()
+	if err != nil {
+		return "", true, fmt.Errorf("error marshaling '%v' as text: %w", value, err)
+	}
+	return string(b), true, nil
+}
+
+func styleStruct(style string, paramName string, value interface{}, opts StyleParamOptions) (string, error) {
+	if timeVal, ok := opts.marshalKnownTypes(value); ok {
+		styledVal, err := stylePrimitive(style, paramName, timeVal, opts)
 		if err != nil {
 			return "", fmt.Errorf("failed to style time: %w", err)
 		}
@@ -219,12 +590,16 @@ func styleStruct(style string, explode bool, paramName string, paramLocation Par
 	}
 
 	if style == "deepObject" {
-		if !explode {
+		if !opts.Explode {
 			return "", errors.New("deepObjects must be exploded")
 		}
 		return MarshalDeepObject(value, paramName)
 	}
 
+	if opts.DottedKeys && style == "form" && opts.Explode {
+		return MarshalDottedForm(value, paramName)
+	}
+
 	// If input has Marshaler, such as object has Additional Property or AnyOf,
 	// We use this Marshaler and convert into interface{} before styling.
 	if m, ok := value.(json.Marshaler); ok {
@@ -239,7 +614,7 @@ func styleStruct(style string, explode bool, paramName string, paramLocation Par
 		if err != nil {
 			return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
 		}
-		s, err := StyleParamWithLocation(style, explode, paramName, paramLocation, i2)
+		s, err := styleParam(style, paramName, i2, opts)
 		if err != nil {
 			return "", fmt.Errorf("error style JSON structure: %w", err)
 		}
@@ -248,83 +623,142 @@ func styleStruct(style string, explode bool, paramName string, paramLocation Par
 
 	// Otherwise, we need to build a dictionary of the struct's fields. Each
 	// field may only be a primitive value.
-	v := reflect.ValueOf(value)
-	t := reflect.TypeOf(value)
-	fieldDict := make(map[string]string)
+	v := reflect.Indirect(reflect.ValueOf(value))
+	plan := cachedStyleFields(v.Type())
+	fields := make([]styleField, 0, len(plan))
+	for _, sf := range plan {
+		f := v.Field(sf.index)
 
+		// Unset optional fields will be nil pointers, skip over those.
+		if f.Kind() == reflect.Ptr && f.IsNil() {
+			continue
+		}
+		str, err := opts.valueToString(f)
+		if err != nil {
+			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
+		}
+		// When several fields share a name, the last one set wins.
+		if n := len(fields); n > 0 && fields[n-1].name == sf.name {
+			fields[n-1].value = str
+			continue
+		}
+		fields = append(fields, styleField{name: sf.name, value: str})
+	}
+	if opts.KeyLess != nil {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return opts.KeyLess(fields[i].name, fields[j].name)
+		})
+	}
+
+	return processFieldDict(style, paramName, fields, opts)
+}
+
+// styleField is a named value of an object parameter.
+type styleField struct {
+	name  string
+	value string
+}
+
+// structField is a field of a struct which is styled as a parameter,
+// named by its json tag.
+type structField struct {
+	index int
+	name  string
+}
+
+// styleFieldCache maps struct types to their []structField, sorted by name.
+var styleFieldCache sync.Map
+
+func cachedStyleFields(t reflect.Type) []structField {
+	if fields, ok := styleFieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+
+	fields := make([]structField, 0, t.NumField())
 	for i := 0; i < t.NumField(); i++ {
 		fieldT := t.Field(i)
+		if fieldT.PkgPath != "" {
+			continue
+		}
 		// Find the json annotation on the field, and use the json specified
 		// name if available, otherwise, just the field name.
-		tag := fieldT.Tag.Get("json")
 		fieldName := fieldT.Name
-		if tag != "" {
-			tagParts := strings.Split(tag, ",")
-			name := tagParts[0]
-			if name != "" {
+		if tag := fieldT.Tag.Get("json"); tag != "" {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
 				fieldName = name
 			}
 		}
-		f := v.Field(i)
-
-		// Unset optional fields will be nil pointers, skip over those.
-		if f.Type().Kind() == reflect.Ptr && f.IsNil() {
-			continue
-		}
-		str, err := primitiveToString(f.Interface())
-		if err != nil {
-			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
-		}
-		fieldDict[fieldName] = str
+		fields = append(fields, structField{index: i, name: fieldName})
 	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
 
-	return processFieldDict(style, explode, paramName, paramLocation, fieldDict)
+	actual, _ := styleFieldCache.LoadOrStore(t, fields)
+	return actual.([]structField)
 }
 
-func styleMap(style string, explode bool, paramName string, paramLocation ParamLocation, value interface{}) (string, error) {
+func styleMap(style string, paramName string, value interface{}, opts StyleParamOptions) (string, error) {
 	if style == "deepObject" {
-		if !explode {
+		if !opts.Explode {
 			return "", errors.New("deepObjects must be exploded")
 		}
 		return MarshalDeepObject(value, paramName)
 	}
 
-	dict, ok := value.(map[string]interface{})
-	if !ok {
-		return "", errors.New("map not of type map[string]interface{}")
+	if opts.DottedKeys && style == "form" && opts.Explode {
+		return MarshalDottedForm(value, paramName)
 	}
 
-	fieldDict := make(map[string]string)
-	for fieldName, value := range dict {
-		str, err := primitiveToString(value)
+	// Any map with primitive keys and values will do, such as
+	// map[string]interface{} or map[string]string.
+	dict := reflect.Indirect(reflect.ValueOf(value))
+	fields := make([]styleField, 0, dict.Len())
+	iter := dict.MapRange()
+	for iter.Next() {
+		// Like unset struct fields, nil values are left out.
+		if elem := iter.Value(); (elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr) && elem.IsNil() {
+			continue
+		}
+		fieldName, err := mapKeyString(iter.Key())
+		if err != nil {
+			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
+		}
+		str, err := opts.valueToString(iter.Value())
 		if err != nil {
 			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
-		fieldDict[fieldName] = str
+		fields = append(fields, styleField{name: fieldName, value: str})
 	}
-	return processFieldDict(style, explode, paramName, paramLocation, fieldDict)
+	less := opts.KeyLess
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return less(fields[i].name, fields[j].name)
+	})
+	return processFieldDict(style, paramName, fields, opts)
 }
 
-func processFieldDict(style string, explode bool, paramName string, paramLocation ParamLocation, fieldDict map[string]string) (string, error) {
-	var parts []string
+func styleOrderedObject(style string, paramName string, o OrderedObject, opts StyleParamOptions) (string, error) {
+	if style == "deepObject" && !opts.Explode {
+		return "", errors.New("deepObjects must be exploded")
+	}
 
-	// This works for everything except deepObject. We'll handle that one
-	// separately.
-	if style != "deepObject" {
-		if explode {
-			for _, k := range sortedKeys(fieldDict) {
-				v := escapeParameterString(fieldDict[k], paramLocation)
-				parts = append(parts, k+"="+v)
-			}
-		} else {
-			for _, k := range sortedKeys(fieldDict) {
-				v := escapeParameterString(fieldDict[k], paramLocation)
-				parts = append(parts, k)
-				parts = append(parts, v)
-			}
+	fields := make([]styleField, 0, len(o))
+	for _, f := range o {
+		str, err := opts.primitiveToString(f.Value)
+		if err != nil {
+			return "", fmt.Errorf("error formatting '%s': %s", paramName, err)
 		}
+		fields = append(fields, styleField{name: f.Name, value: str})
 	}
+	return processFieldDict(style, paramName, fields, opts)
+}
 
+// processFieldDict styles the fields of an object parameter, in the order
+// given.
+func processFieldDict(style string, paramName string, fields []styleField, opts StyleParamOptions) (string, error) {
 	var prefix string
 	var separator string
 
@@ -333,47 +767,83 @@ func processFieldDict(style string, explode bool, paramName string, paramLocatio
 		separator = ","
 	case "label":
 		prefix = "."
-		if explode {
+		if opts.Explode {
 			separator = prefix
 		} else {
 			separator = ","
 		}
 	case "matrix":
-		if explode {
+		if opts.Explode {
 			separator = ";"
 			prefix = ";"
 		} else {
 			separator = ","
-			prefix = fmt.Sprintf(";%s=", paramName)
+			prefix = ";" + paramName + "="
 		}
 	case "form":
-		if explode {
-			separator = "&"
+		if opts.Explode {
+			separator = formSeparator(opts.ParamLocation)
 		} else {
-			prefix = fmt.Sprintf("%s=", paramName)
+			prefix = paramName + "="
 			separator = ","
 		}
-	case "deepObject":
-		{
-			if !explode {
-				return "", fmt.Errorf("deepObject parameters must be exploded")
-			}
-			for _, k := range sortedKeys(fieldDict) {
-				v := fieldDict[k]
-				part := fmt.Sprintf("%s[%s]=%s", paramName, k, v)
-				parts = append(parts, part)
+	case "spaceDelimited", "pipeDelimited":
+		// Unexploded, properties and values are joined by the delimiter,
+		// exploded, these are the same as the form style.
+		if opts.Explode {
+			separator = formSeparator(opts.ParamLocation)
+		} else {
+			prefix = paramName + "="
+			separator = " "
+			if style == "pipeDelimited" {
+				separator = "|"
 			}
-			separator = "&"
 		}
+	case "deepObject":
+		if !opts.Explode {
+			return "", fmt.Errorf("deepObject parameters must be exploded")
+		}
+		separator = "&"
 	default:
 		return "", fmt.Errorf("unsupported style '%s'", style)
 	}
 
-	return prefix + strings.Join(parts, separator), nil
+	var b strings.Builder
+	b.WriteString(prefix)
+	for i, f := range fields {
+		if style != "deepObject" {
+			if err := opts.checkDelimiters(style, true, f.name); err != nil {
+				return "", err
+			}
+			if err := opts.checkDelimiters(style, true, f.value); err != nil {
+				return "", err
+			}
+		}
+		if i > 0 {
+			b.WriteString(separator)
+		}
+		switch {
+		case style == "deepObject":
+			b.WriteString(paramName)
+			b.WriteByte('[')
+			b.WriteString(f.name)
+			b.WriteString("]=")
+			b.WriteString(f.value)
+		case opts.Explode:
+			b.WriteString(opts.escape(f.name))
+			b.WriteByte('=')
+			b.WriteString(opts.escape(f.value))
+		default:
+			b.WriteString(opts.escape(f.name))
+			b.WriteString(separator)
+			b.WriteString(opts.escape(f.value))
+		}
+	}
+	return b.String(), nil
 }
 
-func stylePrimitive(style string, explode bool, paramName string, paramLocation ParamLocation, value interface{}) (string, error) {
-	strVal, err := primitiveToString(value)
+func stylePrimitive(style string, paramName string, value interface{}, opts StyleParamOptions) (string, error) {
+	strVal, err := opts.primitiveToString(value)
 	if err != nil {
 		return "", err
 	}
@@ -384,18 +854,36 @@ func stylePrimitive(style string, explode bool, paramName string, paramLocation
 	case "label":
 		prefix = "."
 	case "matrix":
-		prefix = fmt.Sprintf(";%s=", paramName)
+		prefix = ";" + paramName + "="
 	case "form":
-		prefix = fmt.Sprintf("%s=", paramName)
+		prefix = paramName + "="
 	default:
 		return "", fmt.Errorf("unsupported style '%s'", style)
 	}
-	return prefix + escapeParameterString(strVal, paramLocation), nil
+	return prefix + opts.escape(strVal), nil
 }
 
 // Converts a primitive value to a string. We need to do this based on the
 // Kind of an interface, not the Type to work with aliased types.
 func primitiveToString(value interface{}) (string, error) {
+	// The common cases first, which need no reflection.
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
 	var output string
 
 	// sometimes time and date used like primitive types
@@ -404,11 +892,21 @@ func primitiveToString(value interface{}) (string, error) {
 		return res, nil
 	}
 
+	if res, ok, err := marshalText(value); ok {
+		return res, err
+	}
+
 	// Values may come in by pointer for optionals, so make sure to dereferene.
 	v := reflect.Indirect(reflect.ValueOf(value))
 	t := v.Type()
 	kind := t.Kind()
 
+	// Named primitive types, such as enums, are formatted using their
+	// declared representation, if they have one, like MarshalDeepObject does.
+	if s, ok := primitiveStringer(v); ok {
+		return s.String(), nil
+	}
+
 	switch kind {
 	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
 		output = strconv.FormatInt(v.Int(), 10)
@@ -463,16 +961,112 @@ func primitiveToString(value interface{}) (string, error) {
 	return output, nil
 }
 
+// escape escapes a parameter value according to the options.
+func (o StyleParamOptions) escape(value string) string {
+	if o.AllowReserved && o.ParamLocation == ParamLocationQuery {
+		return escapeQueryAllowReserved(value)
+	}
+	return escapeParameterString(value, o.ParamLocation)
+}
+
+// escapeQueryAllowReserved percent-encodes a query parameter value, except
+// for the unreserved and reserved characters of RFC 3986, section 2.
+func escapeQueryAllowReserved(value string) string {
+	const upperhex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isUnreserved(c) || strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// formSeparator returns the separator between the name=value pairs of
+// exploded form style parameters. Cookies hold their pairs in a Cookie
+// header, where they are separated by semicolons, rather than in a query.
+func formSeparator(paramLocation ParamLocation) string {
+	if paramLocation == ParamLocationCookie {
+		return "; "
+	}
+	return "&"
+}
+
 // escapeParameterString escapes a parameter value bas on the location of that parameter.
-// Query params and path params need different kinds of escaping, while header
-// and cookie params seem not to need escaping.
+// Query params and path params need different kinds of escaping, cookie
+// params must only contain the characters allowed in a cookie value, and
+// header params those allowed in a header field value.
 func escapeParameterString(value string, paramLocation ParamLocation) string {
 	switch paramLocation {
 	case ParamLocationQuery:
 		return url.QueryEscape(value)
 	case ParamLocationPath:
 		return url.PathEscape(value)
+	case ParamLocationCookie:
+		return escapeCookieValue(value)
+	case ParamLocationHeader:
+		return escapeHeaderValue(value)
 	default:
 		return value
 	}
 }
+
+// escapeHeaderValue percent-encodes the bytes which may not appear in a
+// field value, as defined by RFC 9110, section 5.5: control characters and
+// non-ASCII text, along with the comma, which delimits list values, the
+// double quote, which starts a quoted string, leading and trailing spaces,
+// which are stripped from field values, and '%' itself, so that the result
+// can be decoded by url.PathUnescape, as binding with DecodePercent does.
+func escapeHeaderValue(value string) string {
+	const upperhex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		space := c == ' ' && (i == 0 || i == len(value)-1)
+		if c >= 0x20 && c < 0x7f && c != '%' && c != ',' && c != '"' && !space {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+	return b.String()
+}
+
+// escapeCookieValue percent-encodes every byte which isn't a cookie-octet,
+// as defined by RFC 6265, section 4.1.1, along with '%' itself, so that the
+// result is a valid cookie value which url.PathUnescape can decode.
+func escapeCookieValue(value string) string {
+	const upperhex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if isCookieOctet(c) && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteByte('%')
+		b.WriteByte(upperhex[c>>4])
+		b.WriteByte(upperhex[c&15])
+	}
+	return b.String()
+}
+
+func isCookieOctet(c byte) bool {
+	return c == 0x21 ||
+		(c >= 0x23 && c <= 0x2B) ||
+		(c >= 0x2D && c <= 0x3A) ||
+		(c >= 0x3C && c <= 0x5B) ||
+		(c >= 0x5D && c <= 0x7E)
+}
@@ -14,12 +14,18 @@
 package runtime
 
 import (
+	"encoding/base64"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/oapi-codegen/runtime/types"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStyleParam(t *testing.T) {
@@ -408,17 +414,21 @@ func TestStyleParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "id=3&id=4&id=5", result)
 
-	_, err = StyleParamWithLocation("spaceDelimited", false, "id", ParamLocationQuery, object)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("spaceDelimited", false, "id", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=firstName Alex role admin", result)
 
-	_, err = StyleParamWithLocation("spaceDelimited", true, "id", ParamLocationQuery, object)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("spaceDelimited", true, "id", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex&role=admin", result)
 
-	_, err = StyleParamWithLocation("spaceDelimited", false, "id", ParamLocationQuery, dict)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("spaceDelimited", false, "id", ParamLocationQuery, dict)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=firstName Alex role admin", result)
 
-	_, err = StyleParamWithLocation("spaceDelimited", true, "id", ParamLocationQuery, dict)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("spaceDelimited", true, "id", ParamLocationQuery, dict)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex&role=admin", result)
 
 	_, err = StyleParamWithLocation("spaceDelimited", false, "id", ParamLocationQuery, timestamp)
 	assert.Error(t, err)
@@ -472,17 +482,21 @@ func TestStyleParam(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, "id=3&id=4&id=5", result)
 
-	_, err = StyleParamWithLocation("pipeDelimited", false, "id", ParamLocationQuery, object)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("pipeDelimited", false, "id", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=firstName|Alex|role|admin", result)
 
-	_, err = StyleParamWithLocation("pipeDelimited", true, "id", ParamLocationQuery, object)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("pipeDelimited", true, "id", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex&role=admin", result)
 
-	_, err = StyleParamWithLocation("pipeDelimited", false, "id", ParamLocationQuery, dict)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("pipeDelimited", false, "id", ParamLocationQuery, dict)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=firstName|Alex|role|admin", result)
 
-	_, err = StyleParamWithLocation("pipeDelimited", true, "id", ParamLocationQuery, dict)
-	assert.Error(t, err)
+	result, err = StyleParamWithLocation("pipeDelimited", true, "id", ParamLocationQuery, dict)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex&role=admin", result)
 
 	_, err = StyleParamWithLocation("pipeDelimited", false, "id", ParamLocationQuery, timestamp)
 	assert.Error(t, err)
@@ -690,3 +704,491 @@ func TestStyleParam(t *testing.T) {
 	assert.EqualValues(t, "972beb41-e5ea-4b31-a79a-96f4999d8769", result)
 
 }
+
+func TestStyleParamCookie(t *testing.T) {
+	type TestObject struct {
+		FirstName string `json:"firstName"`
+		Role      string `json:"role"`
+	}
+	object := TestObject{
+		FirstName: "Alex Smith",
+		Role:      "admin",
+	}
+
+	result, err := StyleParamWithLocation("form", false, "id", ParamLocationCookie, 5)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=5", result)
+
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationCookie, "a b;c,d\"é%")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=a%20b%3Bc%2Cd%22%C3%A9%25", result)
+
+	result, err = StyleParamWithLocation("form", false, "id", ParamLocationCookie, []string{"3", "4,5"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=3,4%2C5", result)
+
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationCookie, []int{3, 4, 5})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=3; id=4; id=5", result)
+
+	result, err = StyleParamWithLocation("form", false, "id", ParamLocationCookie, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "id=firstName,Alex%20Smith,role,admin", result)
+
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationCookie, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex%20Smith; role=admin", result)
+
+	// Generated clients put the simple styled value into an http.Cookie.
+	result, err = StyleParamWithLocation("simple", false, "id", ParamLocationCookie, "session id")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "session%20id", result)
+}
+
+func TestStyleParamAllowReserved(t *testing.T) {
+	opts := StyleParamOptions{ParamLocation: ParamLocationQuery, AllowReserved: true}
+
+	result, err := StyleParamWithOptions("form", "filter", "/a/b?c=d&e", opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "filter=/a/b?c=d&e", result)
+
+	result, err = StyleParamWithOptions("form", "filter", "a b%c\"é", opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "filter=a%20b%25c%22%C3%A9", result)
+
+	result, err = StyleParamWithOptions("form", "filter", []string{"/a", "/b"}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "filter=/a,/b", result)
+
+	// Without allowReserved, the value is fully escaped.
+	opts.AllowReserved = false
+	result, err = StyleParamWithOptions("form", "filter", "/a/b", opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "filter=%2Fa%2Fb", result)
+
+	// It only applies to query parameters.
+	result, err = StyleParamWithOptions("simple", "filter", "/a/b", StyleParamOptions{ParamLocation: ParamLocationPath, AllowReserved: true})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "%2Fa%2Fb", result)
+}
+
+func TestStyleParamKnownTypes(t *testing.T) {
+	id := uuid.MustParse("baa07328-452e-40bd-aa2e-fa823ec13605")
+	addr := netip.MustParseAddr("2001:db8::1")
+	u, err := url.Parse("https://example.com/a b?q=1")
+	require.NoError(t, err)
+
+	result, err := StyleParamWithLocation("simple", false, "id", ParamLocationPath, id)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "baa07328-452e-40bd-aa2e-fa823ec13605", result)
+
+	result, err = StyleParamWithLocation("form", true, "addr", ParamLocationQuery, addr)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "addr=2001%3Adb8%3A%3A1", result)
+
+	result, err = StyleParamWithLocation("form", true, "prefix", ParamLocationQuery, netip.MustParsePrefix("10.0.0.0/8"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "prefix=10.0.0.0%2F8", result)
+
+	result, err = StyleParamWithLocation("form", true, "url", ParamLocationQuery, u)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "url=https%3A%2F%2Fexample.com%2Fa%2520b%3Fq%3D1", result)
+
+	result, err = StyleParamWithLocation("form", true, "url", ParamLocationQuery, *u)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "url=https%3A%2F%2Fexample.com%2Fa%2520b%3Fq%3D1", result)
+
+	result, err = StyleParamWithLocation("simple", false, "timeout", ParamLocationHeader, 90*time.Second)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "1m30s", result)
+
+	result, err = StyleParamWithLocation("form", false, "timeouts", ParamLocationQuery, []time.Duration{time.Second, time.Millisecond})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "timeouts=1s,1ms", result)
+
+	// Other integer types are not mistaken for durations.
+	type Count int64
+	result, err = StyleParamWithLocation("simple", false, "count", ParamLocationPath, Count(90))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "90", result)
+
+	type Object struct {
+		ID      uuid.UUID     `json:"id"`
+		Addr    netip.Addr    `json:"addr"`
+		URL     *url.URL      `json:"url"`
+		Timeout time.Duration `json:"timeout"`
+	}
+	object := Object{ID: id, Addr: addr, URL: u, Timeout: time.Minute}
+
+	result, err = StyleParamWithLocation("form", true, "object", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "addr=2001%3Adb8%3A%3A1&id=baa07328-452e-40bd-aa2e-fa823ec13605&timeout=1m0s&url=https%3A%2F%2Fexample.com%2Fa%2520b%3Fq%3D1", result)
+}
+
+type textColor int
+
+func (c textColor) MarshalText() ([]byte, error) {
+	switch c {
+	case 0:
+		return []byte("red"), nil
+	case 1:
+		return []byte("green"), nil
+	}
+	return nil, fmt.Errorf("unknown color %d", int(c))
+}
+
+type textPoint struct {
+	X, Y int
+}
+
+func (p *textPoint) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d:%d", p.X, p.Y)), nil
+}
+
+func TestStyleParamTextMarshaler(t *testing.T) {
+	result, err := StyleParamWithLocation("simple", false, "color", ParamLocationPath, textColor(1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "green", result)
+
+	// MarshalText is used even when it's on the pointer receiver.
+	result, err = StyleParamWithLocation("form", true, "point", ParamLocationQuery, textPoint{X: 1, Y: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "point=1%3A2", result)
+
+	result, err = StyleParamWithLocation("form", true, "point", ParamLocationQuery, &textPoint{X: 1, Y: 2})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "point=1%3A2", result)
+
+	result, err = StyleParamWithLocation("form", false, "colors", ParamLocationQuery, []textColor{0, 1})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "colors=red,green", result)
+
+	type Object struct {
+		Color textColor `json:"color"`
+		Point textPoint `json:"point"`
+	}
+	result, err = StyleParamWithLocation("simple", true, "object", ParamLocationHeader, Object{Color: 0, Point: textPoint{X: 3, Y: 4}})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "color=red,point=3:4", result)
+
+	_, err = StyleParamWithLocation("simple", false, "color", ParamLocationPath, textColor(7))
+	assert.ErrorContains(t, err, "unknown color 7")
+}
+
+func TestStyleParamTimeFormatter(t *testing.T) {
+	ti := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	opts := StyleParamOptions{
+		ParamLocation: ParamLocationQuery,
+		Explode:       true,
+		TimeFormatter: TimeLayout("2006-01-02 15:04"),
+	}
+	result, err := StyleParamWithOptions("form", "since", ti, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "since=2020-01-02+15%3A04", result)
+
+	result, err = StyleParamWithOptions("form", "since", &ti, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "since=2020-01-02+15%3A04", result)
+
+	opts.TimeFormatter = UnixTimeFormatter{}
+	result, err = StyleParamWithOptions("form", "since", []time.Time{ti, ti.Add(time.Second)}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "since=1577977445&since=1577977446", result)
+
+	type Range struct {
+		From time.Time  `json:"from"`
+		To   *time.Time `json:"to"`
+	}
+	result, err = StyleParamWithOptions("form", "range", Range{From: ti, To: &ti}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "from=1577977445&to=1577977445", result)
+
+	// Dates are unaffected by the time formatter.
+	result, err = StyleParamWithOptions("form", "day", types.Date{Time: ti}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "day=2020-01-02", result)
+
+	// Without a formatter, times are RFC3339.
+	opts.TimeFormatter = nil
+	result, err = StyleParamWithOptions("form", "since", ti, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "since=2020-01-02T15%3A04%3A05Z", result)
+}
+
+type benchmarkObject struct {
+	ID        int        `json:"id"`
+	FirstName string     `json:"firstName"`
+	LastName  string     `json:"lastName"`
+	Role      string     `json:"role,omitempty"`
+	Active    bool       `json:"active"`
+	Score     float64    `json:"score"`
+	Nickname  *string    `json:"nickname"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+func BenchmarkStyleParam(b *testing.B) {
+	nickname := "al"
+	object := benchmarkObject{
+		ID:        42,
+		FirstName: "Alex",
+		LastName:  "Smith Jones",
+		Role:      "admin",
+		Active:    true,
+		Score:     9.5,
+		Nickname:  &nickname,
+		CreatedAt: time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC),
+	}
+	benchmarks := []struct {
+		name    string
+		style   string
+		explode bool
+		value   interface{}
+	}{
+		{"primitive/int", "simple", false, 12345},
+		{"primitive/string", "form", true, "hello world"},
+		{"primitive/uuid", "simple", false, uuid.MustParse("baa07328-452e-40bd-aa2e-fa823ec13605")},
+		{"primitive/time", "form", true, time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"slice/int", "form", true, []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"slice/string", "simple", false, []string{"a", "b c", "d", "e/f"}},
+		{"struct/form", "form", true, object},
+		{"struct/simple", "simple", false, &object},
+		{"map/form", "form", true, map[string]interface{}{"a": 1, "b": "two", "c": true}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := StyleParamWithLocation(bm.style, bm.explode, "id", ParamLocationQuery, bm.value)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestStyleParamStructPointer(t *testing.T) {
+	type Object struct {
+		FirstName string `json:"firstName"`
+		Role      string `json:"role,omitempty"`
+		internal  string
+	}
+	object := &Object{FirstName: "Alex", Role: "admin", internal: "x"}
+
+	result, err := StyleParamWithLocation("simple", false, "id", ParamLocationPath, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName,Alex,role,admin", result)
+
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "firstName=Alex&role=admin", result)
+}
+
+func TestStyleParamOmitNil(t *testing.T) {
+	var name *string
+
+	_, err := StyleParamWithLocation("form", true, "name", ParamLocationQuery, name)
+	assert.ErrorContains(t, err, "value is a nil pointer")
+
+	_, err = StyleParamWithLocation("form", true, "name", ParamLocationQuery, nil)
+	assert.ErrorContains(t, err, "value is nil")
+
+	opts := StyleParamOptions{ParamLocation: ParamLocationQuery, Explode: true, OmitNil: true}
+	_, err = StyleParamWithOptions("form", "name", name, opts)
+	assert.ErrorIs(t, err, ErrOmitParam)
+
+	_, err = StyleParamWithOptions("form", "name", nil, opts)
+	assert.ErrorIs(t, err, ErrOmitParam)
+
+	value := "Alex"
+	name = &value
+	result, err := StyleParamWithOptions("form", "name", name, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "name=Alex", result)
+}
+
+func TestStyleParamBytes(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0x01, 'h', 'i'}
+
+	result, err := StyleParamWithLocation("simple", false, "data", ParamLocationHeader, data)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "+/8BaGk=", result)
+
+	result, err = StyleParamWithLocation("form", true, "data", ParamLocationQuery, data)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "data=%2B%2F8BaGk%3D", result)
+
+	opts := StyleParamOptions{ParamLocation: ParamLocationQuery, Explode: true, ByteEncoding: base64.RawURLEncoding}
+	result, err = StyleParamWithOptions("form", "data", data, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "data=-_8BaGk", result)
+
+	type Blob []byte
+	result, err = StyleParamWithOptions("form", "data", Blob(data), opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "data=-_8BaGk", result)
+
+	type Object struct {
+		Data []byte `json:"data"`
+	}
+	result, err = StyleParamWithOptions("form", "object", Object{Data: data}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "data=-_8BaGk", result)
+
+	result, err = StyleParamWithOptions("form", "data", [][]byte{data, []byte("hi")}, opts)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "data=-_8BaGk&data=aGk", result)
+}
+
+func TestStyleParamError(t *testing.T) {
+	_, err := StyleParamWithLocation("matrix", true, "color", ParamLocationPath, textColor(7))
+	var styleErr *StyleError
+	require.ErrorAs(t, err, &styleErr)
+	assert.Equal(t, "color", styleErr.ParamName)
+	assert.Equal(t, ParamLocationPath, styleErr.Location)
+	assert.Equal(t, "matrix", styleErr.Style)
+	assert.True(t, styleErr.Explode)
+	assert.ErrorContains(t, styleErr.Err, "unknown color 7")
+	assert.EqualError(t, err, "error styling path parameter 'color' (style=matrix, explode=true): "+
+		"error marshaling '7' as text: unknown color 7")
+
+	_, err = StyleParamWithLocation("unknown", false, "id", ParamLocationQuery, 5)
+	require.ErrorAs(t, err, &styleErr)
+	assert.Equal(t, "unknown", styleErr.Style)
+	assert.EqualError(t, styleErr.Err, "unsupported style 'unknown'")
+}
+
+func TestStyleParamKeyOrder(t *testing.T) {
+	dict := map[string]interface{}{"b": 1, "a": 2, "c": 3}
+
+	// Keys are always sorted by default.
+	for i := 0; i < 10; i++ {
+		result, err := StyleParamWithLocation("form", true, "id", ParamLocationQuery, dict)
+		require.NoError(t, err)
+		assert.EqualValues(t, "a=2&b=1&c=3", result)
+	}
+
+	reverse := func(a, b string) bool { return a > b }
+	opts := StyleParamOptions{ParamLocation: ParamLocationQuery, Explode: true, KeyLess: reverse}
+	result, err := StyleParamWithOptions("form", "id", dict, opts)
+	require.NoError(t, err)
+	assert.EqualValues(t, "c=3&b=1&a=2", result)
+
+	type Object struct {
+		First  string `json:"first"`
+		Second string `json:"second"`
+	}
+	result, err = StyleParamWithOptions("form", "id", Object{First: "x", Second: "y"}, opts)
+	require.NoError(t, err)
+	assert.EqualValues(t, "second=y&first=x", result)
+
+	ordered := OrderedObject{{Name: "z", Value: 1}, {Name: "a", Value: "b c"}}
+	result, err = StyleParamWithLocation("form", true, "id", ParamLocationQuery, ordered)
+	require.NoError(t, err)
+	assert.EqualValues(t, "z=1&a=b+c", result)
+
+	result, err = StyleParamWithLocation("simple", false, "id", ParamLocationPath, ordered)
+	require.NoError(t, err)
+	assert.EqualValues(t, "z,1,a,b%20c", result)
+
+	result, err = StyleParamWithLocation("deepObject", true, "id", ParamLocationQuery, ordered)
+	require.NoError(t, err)
+	assert.EqualValues(t, "id[z]=1&id[a]=b c", result)
+}
+
+func TestStyleParamStrictDelimiters(t *testing.T) {
+	opts := StyleParamOptions{ParamLocation: ParamLocationHeader, StrictDelimiters: true}
+
+	_, err := StyleParamWithOptions("simple", "ids", []string{"a,b", "c"}, opts)
+	assert.ErrorContains(t, err, "value 'a,b' contains the delimiter ',' of the simple style")
+
+	_, err = StyleParamWithOptions("simple", "object", map[string]interface{}{"a,b": "c"}, opts)
+	assert.ErrorContains(t, err, "contains the delimiter")
+
+	opts.Explode = true
+	_, err = StyleParamWithOptions("simple", "object", map[string]interface{}{"a": "b=c"}, opts)
+	assert.ErrorContains(t, err, "value 'b=c' contains the delimiter '='")
+
+	opts = StyleParamOptions{ParamLocation: ParamLocationQuery, StrictDelimiters: true}
+	_, err = StyleParamWithOptions("pipeDelimited", "ids", []string{"a|b"}, opts)
+	assert.ErrorContains(t, err, "contains the delimiter '|'")
+
+	// Values without delimiters, and exploded form values, which are
+	// separate query parameters, are fine.
+	result, err := StyleParamWithOptions("pipeDelimited", "ids", []string{"a,b", "c"}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "ids=a%2Cb|c", result)
+
+	opts.Explode = true
+	result, err = StyleParamWithOptions("form", "ids", []string{"a,b", "c"}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "ids=a%2Cb&ids=c", result)
+}
+
+func TestStyleParamHeader(t *testing.T) {
+	result, err := StyleParamWithLocation("simple", false, "X-Name", ParamLocationHeader, "Zoë \"Z\", 50%")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "Zo%C3%AB %22Z%22%2C 50%25", result)
+
+	result, err = StyleParamWithLocation("simple", false, "X-Name", ParamLocationHeader, " padded ")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "%20padded%20", result)
+
+	result, err = StyleParamWithLocation("simple", false, "X-Name", ParamLocationHeader, "line\r\nX-Injected: 1")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "line%0D%0AX-Injected: 1", result)
+
+	result, err = StyleParamWithLocation("simple", false, "X-Names", ParamLocationHeader, []string{"a,b", "c"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a%2Cb,c", result)
+}
+
+type stringerLevel int
+
+func (l stringerLevel) String() string {
+	return [...]string{"DEBUG", "INFO"}[l]
+}
+
+type stringerColor string
+
+func (c *stringerColor) String() string {
+	return strings.ToUpper(string(*c))
+}
+
+func TestStyleParamStringer(t *testing.T) {
+	result, err := StyleParamWithLocation("simple", false, "level", ParamLocationPath, stringerLevel(1))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "INFO", result)
+
+	result, err = StyleParamWithLocation("form", false, "levels", ParamLocationQuery, []stringerLevel{0, 1})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "levels=DEBUG,INFO", result)
+
+	color := stringerColor("red")
+	result, err = StyleParamWithLocation("form", true, "color", ParamLocationQuery, &color)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "color=RED", result)
+
+	type Object struct {
+		Level stringerLevel  `json:"level"`
+		Color *stringerColor `json:"color"`
+	}
+	object := Object{Level: 0, Color: &color}
+	result, err = StyleParamWithLocation("form", true, "object", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "color=RED&level=DEBUG", result)
+
+	result, err = StyleParamWithLocation("deepObject", true, "object", ParamLocationQuery, object)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "object[color]=RED&object[level]=DEBUG", result)
+}
+
+func TestStyleParamULIDs(t *testing.T) {
+	id, err := types.ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	styled, err := StyleParamWithLocation("simple", false, "ids", ParamLocationPath, []types.ULID{id, id})
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV,01ARZ3NDEKTSV4RRFFQ69G5FAV", styled)
+}
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// ReadTextBody reads the body of r, such as a text/plain body, transcoding
+// it to UTF-8 from the charset given by its Content-Type, such as
+// ISO-8859-1 or windows-1252. Charsets are named as in the WHATWG Encoding
+// standard, which browsers follow, so that ISO-8859-1 is read as
+// windows-1252, its superset. Bodies without a charset are taken as UTF-8.
+func ReadTextBody(r *http.Request) (string, error) {
+	enc, err := charsetEncoding(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", err
+	}
+	var body io.Reader = r.Body
+	if enc != nil {
+		body = enc.NewDecoder().Reader(body)
+	}
+	text, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("error reading text body: %w", err)
+	}
+	return string(text), nil
+}
+
+// BindTextBody reads the body of r as ReadTextBody does, and binds it to
+// dst as BindStringToObject does, so that strings, numbers and
+// encoding.TextUnmarshalers may be bound.
+func BindTextBody(r *http.Request, dst interface{}) error {
+	text, err := ReadTextBody(r)
+	if err != nil {
+		return err
+	}
+	return BindStringToObject(text, dst)
+}
+
+// charsetEncoding returns the encoding of the charset parameter of a
+// Content-Type, or nil when it's UTF-8 or isn't given.
+func charsetEncoding(contentType string) (encoding.Encoding, error) {
+	if contentType == "" {
+		return nil, nil
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Type '%s': %w", contentType, err)
+	}
+	charset := params["charset"]
+	if charset == "" {
+		return nil, nil
+	}
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset '%s'", charset)
+	}
+	if enc == unicode.UTF8 {
+		return nil, nil
+	}
+	return enc, nil
+}
+
+// charsetReader transcodes input to UTF-8 from the named charset, as
+// xml.Decoder.CharsetReader does.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset '%s'", charset)
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// decodeValues returns values with their names and values transcoded to
+// UTF-8 from enc.
+func decodeValues(values url.Values, enc encoding.Encoding) (url.Values, error) {
+	decoder := enc.NewDecoder()
+	decoded := make(url.Values, len(values))
+	for name, vs := range values {
+		decodedName, err := decoder.String(name)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding '%s': %w", name, err)
+		}
+		for _, v := range vs {
+			decodedValue, err := decoder.String(v)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding '%s': %w", name, err)
+			}
+			decoded[decodedName] = append(decoded[decodedName], decodedValue)
+		}
+	}
+	return decoded, nil
+}
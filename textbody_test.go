@@ -0,0 +1,49 @@
+package runtime
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBodyRequest returns a request with the given body and Content-Type,
+// for the tests of the body binders.
+func newBodyRequest[T string | []byte](contentType string, body T) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	r.Header.Set("Content-Type", contentType)
+	return r
+}
+
+func TestReadTextBody(t *testing.T) {
+	for _, tc := range []struct {
+		contentType, body, expected string
+	}{
+		{"text/plain", "naïve", "naïve"},
+		{"text/plain; charset=utf-8", "naïve", "naïve"},
+		{"text/plain; charset=ISO-8859-1", "na\xefve \x80", "naïve €"},
+		{"text/plain; charset=windows-1252", "na\xefve \x80", "naïve €"},
+		{"text/plain; charset=iso-8859-15", "\xa4", "€"},
+		{"", "plain", "plain"},
+	} {
+		text, err := ReadTextBody(newBodyRequest(tc.contentType, tc.body))
+		require.NoError(t, err, tc.contentType)
+		assert.Equal(t, tc.expected, text, tc.contentType)
+	}
+
+	_, err := ReadTextBody(newBodyRequest("text/plain; charset=klingon", "x"))
+	assert.EqualError(t, err, "unsupported charset 'klingon'")
+}
+
+func TestBindTextBody(t *testing.T) {
+	var note string
+	require.NoError(t, BindTextBody(newBodyRequest("text/plain; charset=latin1", "caf\xe9"), &note))
+	assert.Equal(t, "café", note)
+
+	var count int
+	require.NoError(t, BindTextBody(newBodyRequest("text/plain", "42"), &count))
+	assert.Equal(t, 42, count)
+}
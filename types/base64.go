@@ -0,0 +1,117 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Bytes is binary data which is encoded as standard base64, as used by
+// schemas with format: byte. Its values may be padded or not.
+type Bytes []byte
+
+// Base64URL is binary data which is encoded as base64 with the URL and file
+// name safe alphabet of RFC 4648, which is encoded without padding and
+// decoded with or without it.
+type Base64URL []byte
+
+// decodeBase64 decodes s, with or without its padding, with the unpadded
+// form of an encoding.
+func decodeBase64(enc *base64.Encoding, s string) ([]byte, error) {
+	return enc.DecodeString(strings.TrimRight(s, "="))
+}
+
+// Bytes returns the raw bytes.
+func (b Bytes) Bytes() []byte {
+	return []byte(b)
+}
+
+// String returns the base64 encoding of the bytes.
+func (b Bytes) String() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+func (b Bytes) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Bytes) UnmarshalText(data []byte) error {
+	decoded, err := decodeBase64(base64.RawStdEncoding, string(data))
+	if err != nil {
+		return fmt.Errorf("invalid base64 '%s': %w", data, err)
+	}
+	*b = decoded
+	return nil
+}
+
+// Bind implements runtime.Binder, so that parameters are decoded from
+// base64.
+func (b *Bytes) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return b.UnmarshalText([]byte(src))
+}
+
+// Bytes returns the raw bytes.
+func (b Base64URL) Bytes() []byte {
+	return []byte(b)
+}
+
+// String returns the unpadded base64url encoding of the bytes.
+func (b Base64URL) String() string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+func (b Base64URL) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Base64URL) UnmarshalText(data []byte) error {
+	decoded, err := decodeBase64(base64.RawURLEncoding, string(data))
+	if err != nil {
+		return fmt.Errorf("invalid base64url '%s': %w", data, err)
+	}
+	*b = decoded
+	return nil
+}
+
+// Bind implements runtime.Binder, so that parameters are decoded from
+// base64url.
+func (b *Base64URL) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return b.UnmarshalText([]byte(src))
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBytesJSON(t *testing.T) {
+	type upload struct {
+		Data Bytes     `json:"data"`
+		Sig  Base64URL `json:"sig"`
+	}
+	raw := []byte{0xfb, 0xff, 0x01}
+
+	data, err := json.Marshal(upload{Data: raw, Sig: raw})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":"+/8B","sig":"-_8B"}`, string(data))
+
+	var u upload
+	require.NoError(t, json.Unmarshal([]byte(`{"data":"+/8B","sig":"-_8B"}`), &u))
+	assert.Equal(t, raw, u.Data.Bytes())
+	assert.Equal(t, raw, u.Sig.Bytes())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"data":"-_8B"}`), &u))
+	assert.Error(t, json.Unmarshal([]byte(`{"sig":"+/8B"}`), &u))
+}
+
+func TestBytesPadding(t *testing.T) {
+	var b Bytes
+	require.NoError(t, b.UnmarshalText([]byte("aGk=")))
+	assert.Equal(t, "hi", string(b))
+	require.NoError(t, b.UnmarshalText([]byte("aGk")))
+	assert.Equal(t, "hi", string(b))
+	assert.Equal(t, "aGk=", b.String())
+
+	var u Base64URL
+	require.NoError(t, u.Bind("aGk="))
+	assert.Equal(t, "hi", string(u))
+	assert.Equal(t, "aGk", u.String())
+}
+
+func TestBytesBind(t *testing.T) {
+	var b Bytes
+	require.NoError(t, b.Bind("aGVsbG8="))
+	assert.Equal(t, "hello", string(b))
+	require.NoError(t, b.Bind(""))
+	assert.Equal(t, "hello", string(b))
+	assert.EqualError(t, b.Bind("not base64!"), "invalid base64 'not base64!': illegal base64 data at input byte 3")
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Binary is a binary body, as used by operations which accept or return
+// application/octet-stream and other non-structured media types. It's read
+// from its ReadCloser as it's needed, so that large payloads are streamed
+// rather than buffered in memory.
+type Binary struct {
+	io.ReadCloser
+	// Length is the length of the content in bytes, or -1 when it isn't
+	// known.
+	Length int64
+	// ContentType is the media type of the content, or "" when it isn't
+	// known.
+	ContentType string
+}
+
+// NewBinary returns a Binary which streams the content of r. The length is
+// -1 when it isn't known.
+func NewBinary(r io.ReadCloser, length int64, contentType string) Binary {
+	return Binary{ReadCloser: r, Length: length, ContentType: contentType}
+}
+
+// BinaryFromRequest returns a Binary which streams the body of r, with the
+// length and content type of its headers. The body is closed by the server,
+// as usual, so it's only valid until the handler returns.
+func BinaryFromRequest(r *http.Request) Binary {
+	body := r.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	return Binary{
+		ReadCloser:  body,
+		Length:      r.ContentLength,
+		ContentType: r.Header.Get("Content-Type"),
+	}
+}
+
+// WriteResponse writes the content as the body of a response with the
+// status code, after setting its Content-Type, which defaults to
+// application/octet-stream, and its Content-Length, when it's known. The
+// content is closed once it's written.
+func (b Binary) WriteResponse(w http.ResponseWriter, statusCode int) error {
+	defer func() { _ = b.Close() }()
+	contentType := b.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	if b.Length >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(b.Length, 10))
+	}
+	w.WriteHeader(statusCode)
+	_, err := io.Copy(w, b.ReadCloser)
+	return err
+}
+
+// Close closes the content, if there's any.
+func (b Binary) Close() error {
+	if b.ReadCloser == nil {
+		return nil
+	}
+	return b.ReadCloser.Close()
+}
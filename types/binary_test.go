@@ -0,0 +1,57 @@
+package types
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestBinaryFromRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/avatar", strings.NewReader("\x89PNG"))
+	r.Header.Set("Content-Type", "image/png")
+
+	b := BinaryFromRequest(r)
+	assert.Equal(t, int64(4), b.Length)
+	assert.Equal(t, "image/png", b.ContentType)
+	data, err := io.ReadAll(b)
+	require.NoError(t, err)
+	assert.Equal(t, "\x89PNG", string(data))
+
+	b = BinaryFromRequest(&http.Request{Header: http.Header{}})
+	data, err = io.ReadAll(b)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestBinaryWriteResponse(t *testing.T) {
+	body := &closeRecorder{Reader: strings.NewReader("hello")}
+	w := httptest.NewRecorder()
+	require.NoError(t, NewBinary(body, 5, "").WriteResponse(w, http.StatusOK))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "5", w.Header().Get("Content-Length"))
+	assert.Equal(t, "hello", w.Body.String())
+	assert.True(t, body.closed)
+
+	w = httptest.NewRecorder()
+	require.NoError(t, NewBinary(io.NopCloser(strings.NewReader("{}")), -1, "application/pdf").
+		WriteResponse(w, http.StatusCreated))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"unicode"
+)
+
+// ContentDisposition is a parsed Content-Disposition header, as sent with
+// multipart parts and file downloads.
+type ContentDisposition struct {
+	// Type is the disposition type, in lower case, such as "form-data",
+	// "attachment" or "inline".
+	Type string
+	// Name is the name of a form-data part.
+	Name string
+	// Filename is the sanitized file name, which is "" when none was given
+	// or nothing is left of it once it's sanitized.
+	Filename string
+	// Params are all the parameters of the header, by their lower case
+	// names, with extended parameters such as filename* decoded under their
+	// plain names.
+	Params map[string]string
+}
+
+// ParseContentDisposition parses a Content-Disposition header, following
+// RFC 6266. The extended filename* parameter of RFC 8187, which gives the
+// charset and percent-encoded bytes of the name, takes precedence over
+// filename, and the file name is sanitized by SanitizeFilename.
+func ParseContentDisposition(s string) (ContentDisposition, error) {
+	dispositionType, params, err := mime.ParseMediaType(s)
+	if err != nil {
+		return ContentDisposition{}, fmt.Errorf("invalid Content-Disposition '%s': %w", s, err)
+	}
+	return ContentDisposition{
+		Type:     dispositionType,
+		Name:     params["name"],
+		Filename: SanitizeFilename(params["filename"]),
+		Params:   params,
+	}, nil
+}
+
+// SanitizeFilename returns the last element of a file name given by a
+// client, with either slashes or backslashes as separators, so that it
+// can't escape the directory it's saved in. Control characters are removed,
+// invalid UTF-8 is replaced, and names which are only "." or ".." are
+// dropped, returning "".
+func SanitizeFilename(name string) string {
+	name = strings.ToValidUTF8(name, "�")
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseContentDisposition(t *testing.T) {
+	cd, err := ParseContentDisposition(`form-data; name="avatar"; filename="me.png"`)
+	require.NoError(t, err)
+	assert.Equal(t, ContentDisposition{
+		Type:     "form-data",
+		Name:     "avatar",
+		Filename: "me.png",
+		Params:   map[string]string{"name": "avatar", "filename": "me.png"},
+	}, cd)
+
+	cd, err = ParseContentDisposition(`Attachment; filename="naive.txt"; filename*=UTF-8''na%C3%AFve%20r%C3%A9sum%C3%A9.txt`)
+	require.NoError(t, err)
+	assert.Equal(t, "attachment", cd.Type)
+	assert.Equal(t, "naïve résumé.txt", cd.Filename)
+
+	cd, err = ParseContentDisposition(`attachment; filename="..\\..\\windows\\system.ini"`)
+	require.NoError(t, err)
+	assert.Equal(t, "system.ini", cd.Filename)
+
+	cd, err = ParseContentDisposition(`inline`)
+	require.NoError(t, err)
+	assert.Equal(t, "", cd.Filename)
+
+	_, err = ParseContentDisposition(`attachment; filename`)
+	assert.ErrorContains(t, err, "invalid Content-Disposition 'attachment; filename'")
+}
+
+func TestSanitizeFilename(t *testing.T) {
+	for name, expected := range map[string]string{
+		"report.pdf":               "report.pdf",
+		"../../etc/passwd":         "passwd",
+		`C:\Users\me\photo.jpg`:    "photo.jpg",
+		"dir/":                     "",
+		"..":                       "",
+		"a/..":                     "",
+		"bad\x00name\r\n.txt":      "badname.txt",
+		" spaced .txt ":            "spaced .txt",
+		"caf\xe9.txt":              "caf\uFFFD.txt",
+		".hidden":                  ".hidden",
+		"résumé.txt":               "résumé.txt",
+		"../evil\u0085/x\u200b.sh": "x\u200b.sh",
+	} {
+		assert.Equal(t, expected, SanitizeFilename(name), name)
+	}
+}
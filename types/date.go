@@ -1,7 +1,11 @@
 package types
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -21,11 +25,11 @@ func (d *Date) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	parsed, err := time.Parse(DateFormat, dateStr)
+	parsed, err := ParseDate(dateStr, DefaultDateOptions())
 	if err != nil {
 		return err
 	}
-	d.Time = parsed
+	*d = parsed
 	return nil
 }
 
@@ -34,10 +38,155 @@ func (d Date) String() string {
 }
 
 func (d *Date) UnmarshalText(data []byte) error {
-	parsed, err := time.Parse(DateFormat, string(data))
+	parsed, err := ParseDate(string(data), DefaultDateOptions())
 	if err != nil {
 		return err
 	}
+	*d = parsed
+	return nil
+}
+
+// DateOptions are the options of ParseDate, which may also be set as the
+// default of the package with SetDefaultDateOptions.
+type DateOptions struct {
+	// Location is the time zone of the midnight of parsed dates, and the zone
+	// whose date is kept when date-times are truncated. It's UTC when it's
+	// nil; time.Local makes it the local time zone.
+	Location *time.Location
+	// TruncateTime accepts RFC 3339 date-times, such as
+	// "2022-06-14T23:30:00+02:00", and keeps the date they fall on in
+	// Location, rather than rejecting them.
+	TruncateTime bool
+}
+
+var defaultDateOptions atomic.Pointer[DateOptions]
+
+// SetDefaultDateOptions sets the options with which dates are parsed when
+// they're unmarshaled from JSON or text, or bound from parameters. It's
+// meant to be called once, as the program starts.
+func SetDefaultDateOptions(opts DateOptions) {
+	defaultDateOptions.Store(&opts)
+}
+
+// DefaultDateOptions returns the options set by SetDefaultDateOptions, which
+// are the zero DateOptions until it's called.
+func DefaultDateOptions() DateOptions {
+	if opts := defaultDateOptions.Load(); opts != nil {
+		return *opts
+	}
+	return DateOptions{}
+}
+
+// ParseDate parses a date in DateFormat given the options.
+func ParseDate(s string, opts DateOptions) (Date, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	parsed, err := time.ParseInLocation(DateFormat, s, loc)
+	if err == nil {
+		return Date{parsed}, nil
+	}
+	if !opts.TruncateTime {
+		return Date{}, err
+	}
+	parsed, dateTimeErr := time.Parse(time.RFC3339Nano, s)
+	if dateTimeErr != nil {
+		return Date{}, err
+	}
+	parsed = parsed.In(loc)
+	return Date{time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, loc)}, nil
+}
+
+// NewDate returns the Date of the given year, month and day, normalized as
+// time.Date normalizes them, so that January 32 is February 1.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today returns the current date in the local time zone.
+func Today() Date {
+	return DateOf(time.Now())
+}
+
+// DateOf returns the date of t, as it reads in the location of t.
+func DateOf(t time.Time) Date {
+	return NewDate(t.Year(), t.Month(), t.Day())
+}
+
+// AddDays returns the date n days after d, or before it when n is negative.
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year(), d.Month(), d.Day()+n)
+}
+
+// Sub returns the number of days from other to d, which is negative when d
+// is before other.
+func (d Date) Sub(other Date) int {
+	// Both dates are at midnight UTC, so every day is exactly 24 hours.
+	return int(d.midnight().Sub(other.midnight()) / (24 * time.Hour))
+}
+
+// Before reports whether d is a day before other. Unlike the methods of
+// time.Time, the comparisons of dates ignore the time of day and location.
+func (d Date) Before(other Date) bool {
+	return d.midnight().Before(other.midnight())
+}
+
+// After reports whether d is a day after other.
+func (d Date) After(other Date) bool {
+	return d.midnight().After(other.midnight())
+}
+
+// Equal reports whether d and other are the same day.
+func (d Date) Equal(other Date) bool {
+	return d.midnight().Equal(other.midnight())
+}
+
+// StartOfMonth returns the first day of the month of d.
+func (d Date) StartOfMonth() Date {
+	return NewDate(d.Year(), d.Month(), 1)
+}
+
+// midnight returns the start of the day of d in UTC.
+func (d Date) midnight() time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Scan implements sql.Scanner, so that a Date can be read from a DATE
+// column. Drivers may return the column as a time.Time, whose date is kept
+// as it reads in its own location, or as a string or bytes in DateFormat. A
+// NULL column can't be scanned into a Date; scan it into a *Date instead.
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	case nil:
+		return errors.New("cannot scan NULL into a Date")
+	default:
+		return fmt.Errorf("cannot scan %T into a Date", src)
+	}
+}
+
+func (d *Date) scanString(s string) error {
+	// Some drivers return DATE columns as timestamps.
+	if len(s) > len(DateFormat) {
+		s = s[:len(DateFormat)]
+	}
+	parsed, err := time.Parse(DateFormat, s)
+	if err != nil {
+		return fmt.Errorf("cannot scan '%s' into a Date: %w", s, err)
+	}
 	d.Time = parsed
 	return nil
 }
+
+// Value implements driver.Valuer, so that a Date can be written to a DATE
+// column. It's passed to the driver as a time.Time at midnight UTC.
+func (d Date) Value() (driver.Value, error) {
+	return d.midnight(), nil
+}
@@ -63,3 +63,96 @@ func TestDate_UnmarshalText(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testDate, date.Time)
 }
+
+func TestDate_Scan(t *testing.T) {
+	testDate := time.Date(2022, 6, 14, 0, 0, 0, 0, time.UTC)
+	local := time.FixedZone("UTC+10", 10*60*60)
+
+	for _, src := range []interface{}{
+		time.Date(2022, 6, 14, 0, 0, 0, 0, local),
+		"2022-06-14",
+		[]byte("2022-06-14"),
+		"2022-06-14T00:00:00Z",
+	} {
+		var d Date
+		assert.NoError(t, d.Scan(src))
+		assert.Equal(t, testDate, d.Time)
+	}
+
+	var d Date
+	assert.EqualError(t, d.Scan(nil), "cannot scan NULL into a Date")
+	assert.EqualError(t, d.Scan(int64(1)), "cannot scan int64 into a Date")
+	assert.Error(t, d.Scan("14/06/2022"))
+}
+
+func TestDate_Value(t *testing.T) {
+	local := time.FixedZone("UTC+10", 10*60*60)
+	d := Date{time.Date(2022, 6, 14, 23, 30, 0, 0, local)}
+	v, err := d.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 14, 0, 0, 0, 0, time.UTC), v)
+}
+
+func TestDate_Arithmetic(t *testing.T) {
+	d := NewDate(2024, time.February, 28)
+	assert.Equal(t, NewDate(2024, time.February, 29), d.AddDays(1))
+	assert.Equal(t, NewDate(2024, time.March, 1), d.AddDays(2))
+	assert.Equal(t, NewDate(2023, time.December, 31), d.AddDays(-59))
+	assert.Equal(t, NewDate(2024, time.February, 1), d.StartOfMonth())
+
+	assert.Equal(t, 2, NewDate(2024, time.March, 1).Sub(d))
+	assert.Equal(t, -365, NewDate(2023, time.February, 28).Sub(d))
+	assert.Equal(t, 0, d.Sub(d))
+
+	// Comparisons ignore the time of day and location.
+	local := time.FixedZone("UTC+10", 10*60*60)
+	late := Date{time.Date(2024, time.February, 28, 23, 0, 0, 0, local)}
+	assert.True(t, late.Equal(d))
+	assert.False(t, late.Before(d))
+	assert.False(t, late.After(d))
+	assert.True(t, d.Before(d.AddDays(1)))
+	assert.True(t, d.After(d.AddDays(-1)))
+
+	now := time.Now()
+	assert.Equal(t, NewDate(now.Year(), now.Month(), now.Day()), Today())
+}
+
+func TestParseDate(t *testing.T) {
+	d, err := ParseDate("2022-06-14", DateOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 14, 0, 0, 0, 0, time.UTC), d.Time)
+
+	_, err = ParseDate("2022-06-14T23:30:00+02:00", DateOptions{})
+	assert.Error(t, err)
+
+	// Date-times are truncated to the date they fall on in the location.
+	d, err = ParseDate("2022-06-14T23:30:00-02:00", DateOptions{TruncateTime: true})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC), d.Time)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	d, err = ParseDate("2022-06-14T20:00:00Z", DateOptions{Location: tokyo, TruncateTime: true})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 6, 15, 0, 0, 0, 0, tokyo), d.Time)
+	assert.Equal(t, "2022-06-15", d.String())
+
+	d, err = ParseDate("2022-06-14", DateOptions{Location: tokyo})
+	assert.NoError(t, err)
+	assert.Equal(t, tokyo, d.Location())
+
+	_, err = ParseDate("14/06/2022", DateOptions{TruncateTime: true})
+	assert.Error(t, err)
+}
+
+func TestSetDefaultDateOptions(t *testing.T) {
+	t.Cleanup(func() { SetDefaultDateOptions(DateOptions{}) })
+
+	var d Date
+	assert.Error(t, json.Unmarshal([]byte(`"2022-06-14T23:30:00Z"`), &d))
+
+	SetDefaultDateOptions(DateOptions{TruncateTime: true})
+	assert.NoError(t, json.Unmarshal([]byte(`"2022-06-14T23:30:00Z"`), &d))
+	assert.Equal(t, time.Date(2022, 6, 14, 0, 0, 0, 0, time.UTC), d.Time)
+	assert.NoError(t, d.UnmarshalText([]byte("2022-06-15T01:00:00+02:00")))
+	assert.Equal(t, "2022-06-14", d.String())
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+var decimalRegex = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+
+// Decimal is an exact decimal number, for money and quantities which can't
+// suffer the rounding of float64. It keeps the digits it was parsed from, so
+// that 1.50 is marshaled as 1.50, rather than 1.5. The zero value is 0.
+type Decimal struct {
+	s string
+}
+
+// ParseDecimal parses a decimal number, such as "19.99", "-0.5" or "1e-3".
+func ParseDecimal(s string) (Decimal, error) {
+	if !decimalRegex.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal '%s'", s)
+	}
+	// Normalize to a valid JSON number.
+	input := s
+	s = strings.TrimPrefix(s, "+")
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	// Leading zeros are redundant, and invalid in JSON.
+	if trimmed := strings.TrimLeft(s, "0"); len(trimmed) < len(s) {
+		s = trimmed
+		if s == "" || !isDigit(s[0]) {
+			s = "0" + s
+		}
+	}
+	if strings.HasPrefix(s, ".") {
+		s = "0" + s
+	}
+	s = strings.Replace(s, ".e", "e", 1)
+	s = strings.Replace(s, ".E", "E", 1)
+	s = strings.TrimSuffix(s, ".")
+	if neg {
+		s = "-" + s
+	}
+	// Exponents too large for big.Rat are rejected, so that every Decimal
+	// has a value.
+	if _, ok := new(big.Rat).SetString(s); !ok {
+		return Decimal{}, fmt.Errorf("decimal '%s' is out of range", input)
+	}
+	return Decimal{s: s}, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// NewDecimalFromRat returns the decimal value of r, rounded to prec digits
+// after the decimal point.
+func NewDecimalFromRat(r *big.Rat, prec int) Decimal {
+	return Decimal{s: r.FloatString(prec)}
+}
+
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// Rat returns the exact value of the decimal.
+func (d Decimal) Rat() *big.Rat {
+	r, ok := new(big.Rat).SetString(d.String())
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// Float64 returns the float64 nearest to the decimal, and whether it's
+// exact.
+func (d Decimal) Float64() (float64, bool) {
+	return d.Rat().Float64()
+}
+
+// Cmp compares the values of d and e, regardless of their representation,
+// returning -1 if d is less than e, +1 if it's greater, and 0 if they're
+// equal.
+func (d Decimal) Cmp(e Decimal) int {
+	return d.Rat().Cmp(e.Rat())
+}
+
+// MarshalJSON marshals the decimal as a JSON number with the digits it was
+// parsed from.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON unmarshals a JSON number, or a string holding one, as some
+// APIs send decimals, without rounding it.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return d.UnmarshalText(data)
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for input, expected := range map[string]string{
+		"19.99":  "19.99",
+		"1.50":   "1.50",
+		"-0.5":   "-0.5",
+		"+7":     "7",
+		".25":    "0.25",
+		"-.25":   "-0.25",
+		"3.":     "3",
+		"1e-3":   "1e-3",
+		"2.E5":   "2E5",
+		"100.00": "100.00",
+		"007":    "7",
+		"00.5":   "0.5",
+		"-00":    "-0",
+		"000e2":  "0e2",
+	} {
+		d, err := ParseDecimal(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, d.String(), input)
+	}
+
+	for _, invalid := range []string{"", "abc", "1/3", "0x10", "1.2.3", "NaN", "1e", "."} {
+		_, err := ParseDecimal(invalid)
+		assert.EqualError(t, err, "invalid decimal '"+invalid+"'")
+	}
+
+	_, err := ParseDecimal("1e999999999999")
+	assert.EqualError(t, err, "decimal '1e999999999999' is out of range")
+
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, 0, Decimal{}.Rat().Sign())
+
+	d, err := ParseDecimal("007")
+	require.NoError(t, err)
+	data, err := json.Marshal(struct {
+		Qty Decimal `json:"qty"`
+	}{d})
+	require.NoError(t, err)
+	assert.Equal(t, `{"qty":7}`, string(data))
+}
+
+func TestDecimalValue(t *testing.T) {
+	a, err := ParseDecimal("0.10")
+	require.NoError(t, err)
+	b, err := ParseDecimal("0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.Equal(t, big.NewRat(1, 10), a.Rat())
+
+	c, err := ParseDecimal("0.3")
+	require.NoError(t, err)
+	assert.Equal(t, -1, a.Cmp(c))
+	assert.Equal(t, 1, c.Cmp(Decimal{}))
+
+	f, exact := c.Float64()
+	assert.Equal(t, 0.3, f)
+	assert.False(t, exact)
+
+	assert.Equal(t, "0.33", NewDecimalFromRat(big.NewRat(1, 3), 2).String())
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var o struct {
+		Price  Decimal  `json:"price"`
+		Amount Decimal  `json:"amount"`
+		Tax    *Decimal `json:"tax"`
+	}
+	// Digits beyond the precision of float64 are kept.
+	input := `{"price":12345678901234567890.10,"amount":"1.50","tax":null}`
+	require.NoError(t, json.Unmarshal([]byte(input), &o))
+	assert.Equal(t, "12345678901234567890.10", o.Price.String())
+	assert.Equal(t, "1.50", o.Amount.String())
+	assert.Nil(t, o.Tax)
+
+	out, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.Equal(t, `{"price":12345678901234567890.10,"amount":1.50,"tax":null}`, string(out))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"price":"ten"}`), &o))
+	assert.Error(t, json.Unmarshal([]byte(`{"price":true}`), &o))
+}
@@ -0,0 +1,202 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration is an ISO 8601 duration, such as "P3DT4H", as used by schemas
+// with format: duration. Its components are kept as they're given, since
+// years, months and, across daylight saving changes, days have no fixed
+// length.
+type Duration struct {
+	Negative bool
+	Years    int
+	Months   int
+	Weeks    int
+	Days     int
+	Hours    int
+	Minutes  int
+	// Seconds may have a fractional part, as in "PT1.5S".
+	Seconds float64
+}
+
+// NewDuration returns the Duration of d in hours, minutes and seconds.
+func NewDuration(d time.Duration) Duration {
+	var dur Duration
+	if d < 0 {
+		dur.Negative = true
+		d = -d
+	}
+	dur.Hours = int(d / time.Hour)
+	dur.Minutes = int(d % time.Hour / time.Minute)
+	dur.Seconds = float64(d%time.Minute) / float64(time.Second)
+	return dur
+}
+
+// ParseDuration parses an ISO 8601 duration, such as "P1Y2M3DT4H5M6.5S",
+// "P2W" or "-PT30M". The decimal sign of fractional seconds may be a period
+// or a comma.
+func ParseDuration(s string) (Duration, error) {
+	var d Duration
+	invalid := fmt.Errorf("invalid ISO 8601 duration '%s'", s)
+
+	rest := s
+	if strings.HasPrefix(rest, "-") {
+		d.Negative = true
+		rest = rest[1:]
+	} else {
+		rest = strings.TrimPrefix(rest, "+")
+	}
+	rest, found := strings.CutPrefix(rest, "P")
+	if !found {
+		return Duration{}, invalid
+	}
+	date, clock, hasClock := strings.Cut(rest, "T")
+	if (date == "" && !hasClock) || (hasClock && clock == "") {
+		return Duration{}, invalid
+	}
+	if err := parseDurationComponents(date, "YMWD", func(unit byte, value string) error {
+		n, err := strconv.Atoi(value)
+		switch unit {
+		case 'Y':
+			d.Years = n
+		case 'M':
+			d.Months = n
+		case 'W':
+			d.Weeks = n
+		case 'D':
+			d.Days = n
+		}
+		return err
+	}); err != nil {
+		return Duration{}, invalid
+	}
+	if err := parseDurationComponents(clock, "HMS", func(unit byte, value string) error {
+		if unit == 'S' {
+			var err error
+			d.Seconds, err = strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+			return err
+		}
+		n, err := strconv.Atoi(value)
+		if unit == 'H' {
+			d.Hours = n
+		} else {
+			d.Minutes = n
+		}
+		return err
+	}); err != nil {
+		return Duration{}, invalid
+	}
+	return d, nil
+}
+
+// parseDurationComponents splits s into numbers followed by their unit
+// designators, which must be among units, in that order, and calls set for
+// each. Only seconds may have a fractional part.
+func parseDurationComponents(s string, units string, set func(unit byte, value string) error) error {
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if i <= 0 {
+			return errors.New("missing number or unit")
+		}
+		value, unit := s[:i], s[i]
+		next := strings.IndexByte(units, unit)
+		if next < 0 || (unit != 'S' && strings.ContainsAny(value, ".,")) {
+			return errors.New("invalid unit")
+		}
+		if err := set(unit, value); err != nil {
+			return err
+		}
+		units, s = units[next+1:], s[i+1:]
+	}
+	return nil
+}
+
+// String formats the duration in ISO 8601, omitting the components which
+// are zero, with "PT0S" for a zero duration.
+func (d Duration) String() string {
+	var b strings.Builder
+	for _, c := range []struct {
+		n    int
+		unit byte
+	}{{d.Years, 'Y'}, {d.Months, 'M'}, {d.Weeks, 'W'}, {d.Days, 'D'}} {
+		if c.n != 0 {
+			b.WriteString(strconv.Itoa(c.n))
+			b.WriteByte(c.unit)
+		}
+	}
+	if d.Hours != 0 || d.Minutes != 0 || d.Seconds != 0 {
+		b.WriteByte('T')
+		for _, c := range []struct {
+			n    int
+			unit byte
+		}{{d.Hours, 'H'}, {d.Minutes, 'M'}} {
+			if c.n != 0 {
+				b.WriteString(strconv.Itoa(c.n))
+				b.WriteByte(c.unit)
+			}
+		}
+		if d.Seconds != 0 {
+			b.WriteString(strconv.FormatFloat(d.Seconds, 'f', -1, 64))
+			b.WriteByte('S')
+		}
+	}
+	if b.Len() == 0 {
+		return "PT0S"
+	}
+	if d.Negative {
+		return "-P" + b.String()
+	}
+	return "P" + b.String()
+}
+
+// TimeDuration converts the duration to a time.Duration, taking weeks as 7
+// days, and days as 24 hours. Durations with years or months, which have no
+// fixed length, and those which overflow time.Duration, can't be converted.
+func (d Duration) TimeDuration() (time.Duration, error) {
+	if d.Years != 0 || d.Months != 0 {
+		return 0, fmt.Errorf("duration '%s' has years or months, which have no fixed length", d)
+	}
+	seconds := (float64(d.Weeks)*7*24+float64(d.Days)*24+float64(d.Hours))*3600 + float64(d.Minutes)*60 + d.Seconds
+	if seconds*float64(time.Second) >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration '%s' overflows time.Duration", d)
+	}
+	td := time.Duration(math.Round(seconds * float64(time.Second)))
+	if d.Negative {
+		td = -td
+	}
+	return td, nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(data []byte) error {
+	parsed, err := ParseDuration(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
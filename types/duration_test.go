@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Duration
+		str      string
+	}{
+		{"P3DT4H", Duration{Days: 3, Hours: 4}, "P3DT4H"},
+		{"P1Y2M3DT4H5M6.5S", Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6.5}, "P1Y2M3DT4H5M6.5S"},
+		{"P2W", Duration{Weeks: 2}, "P2W"},
+		{"PT1M", Duration{Minutes: 1}, "PT1M"},
+		{"P1M", Duration{Months: 1}, "P1M"},
+		{"-PT30M", Duration{Negative: true, Minutes: 30}, "-PT30M"},
+		{"+PT0,25S", Duration{Seconds: 0.25}, "PT0.25S"},
+		{"PT0S", Duration{}, "PT0S"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			d, err := ParseDuration(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, d)
+			assert.Equal(t, tc.str, d.String())
+		})
+	}
+
+	for _, invalid := range []string{"", "P", "PT", "3D", "P3", "PD", "P1H", "PT1D", "P1D2Y", "P1.5D", "P1DT1S1M", "P1DD"} {
+		_, err := ParseDuration(invalid)
+		assert.EqualError(t, err, "invalid ISO 8601 duration '"+invalid+"'")
+	}
+}
+
+func TestDurationTimeDuration(t *testing.T) {
+	d, err := ParseDuration("P1W1DT1H1M1.5S")
+	require.NoError(t, err)
+	td, err := d.TimeDuration()
+	require.NoError(t, err)
+	assert.Equal(t, 8*24*time.Hour+time.Hour+time.Minute+1500*time.Millisecond, td)
+
+	td, err = Duration{Negative: true, Minutes: 30}.TimeDuration()
+	require.NoError(t, err)
+	assert.Equal(t, -30*time.Minute, td)
+
+	_, err = Duration{Months: 1}.TimeDuration()
+	assert.EqualError(t, err, "duration 'P1M' has years or months, which have no fixed length")
+	_, err = Duration{Weeks: 1 << 40}.TimeDuration()
+	assert.Error(t, err)
+
+	assert.Equal(t, Duration{Hours: 26, Minutes: 3, Seconds: 4.1}, NewDuration(26*time.Hour+3*time.Minute+4100*time.Millisecond))
+	assert.Equal(t, "-PT1.5S", NewDuration(-1500*time.Millisecond).String())
+}
+
+func TestDurationJSON(t *testing.T) {
+	var o struct {
+		Timeout Duration `json:"timeout"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"timeout":"PT1H30M"}`), &o))
+	assert.Equal(t, Duration{Hours: 1, Minutes: 30}, o.Timeout)
+
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"timeout":"PT1H30M"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"timeout":"90 minutes"}`), &o))
+}
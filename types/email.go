@@ -3,6 +3,10 @@ package types
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode"
 )
 
 // ErrValidationEmail is the sentinel error returned when an email fails validation
@@ -38,3 +42,152 @@ func (e *Email) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// EmailValidation is how strictly ParseEmail validates an email address.
+type EmailValidation int
+
+const (
+	// EmailValidationDefault validates an address with the regular
+	// expression which Email's JSON methods use.
+	EmailValidationDefault EmailValidation = iota
+	// EmailValidationPermissive only requires a single '@' between a
+	// non-empty local part and domain, without any whitespace.
+	EmailValidationPermissive
+	// EmailValidationStrict validates an address as the mailbox of RFC 5321:
+	// an ASCII dot-atom or quoted local part of at most 64 characters, and a
+	// domain which is a host name or an address literal, in at most 254
+	// characters.
+	EmailValidationStrict
+)
+
+// EmailOptions are the options of ParseEmail.
+type EmailOptions struct {
+	Validation EmailValidation
+	// LowercaseDomain lowercases the domain of the address, which is case
+	// insensitive, unlike its local part.
+	LowercaseDomain bool
+	// CheckDomain, when it's set, is called with the domain of a valid
+	// address, so that it may check that mail can be delivered to it, such
+	// as by looking up its MX records:
+	//
+	//	CheckDomain: func(domain string) error {
+	//		_, err := net.LookupMX(domain)
+	//		return err
+	//	}
+	//
+	// Its errors fail the validation.
+	CheckDomain func(domain string) error
+}
+
+// ParseEmail validates and normalizes an email address given the options.
+// Its errors wrap ErrValidationEmail.
+func ParseEmail(s string, opts EmailOptions) (Email, error) {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 || at == len(s)-1 {
+		return "", fmt.Errorf("%w: '%s' isn't of the form local@domain", ErrValidationEmail, s)
+	}
+	local, domain := s[:at], s[at+1:]
+
+	switch opts.Validation {
+	case EmailValidationDefault:
+		if !emailRegex.MatchString(s) {
+			return "", fmt.Errorf("%w: '%s'", ErrValidationEmail, s)
+		}
+	case EmailValidationPermissive:
+		if strings.IndexByte(local, '@') >= 0 || strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+			return "", fmt.Errorf("%w: '%s' has whitespace or more than one '@'", ErrValidationEmail, s)
+		}
+	case EmailValidationStrict:
+		if err := validateStrictEmail(local, domain); err != nil {
+			return "", fmt.Errorf("%w: '%s' %v", ErrValidationEmail, s, err)
+		}
+	default:
+		return "", fmt.Errorf("unknown email validation %d", opts.Validation)
+	}
+
+	if opts.LowercaseDomain {
+		domain = strings.ToLower(domain)
+	}
+	if opts.CheckDomain != nil {
+		if err := opts.CheckDomain(domain); err != nil {
+			return "", fmt.Errorf("%w: '%s' domain check failed: %v", ErrValidationEmail, s, err)
+		}
+	}
+	return Email(local + "@" + domain), nil
+}
+
+// validateStrictEmail validates the local part and domain of an address as
+// RFC 5321 does.
+func validateStrictEmail(local, domain string) error {
+	if len(local)+1+len(domain) > 254 {
+		return errors.New("is longer than 254 characters")
+	}
+	if len(local) > 64 {
+		return errors.New("has a local part longer than 64 characters")
+	}
+	if strings.HasPrefix(local, `"`) {
+		if !isQuotedLocalPart(local) {
+			return errors.New("has an invalid quoted local part")
+		}
+	} else {
+		for _, atom := range strings.Split(local, ".") {
+			if atom == "" || strings.IndexFunc(atom, func(c rune) bool { return !isAtext(c) }) >= 0 {
+				return errors.New("has an invalid local part")
+			}
+		}
+	}
+
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		literal := domain[1 : len(domain)-1]
+		if ipv6, ok := cutPrefixFold(literal, "IPv6:"); ok {
+			if addr, err := netip.ParseAddr(ipv6); err == nil && addr.Is6() && addr.Zone() == "" {
+				return nil
+			}
+		} else if addr, err := netip.ParseAddr(literal); err == nil && addr.Is4() {
+			return nil
+		}
+		return errors.New("has an invalid address literal")
+	}
+	if strings.HasSuffix(domain, ".") {
+		return errors.New("has a domain with a trailing dot")
+	}
+	if _, err := ParseHostname(domain); err != nil {
+		return errors.New("has an invalid domain")
+	}
+	return nil
+}
+
+// isAtext reports whether c may be used in an atom, as RFC 5322 defines.
+func isAtext(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", c)
+}
+
+// isQuotedLocalPart reports whether s is a quoted string of printable ASCII
+// characters, where quotes and backslashes are escaped by a backslash.
+func isQuotedLocalPart(s string) bool {
+	if len(s) < 2 || s[len(s)-1] != '"' {
+		return false
+	}
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			i++
+			if i == len(s)-1 || s[i] < ' ' || s[i] > '~' {
+				return false
+			}
+		case c == '"' || c < ' ' || c > '~':
+			return false
+		}
+	}
+	return true
+}
+
+// cutPrefixFold is strings.CutPrefix, ignoring case.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
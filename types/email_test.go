@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,3 +176,95 @@ func TestEmail_UnmarshalJSON_NullableEmail_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestParseEmail(t *testing.T) {
+	testCases := map[string]struct {
+		email    string
+		opts     EmailOptions
+		expected Email
+		valid    bool
+	}{
+		"default accepts a valid address": {
+			email: "gaben@valvesoftware.com", expected: "gaben@valvesoftware.com", valid: true,
+		},
+		"default rejects an address without a domain": {
+			email: "gaben@",
+		},
+		"permissive accepts an address the regex rejects": {
+			email: "gaben@localhost", opts: EmailOptions{Validation: EmailValidationPermissive},
+			expected: "gaben@localhost", valid: true,
+		},
+		"permissive rejects whitespace": {
+			email: "gabe n@valve", opts: EmailOptions{Validation: EmailValidationPermissive},
+		},
+		"permissive rejects two @": {
+			email: "a@b@valve", opts: EmailOptions{Validation: EmailValidationPermissive},
+		},
+		"strict accepts a dot-atom": {
+			email: "gabe.newell+steam@valvesoftware.com", opts: EmailOptions{Validation: EmailValidationStrict},
+			expected: "gabe.newell+steam@valvesoftware.com", valid: true,
+		},
+		"strict accepts a quoted local part": {
+			email: `"gabe \"n\""@valvesoftware.com`, opts: EmailOptions{Validation: EmailValidationStrict},
+			expected: `"gabe \"n\""@valvesoftware.com`, valid: true,
+		},
+		"strict accepts address literals": {
+			email: "gaben@[IPv6:2001:db8::1]", opts: EmailOptions{Validation: EmailValidationStrict},
+			expected: "gaben@[IPv6:2001:db8::1]", valid: true,
+		},
+		"strict rejects consecutive dots": {
+			email: "gabe..n@valvesoftware.com", opts: EmailOptions{Validation: EmailValidationStrict},
+		},
+		"strict rejects non-ASCII local parts": {
+			email: "gäben@valvesoftware.com", opts: EmailOptions{Validation: EmailValidationStrict},
+		},
+		"strict rejects a long local part": {
+			email: strings.Repeat("a", 65) + "@valvesoftware.com", opts: EmailOptions{Validation: EmailValidationStrict},
+		},
+		"strict rejects a trailing dot": {
+			email: "gaben@valvesoftware.com.", opts: EmailOptions{Validation: EmailValidationStrict},
+		},
+		"strict rejects an invalid address literal": {
+			email: "gaben@[300.1.1.1]", opts: EmailOptions{Validation: EmailValidationStrict},
+		},
+		"the domain is lowercased": {
+			email: "GabeN@ValveSoftware.COM", opts: EmailOptions{LowercaseDomain: true},
+			expected: "GabeN@valvesoftware.com", valid: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			email, err := ParseEmail(tc.email, tc.opts)
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, email)
+			} else {
+				assert.ErrorIs(t, err, ErrValidationEmail)
+			}
+		})
+	}
+}
+
+func TestParseEmailCheckDomain(t *testing.T) {
+	var checked string
+	opts := EmailOptions{
+		LowercaseDomain: true,
+		CheckDomain: func(domain string) error {
+			checked = domain
+			if domain != "valvesoftware.com" {
+				return errors.New("no MX records")
+			}
+			return nil
+		},
+	}
+
+	_, err := ParseEmail("gaben@ValveSoftware.com", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, "valvesoftware.com", checked)
+
+	_, err = ParseEmail("gaben@example.com", opts)
+	assert.ErrorIs(t, err, ErrValidationEmail)
+	assert.EqualError(t, err, "email: failed to pass regex validation: 'gaben@example.com' domain check failed: no MX records")
+}
@@ -2,44 +2,124 @@ package types
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
 )
 
+// ErrFileTooLarge is returned when reading a file which is larger than the
+// maximum size set by File.SetMaxSize.
+var ErrFileTooLarge = errors.New("file: exceeds maximum size")
+
 type File struct {
-	multipart *multipart.FileHeader
-	data      []byte
-	filename  string
+	multipart   *multipart.FileHeader
+	data        []byte
+	filename    string
+	reader      io.Reader
+	size        int64
+	contentType string
+	maxSize     int64
+	// header and path are those of a file made by NewFileFromPart.
+	header textproto.MIMEHeader
+	path   string
 }
 
 func (file *File) InitFromMultipart(header *multipart.FileHeader) {
-	file.multipart = header
-	file.data = nil
-	file.filename = ""
+	*file = File{multipart: header}
 }
 
 func (file *File) InitFromBytes(data []byte, filename string) {
-	file.data = data
-	file.filename = filename
-	file.multipart = nil
+	*file = File{data: data, filename: filename}
+}
+
+// NewFileFromPart returns a File read from a multipart part with the given
+// header, which gives its name and Content-Type. Its content is data, or,
+// when path isn't empty, the size bytes stored in the file at path, which
+// is left for the caller to remove, as multipart.Form.RemoveAll does.
+func NewFileFromPart(header textproto.MIMEHeader, data []byte, path string, size int64) File {
+	file := File{
+		header:      header,
+		contentType: header.Get("Content-Type"),
+		data:        data,
+		path:        path,
+		size:        size,
+	}
+	if cd, err := ParseContentDisposition(header.Get("Content-Disposition")); err == nil {
+		file.filename = cd.Filename
+	}
+	return file
+}
+
+// NewFileFromReader returns a File whose content is read from r only once
+// it's needed, such as by Reader or WriteTo, so that large payloads can be
+// streamed, for example into a multipart.Writer part, rather than held in
+// memory. The size is -1 when it isn't known. The content can
+// only be read once, after which the File is empty.
+func NewFileFromReader(r io.Reader, filename string, contentType string, size int64) File {
+	return File{
+		reader:      r,
+		filename:    filename,
+		size:        size,
+		contentType: contentType,
+	}
 }
 
+// MarshalJSON encodes the content of the file as a standard base64 string,
+// as schemas with format: byte expect, so that files may be embedded in JSON
+// bodies. The content is encoded as it's read, rather than read into memory
+// first.
 func (file File) MarshalJSON() ([]byte, error) {
-	b, err := file.Bytes()
+	r, err := file.Reader()
 	if err != nil {
 		return nil, err
 	}
-	return json.Marshal(b)
+	defer func() { _ = r.Close() }()
+
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	enc := base64.NewEncoder(base64.StdEncoding, &buf)
+	if _, err := io.Copy(enc, r); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('"')
+	return buf.Bytes(), nil
 }
 
+// UnmarshalJSON decodes the content of the file from a base64 string, which
+// may be padded or not, and replaces the file with it. Its maximum size, if
+// it has one, is kept and enforced. A null leaves the file unchanged.
 func (file *File) UnmarshalJSON(data []byte) error {
-	return json.Unmarshal(data, &file.data)
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if file.maxSize > 0 && int64(base64.RawStdEncoding.DecodedLen(len(strings.TrimRight(s, "=")))) > file.maxSize {
+		return ErrFileTooLarge
+	}
+	decoded, err := decodeBase64(base64.RawStdEncoding, s)
+	if err != nil {
+		return fmt.Errorf("invalid base64 file content: %w", err)
+	}
+	*file = File{data: decoded, maxSize: file.maxSize}
+	return nil
 }
 
 func (file File) Bytes() ([]byte, error) {
-	if file.multipart != nil {
-		f, err := file.multipart.Open()
+	if file.multipart != nil || file.reader != nil || file.path != "" || file.maxSize > 0 {
+		f, err := file.Reader()
 		if err != nil {
 			return nil, err
 		}
@@ -50,15 +130,133 @@ func (file File) Bytes() ([]byte, error) {
 }
 
 func (file File) Reader() (io.ReadCloser, error) {
+	if file.maxSize > 0 && file.FileSize() > file.maxSize {
+		return nil, ErrFileTooLarge
+	}
+	r, err := file.open()
+	if err != nil || file.maxSize <= 0 {
+		return r, err
+	}
+	return &maxSizeReader{ReadCloser: r, remaining: file.maxSize}, nil
+}
+
+func (file File) open() (io.ReadCloser, error) {
 	if file.multipart != nil {
 		return file.multipart.Open()
 	}
+	if file.path != "" {
+		return os.Open(file.path)
+	}
+	if file.reader != nil {
+		if rc, ok := file.reader.(io.ReadCloser); ok {
+			return rc, nil
+		}
+		return io.NopCloser(file.reader), nil
+	}
 	return io.NopCloser(bytes.NewReader(file.data)), nil
 }
 
+// SetMaxSize limits the size of the file's content to n bytes, so that
+// reading it, such as with Bytes, Reader or SaveTo, fails with
+// ErrFileTooLarge rather than taking up unbounded memory or disk space. It's
+// checked upfront when the size is known, and as the content is read when
+// it isn't. A size of 0 or less removes the limit.
+func (file *File) SetMaxSize(n int64) {
+	file.maxSize = n
+}
+
+// maxSizeReader fails with ErrFileTooLarge once more than remaining bytes
+// have been read.
+type maxSizeReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *maxSizeReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+		r.remaining = 0
+		return n, ErrFileTooLarge
+	}
+	r.remaining -= int64(n)
+	return n, err
+}
+
+// SaveTo writes the content of the file to a file at path, which is created
+// or truncated, streaming it rather than reading it into memory. Nothing is
+// left at path if it fails, such as with ErrFileTooLarge.
+func (file File) SaveTo(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// DetectContentType sniffs the media type of the file's content, as
+// http.DetectContentType does, from its first 512 bytes, rather than
+// trusting the type it was given with. The content of a file made with
+// NewFileFromReader remains readable afterwards.
+func (file *File) DetectContentType() (string, error) {
+	if file.reader != nil {
+		head := make([]byte, 512)
+		n, err := io.ReadFull(file.reader, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		head = head[:n]
+		r := io.MultiReader(bytes.NewReader(head), file.reader)
+		if c, ok := file.reader.(io.Closer); ok {
+			file.reader = struct {
+				io.Reader
+				io.Closer
+			}{r, c}
+		} else {
+			file.reader = r
+		}
+		return http.DetectContentType(head), nil
+	}
+	r, err := file.open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = r.Close() }()
+	head, err := io.ReadAll(io.LimitReader(r, 512))
+	if err != nil {
+		return "", err
+	}
+	return http.DetectContentType(head), nil
+}
+
+// WriteTo writes the content of the file to w, without reading it all into
+// memory first, which makes File an io.WriterTo.
+func (file File) WriteTo(w io.Writer) (int64, error) {
+	r, err := file.Reader()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = r.Close() }()
+	return io.Copy(w, r)
+}
+
+// Filename returns the name of the file. The names of files bound from
+// multipart parts, which are given by clients, are sanitized by
+// SanitizeFilename.
 func (file File) Filename() string {
 	if file.multipart != nil {
-		return file.multipart.Filename
+		return SanitizeFilename(file.multipart.Filename)
 	}
 	return file.filename
 }
@@ -67,5 +265,27 @@ func (file File) FileSize() int64 {
 	if file.multipart != nil {
 		return file.multipart.Size
 	}
+	if file.reader != nil || file.path != "" {
+		return file.size
+	}
 	return int64(len(file.data))
 }
+
+// Header returns the MIME header of the multipart part the file was bound
+// from, or made from by NewFileFromPart, such as a Content-ID given by the
+// encoding of the part, or nil when it wasn't bound from one.
+func (file File) Header() textproto.MIMEHeader {
+	if file.multipart != nil {
+		return file.multipart.Header
+	}
+	return file.header
+}
+
+// ContentType returns the media type the file was given with, such as the
+// Content-Type of its multipart part, or "" if it wasn't given one.
+func (file File) ContentType() string {
+	if file.multipart != nil {
+		return file.multipart.Header.Get("Content-Type")
+	}
+	return file.contentType
+}
@@ -1,7 +1,14 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +59,158 @@ func TestFileJSON(t *testing.T) {
 	assert.Equal(t, []byte("hello"), o4Bytes)
 
 }
+
+var _ io.WriterTo = File{}
+
+func TestFileFromReader(t *testing.T) {
+	r := strings.NewReader("hello, world")
+	f := NewFileFromReader(r, "hello.txt", "text/plain", int64(r.Len()))
+	assert.Equal(t, "hello.txt", f.Filename())
+	assert.Equal(t, "text/plain", f.ContentType())
+	assert.Equal(t, int64(12), f.FileSize())
+
+	// Nothing is read until the content is needed.
+	assert.Equal(t, 12, r.Len())
+
+	// The content is streamed into multipart parts.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile("file", f.Filename())
+	require.NoError(t, err)
+	n, err := f.WriteTo(part)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+	require.NoError(t, mw.Close())
+
+	mr := multipart.NewReader(&body, mw.Boundary())
+	form, err := mr.ReadForm(1 << 20)
+	require.NoError(t, err)
+	var uploaded File
+	uploaded.InitFromMultipart(form.File["file"][0])
+	b, err := uploaded.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello, world"), b)
+	assert.Equal(t, "hello.txt", uploaded.Filename())
+	assert.Equal(t, "application/octet-stream", uploaded.ContentType())
+
+	// The content can only be read once.
+	b, err = f.Bytes()
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	assert.Equal(t, int64(-1), NewFileFromReader(r, "", "", -1).FileSize())
+}
+
+func TestFileSaveTo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upload.txt")
+
+	var f File
+	f.InitFromBytes([]byte("hello"), "hello.txt")
+	require.NoError(t, f.SaveTo(path))
+	saved, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), saved)
+
+	// Files which are too large are rejected upfront when their size is
+	// known, and as they're read when it isn't.
+	f.SetMaxSize(4)
+	_, err = f.Bytes()
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+
+	path = filepath.Join(t.TempDir(), "large.txt")
+	large := NewFileFromReader(strings.NewReader("hello, world"), "large.txt", "", -1)
+	large.SetMaxSize(5)
+	assert.ErrorIs(t, large.SaveTo(path), ErrFileTooLarge)
+	assert.NoFileExists(t, path)
+
+	small := NewFileFromReader(strings.NewReader("hello"), "small.txt", "", -1)
+	small.SetMaxSize(5)
+	b, err := small.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+}
+
+func TestFileDetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	var f File
+	f.InitFromBytes(png, "image.txt")
+	contentType, err := f.DetectContentType()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	// The content of reader backed files remains readable.
+	r := NewFileFromReader(bytes.NewReader(png), "image.txt", "text/plain", int64(len(png)))
+	contentType, err = r.DetectContentType()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	assert.Equal(t, "text/plain", r.ContentType())
+	b, err := r.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, png, b)
+}
+
+func TestFileJSONBase64(t *testing.T) {
+	type upload struct {
+		Avatar File `json:"avatar"`
+	}
+
+	// Files made from readers are encoded as they're read.
+	u := upload{Avatar: NewFileFromReader(strings.NewReader("\xfb\xffhello"), "avatar.png", "image/png", -1)}
+	data, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"avatar":"+/9oZWxsbw=="}`, string(data))
+
+	var decoded upload
+	require.NoError(t, json.Unmarshal([]byte(`{"avatar":"+/9oZWxsbw"}`), &decoded))
+	content, err := decoded.Avatar.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "\xfb\xffhello", string(content))
+	assert.Equal(t, int64(7), decoded.Avatar.FileSize())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"avatar":null}`), &decoded))
+	content, err = decoded.Avatar.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "\xfb\xffhello", string(content))
+
+	var empty upload
+	data, err = json.Marshal(empty)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"avatar":""}`, string(data))
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"avatar":"not base64!"}`), &decoded), "invalid base64 file content")
+
+	var limited File
+	limited.SetMaxSize(4)
+	assert.ErrorIs(t, limited.UnmarshalJSON([]byte(`"aGVsbG8="`)), ErrFileTooLarge)
+	require.NoError(t, limited.UnmarshalJSON([]byte(`"aGk="`)))
+	content, err = limited.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, "hi", string(content))
+}
+
+func TestFileFromPart(t *testing.T) {
+	header := textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="doc"; filename="../report.txt"`},
+		"Content-Type":        {"text/plain"},
+		"Content-Id":          {"<doc>"},
+	}
+	f := NewFileFromPart(header, []byte("hello"), "", 5)
+	assert.Equal(t, "report.txt", f.Filename())
+	assert.Equal(t, "text/plain", f.ContentType())
+	assert.Equal(t, "<doc>", f.Header().Get("Content-ID"))
+	b, err := f.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), b)
+
+	path := filepath.Join(t.TempDir(), "part")
+	require.NoError(t, os.WriteFile(path, []byte("hello, world"), 0o600))
+	f = NewFileFromPart(header, nil, path, 12)
+	assert.Equal(t, int64(12), f.FileSize())
+	b, err = f.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello, world"), b)
+	f.SetMaxSize(5)
+	_, err = f.Bytes()
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"fmt"
+	"io"
+)
+
+// Files are the files of a multipart field which may be given more than
+// once, such as an array of files with format: binary.
+type Files []File
+
+// TotalSize returns the total size of the files in bytes, and whether it's
+// known, which it isn't when any file was made from a reader of unknown
+// size.
+func (files Files) TotalSize() (int64, bool) {
+	var total int64
+	for _, file := range files {
+		size := file.FileSize()
+		if size < 0 {
+			return 0, false
+		}
+		total += size
+	}
+	return total, true
+}
+
+// CheckMaxTotalSize returns an error wrapping ErrFileTooLarge when the total
+// size of the files is known to be more than n bytes.
+func (files Files) CheckMaxTotalSize(n int64) error {
+	if total, ok := files.TotalSize(); ok && total > n {
+		return fmt.Errorf("%w: %d files of %d bytes exceed %d bytes", ErrFileTooLarge, len(files), total, n)
+	}
+	return nil
+}
+
+// Filenames returns the names of the files.
+func (files Files) Filenames() []string {
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = file.Filename()
+	}
+	return names
+}
+
+// Each calls fn with each file and a reader of its content in turn, so that
+// only one file is open, and none is read into memory, at a time. Each
+// reader is closed once fn returns. It stops at the first error.
+func (files Files) Each(fn func(file File, r io.Reader) error) error {
+	for _, file := range files {
+		if err := eachFile(file, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eachFile(file File, fn func(file File, r io.Reader) error) error {
+	r, err := file.Reader()
+	if err != nil {
+		return fmt.Errorf("error opening file '%s': %w", file.Filename(), err)
+	}
+	defer func() { _ = r.Close() }()
+	return fn(file, r)
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	var a, b File
+	a.InitFromBytes([]byte("hello"), "a.txt")
+	b.InitFromBytes([]byte("world!"), "b.txt")
+	files := Files{a, b}
+
+	total, ok := files.TotalSize()
+	assert.True(t, ok)
+	assert.Equal(t, int64(11), total)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, files.Filenames())
+	assert.NoError(t, files.CheckMaxTotalSize(11))
+	assert.ErrorIs(t, files.CheckMaxTotalSize(10), ErrFileTooLarge)
+
+	var contents []string
+	require.NoError(t, files.Each(func(file File, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		contents = append(contents, file.Filename()+":"+string(data))
+		return err
+	}))
+	assert.Equal(t, []string{"a.txt:hello", "b.txt:world!"}, contents)
+
+	errStop := errors.New("stop")
+	var visited int
+	assert.ErrorIs(t, files.Each(func(File, io.Reader) error {
+		visited++
+		return errStop
+	}), errStop)
+	assert.Equal(t, 1, visited)
+
+	// The total size of files of unknown size isn't known.
+	files = append(files, NewFileFromReader(strings.NewReader("..."), "c.txt", "", -1))
+	_, ok = files.TotalSize()
+	assert.False(t, ok)
+	assert.NoError(t, files.CheckMaxTotalSize(1))
+}
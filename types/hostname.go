@@ -0,0 +1,91 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrValidationHostname is the sentinel error wrapped by the errors of host
+// names which fail validation.
+var ErrValidationHostname = errors.New("hostname: failed validation")
+
+// Hostname is a host name, as used by schemas with format: hostname. As RFC
+// 1123 describes, it's made of dot separated labels of letters, digits and
+// hyphens, which don't start or end with a hyphen, and are at most 63
+// characters long, up to 253 characters in all. A trailing dot, as in a
+// fully qualified name, is allowed.
+type Hostname string
+
+// ParseHostname validates a host name.
+func ParseHostname(s string) (Hostname, error) {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" {
+		return "", fmt.Errorf("%w: '%s' is empty", ErrValidationHostname, s)
+	}
+	if len(name) > 253 {
+		return "", fmt.Errorf("%w: '%s' is longer than 253 characters", ErrValidationHostname, s)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 {
+			return "", fmt.Errorf("%w: '%s' has a label which is empty or longer than 63 characters",
+				ErrValidationHostname, s)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", fmt.Errorf("%w: '%s' has a label which starts or ends with a hyphen", ErrValidationHostname, s)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return "", fmt.Errorf("%w: '%s' has an invalid character %q", ErrValidationHostname, s, c)
+			}
+		}
+	}
+	return Hostname(s), nil
+}
+
+// NormalizeHostname converts an internationalized host name, such as
+// "Bücher.example", to its lowercase ASCII form, "xn--bcher-kva.example",
+// with IDNA, as used to look it up, and validates it.
+func NormalizeHostname(s string) (Hostname, error) {
+	ascii, err := idna.Lookup.ToASCII(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' %v", ErrValidationHostname, s, err)
+	}
+	return ParseHostname(ascii)
+}
+
+func (h Hostname) MarshalJSON() ([]byte, error) {
+	if _, err := ParseHostname(string(h)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(h))
+}
+
+func (h *Hostname) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return h.UnmarshalText([]byte(s))
+}
+
+func (h *Hostname) UnmarshalText(data []byte) error {
+	parsed, err := ParseHostname(string(data))
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (h *Hostname) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return h.UnmarshalText([]byte(src))
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostname(t *testing.T) {
+	for _, valid := range []string{
+		"example.com",
+		"api.Example.COM",
+		"localhost",
+		"1password.com",
+		"a-b.c-d.example.",
+		strings.Repeat("a", 63) + ".example",
+	} {
+		h, err := ParseHostname(valid)
+		require.NoError(t, err, valid)
+		assert.Equal(t, Hostname(valid), h)
+	}
+
+	for invalid, reason := range map[string]string{
+		"":                                "is empty",
+		".":                               "is empty",
+		"-example.com":                    "starts or ends with a hyphen",
+		"example-.com":                    "starts or ends with a hyphen",
+		"exa_mple.com":                    "invalid character '_'",
+		"example..com":                    "empty or longer than 63 characters",
+		strings.Repeat("a", 64) + ".com":  "empty or longer than 63 characters",
+		strings.Repeat("abc.", 64) + "de": "longer than 253 characters",
+		"bücher.example":                  "invalid character 'ü'",
+	} {
+		_, err := ParseHostname(invalid)
+		assert.ErrorIs(t, err, ErrValidationHostname, invalid)
+		assert.ErrorContains(t, err, reason, invalid)
+	}
+}
+
+func TestNormalizeHostname(t *testing.T) {
+	h, err := NormalizeHostname("Bücher.Example")
+	require.NoError(t, err)
+	assert.Equal(t, Hostname("xn--bcher-kva.example"), h)
+
+	h, err = NormalizeHostname("API.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, Hostname("api.example.com"), h)
+
+	_, err = NormalizeHostname("exa mple.com")
+	assert.ErrorIs(t, err, ErrValidationHostname)
+}
+
+func TestHostnameJSON(t *testing.T) {
+	var o struct {
+		Host Hostname `json:"host"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"host":"example.com"}`), &o))
+	assert.Equal(t, Hostname("example.com"), o.Host)
+
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"host":"example.com"}`, string(b))
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"host":"not a host"}`), &o), ErrValidationHostname)
+	o.Host = "not a host"
+	_, err = json.Marshal(o)
+	assert.ErrorIs(t, err, ErrValidationHostname)
+
+	require.NoError(t, o.Host.Bind("example.org"))
+	assert.Equal(t, Hostname("example.org"), o.Host)
+}
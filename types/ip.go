@@ -0,0 +1,224 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/netip"
+)
+
+// IPv4 is an IPv4 address in dotted decimal notation, such as "192.0.2.1",
+// as used by schemas with format: ipv4.
+type IPv4 struct {
+	addr netip.Addr
+}
+
+// IPv6 is an IPv6 address, such as "2001:db8::1", as used by schemas with
+// format: ipv6. Addresses with a zone aren't valid.
+type IPv6 struct {
+	addr netip.Addr
+}
+
+// CIDR is an IP network in CIDR notation, such as "192.0.2.0/24" or
+// "2001:db8::/32".
+type CIDR struct {
+	prefix netip.Prefix
+}
+
+// ParseIPv4 parses an IPv4 address.
+func ParseIPv4(s string) (IPv4, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return IPv4{}, fmt.Errorf("invalid IPv4 address '%s': %w", s, err)
+	}
+	if !addr.Is4() {
+		return IPv4{}, fmt.Errorf("invalid IPv4 address '%s': it's an IPv6 address", s)
+	}
+	return IPv4{addr: addr}, nil
+}
+
+// ParseIPv6 parses an IPv6 address.
+func ParseIPv6(s string) (IPv6, error) {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return IPv6{}, fmt.Errorf("invalid IPv6 address '%s': %w", s, err)
+	}
+	if !addr.Is6() {
+		return IPv6{}, fmt.Errorf("invalid IPv6 address '%s': it's an IPv4 address", s)
+	}
+	if addr.Zone() != "" {
+		return IPv6{}, fmt.Errorf("invalid IPv6 address '%s': it has a zone", s)
+	}
+	return IPv6{addr: addr}, nil
+}
+
+// ParseCIDR parses an IP network in CIDR notation. The address is kept as
+// it's given, rather than masked to the network's prefix.
+func ParseCIDR(s string) (CIDR, error) {
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return CIDR{}, fmt.Errorf("invalid CIDR '%s': %w", s, err)
+	}
+	return CIDR{prefix: prefix}, nil
+}
+
+// Addr returns the address, which is invalid for the zero IPv4.
+func (ip IPv4) Addr() netip.Addr {
+	return ip.addr
+}
+
+// String returns the address, or "" for the zero IPv4, so that it
+// round-trips through JSON and text.
+func (ip IPv4) String() string {
+	if !ip.addr.IsValid() {
+		return ""
+	}
+	return ip.addr.String()
+}
+
+func (ip IPv4) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ip.String())
+}
+
+func (ip *IPv4) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return ip.UnmarshalText([]byte(s))
+}
+
+func (ip IPv4) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+// UnmarshalText parses the address, and "" as the zero IPv4.
+func (ip *IPv4) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*ip = IPv4{}
+		return nil
+	}
+	parsed, err := ParseIPv4(string(data))
+	if err != nil {
+		return err
+	}
+	*ip = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (ip *IPv4) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return ip.UnmarshalText([]byte(src))
+}
+
+// Addr returns the address, which is invalid for the zero IPv6.
+func (ip IPv6) Addr() netip.Addr {
+	return ip.addr
+}
+
+// String returns the address, or "" for the zero IPv6.
+func (ip IPv6) String() string {
+	if !ip.addr.IsValid() {
+		return ""
+	}
+	return ip.addr.String()
+}
+
+func (ip IPv6) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ip.String())
+}
+
+func (ip *IPv6) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return ip.UnmarshalText([]byte(s))
+}
+
+func (ip IPv6) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+// UnmarshalText parses the address, and "" as the zero IPv6.
+func (ip *IPv6) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*ip = IPv6{}
+		return nil
+	}
+	parsed, err := ParseIPv6(string(data))
+	if err != nil {
+		return err
+	}
+	*ip = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (ip *IPv6) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return ip.UnmarshalText([]byte(src))
+}
+
+// Prefix returns the network, which is invalid for the zero CIDR.
+func (c CIDR) Prefix() netip.Prefix {
+	return c.prefix
+}
+
+// Contains reports whether the network includes the address.
+func (c CIDR) Contains(addr netip.Addr) bool {
+	return c.prefix.Contains(addr)
+}
+
+// String returns the network, or "" for the zero CIDR.
+func (c CIDR) String() string {
+	if !c.prefix.IsValid() {
+		return ""
+	}
+	return c.prefix.String()
+}
+
+func (c CIDR) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *CIDR) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+func (c CIDR) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText parses the network, and "" as the zero CIDR.
+func (c *CIDR) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = CIDR{}
+		return nil
+	}
+	parsed, err := ParseCIDR(string(data))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (c *CIDR) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return c.UnmarshalText([]byte(src))
+}
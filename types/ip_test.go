@@ -0,0 +1,93 @@
+package types
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIP(t *testing.T) {
+	v4, err := ParseIPv4("192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), v4.Addr())
+	assert.Equal(t, "192.0.2.1", v4.String())
+
+	_, err = ParseIPv4("2001:db8::1")
+	assert.EqualError(t, err, "invalid IPv4 address '2001:db8::1': it's an IPv6 address")
+	_, err = ParseIPv4("192.0.2.256")
+	assert.ErrorContains(t, err, "invalid IPv4 address '192.0.2.256'")
+
+	v6, err := ParseIPv6("2001:DB8:0:0::1")
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", v6.String())
+
+	_, err = ParseIPv6("192.0.2.1")
+	assert.EqualError(t, err, "invalid IPv6 address '192.0.2.1': it's an IPv4 address")
+	_, err = ParseIPv6("fe80::1%eth0")
+	assert.EqualError(t, err, "invalid IPv6 address 'fe80::1%eth0': it has a zone")
+
+	cidr, err := ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+	assert.True(t, cidr.Contains(v4.Addr()))
+	assert.False(t, cidr.Contains(netip.MustParseAddr("198.51.100.1")))
+	assert.Equal(t, 24, cidr.Prefix().Bits())
+
+	_, err = ParseCIDR("192.0.2.0")
+	assert.ErrorContains(t, err, "invalid CIDR '192.0.2.0'")
+}
+
+func TestIPJSON(t *testing.T) {
+	var o struct {
+		V4      IPv4  `json:"v4"`
+		V6      IPv6  `json:"v6"`
+		Network CIDR  `json:"network"`
+		Gateway *IPv4 `json:"gateway,omitempty"`
+	}
+	input := `{"v4":"10.0.0.1","v6":"::1","network":"10.0.0.0/8"}`
+	require.NoError(t, json.Unmarshal([]byte(input), &o))
+	assert.Equal(t, "10.0.0.1", o.V4.String())
+	assert.True(t, o.V6.Addr().IsLoopback())
+	assert.True(t, o.Network.Contains(o.V4.Addr()))
+
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, input, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"v4":"::1"}`), &o))
+	assert.Error(t, json.Unmarshal([]byte(`{"v6":"10.0.0.1"}`), &o))
+	assert.Error(t, json.Unmarshal([]byte(`{"network":"10.0.0.0/33"}`), &o))
+
+	// The zero values round-trip as empty strings.
+	var zero struct {
+		V4      IPv4 `json:"v4"`
+		V6      IPv6 `json:"v6"`
+		Network CIDR `json:"network"`
+	}
+	b, err = json.Marshal(zero)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"v4":"","v6":"","network":""}`, string(b))
+	require.NoError(t, json.Unmarshal([]byte(input), &zero))
+	require.NoError(t, json.Unmarshal(b, &zero))
+	assert.False(t, zero.V4.Addr().IsValid())
+	assert.False(t, zero.V6.Addr().IsValid())
+	assert.False(t, zero.Network.Prefix().IsValid())
+}
+
+func TestIPBind(t *testing.T) {
+	var v4 IPv4
+	require.NoError(t, v4.Bind("127.0.0.1"))
+	require.NoError(t, v4.Bind(""))
+	assert.Equal(t, "127.0.0.1", v4.String())
+	assert.Error(t, v4.Bind("localhost"))
+
+	var v6 IPv6
+	require.NoError(t, v6.Bind("::1"))
+	assert.Equal(t, "::1", v6.String())
+
+	var cidr CIDR
+	require.NoError(t, cidr.Bind("::/0"))
+	assert.Equal(t, "::/0", cidr.String())
+}
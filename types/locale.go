@@ -0,0 +1,135 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ErrValidationLanguageTag is the sentinel error wrapped by the errors of
+// language tags which fail validation.
+var ErrValidationLanguageTag = errors.New("language tag: failed validation")
+
+// ErrValidationCountryCode is the sentinel error wrapped by the errors of
+// country codes which fail validation.
+var ErrValidationCountryCode = errors.New("country code: failed validation")
+
+// LanguageTag is a BCP 47 language tag, such as "en-US" or "zh-Hant-TW", as
+// used by Accept-Language and Content-Language headers. It's kept in its
+// canonical form, so that "en_us" becomes "en-US" and "iw" becomes "he".
+type LanguageTag string
+
+// ParseLanguageTag validates a language tag and returns its canonical form.
+func ParseLanguageTag(s string) (LanguageTag, error) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' %v", ErrValidationLanguageTag, s, err)
+	}
+	return LanguageTag(tag.String()), nil
+}
+
+// Tag returns the language.Tag of the language tag, such as to match it
+// against the languages an API supports with a language.Matcher.
+func (l LanguageTag) Tag() (language.Tag, error) {
+	return language.Parse(string(l))
+}
+
+func (l LanguageTag) MarshalJSON() ([]byte, error) {
+	if _, err := ParseLanguageTag(string(l)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(l))
+}
+
+func (l *LanguageTag) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(s))
+}
+
+func (l *LanguageTag) UnmarshalText(data []byte) error {
+	parsed, err := ParseLanguageTag(string(data))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (l *LanguageTag) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return l.UnmarshalText([]byte(src))
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country code, such as "US". It's
+// kept in its canonical form, in uppercase, and with deprecated codes
+// replaced, so that "uk" becomes "GB".
+type CountryCode string
+
+// ParseCountryCode validates a two letter country code and returns its
+// canonical form.
+func ParseCountryCode(s string) (CountryCode, error) {
+	if len(s) != 2 {
+		return "", fmt.Errorf("%w: '%s' isn't two letters", ErrValidationCountryCode, s)
+	}
+	region, err := language.ParseRegion(strings.ToUpper(s))
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' %v", ErrValidationCountryCode, s, err)
+	}
+	region = region.Canonicalize()
+	if !region.IsCountry() {
+		return "", fmt.Errorf("%w: '%s' isn't a country", ErrValidationCountryCode, s)
+	}
+	return CountryCode(region.String()), nil
+}
+
+// ISO3 returns the ISO 3166-1 alpha-3 code of the country, such as "USA".
+func (c CountryCode) ISO3() string {
+	region, err := language.ParseRegion(string(c))
+	if err != nil {
+		return ""
+	}
+	return region.ISO3()
+}
+
+func (c CountryCode) MarshalJSON() ([]byte, error) {
+	if _, err := ParseCountryCode(string(c)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(c))
+}
+
+func (c *CountryCode) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+func (c *CountryCode) UnmarshalText(data []byte) error {
+	parsed, err := ParseCountryCode(string(data))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (c *CountryCode) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return c.UnmarshalText([]byte(src))
+}
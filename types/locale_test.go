@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseLanguageTag(t *testing.T) {
+	for s, expected := range map[string]LanguageTag{
+		"en-us":      "en-US",
+		"EN_us":      "en-US",
+		"zh-hant-tw": "zh-Hant-TW",
+		"iw":         "he",
+		"i-klingon":  "tlh",
+	} {
+		tag, err := ParseLanguageTag(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, tag, s)
+	}
+
+	for _, s := range []string{"", "xx-YY", "en-", "english"} {
+		_, err := ParseLanguageTag(s)
+		assert.ErrorIs(t, err, ErrValidationLanguageTag, s)
+	}
+
+	tag, err := LanguageTag("fr-CA").Tag()
+	require.NoError(t, err)
+	assert.Equal(t, language.CanadianFrench, tag)
+}
+
+func TestParseCountryCode(t *testing.T) {
+	for s, expected := range map[string]CountryCode{
+		"us": "US",
+		"GB": "GB",
+		"uk": "GB",
+		"DD": "DE",
+	} {
+		code, err := ParseCountryCode(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, code, s)
+	}
+
+	for _, s := range []string{"", "USA", "840", "XX", "EU", "ZZ", "1A"} {
+		_, err := ParseCountryCode(s)
+		assert.ErrorIs(t, err, ErrValidationCountryCode, s)
+	}
+
+	assert.Equal(t, "USA", CountryCode("US").ISO3())
+}
+
+func TestLocaleJSON(t *testing.T) {
+	type address struct {
+		Country  CountryCode `json:"country"`
+		Language LanguageTag `json:"language"`
+	}
+
+	var a address
+	require.NoError(t, json.Unmarshal([]byte(`{"country":"uk","language":"en-gb"}`), &a))
+	assert.Equal(t, address{Country: "GB", Language: "en-GB"}, a)
+
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"country":"GB","language":"en-GB"}`, string(data))
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"country":"XX"}`), &a), ErrValidationCountryCode)
+	_, err = json.Marshal(address{Country: "XX", Language: "en"})
+	assert.ErrorIs(t, err, ErrValidationCountryCode)
+
+	require.NoError(t, a.Language.Bind(""))
+	assert.Equal(t, LanguageTag("en-GB"), a.Language)
+	require.NoError(t, a.Language.Bind("pt-br"))
+	assert.Equal(t, LanguageTag("pt-BR"), a.Language)
+}
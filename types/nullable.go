@@ -0,0 +1,112 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ErrNullableIsNull is returned when getting the value of a Nullable which
+// is null.
+var ErrNullableIsNull = errors.New("nullable: value is null")
+
+// ErrNullableNotSpecified is returned when getting the value of a Nullable
+// which wasn't specified.
+var ErrNullableNotSpecified = errors.New("nullable: value is not specified")
+
+// Nullable is a value of a nullable field which tells apart the three
+// states such a field has in JSON: absent, null, and set to a value, so that
+// PATCH bodies can clear a field with null without clearing the fields they
+// leave out. It's a map so that fields with the omitempty option are left
+// out while unspecified, and marshaled as null when null:
+//
+//	type PetPatch struct {
+//		Name types.Nullable[string] `json:"name,omitempty"`
+//	}
+//
+// The zero value is unspecified.
+type Nullable[T any] map[bool]T
+
+// NewNullableWithValue returns a Nullable set to value.
+func NewNullableWithValue[T any](value T) Nullable[T] {
+	var n Nullable[T]
+	n.Set(value)
+	return n
+}
+
+// NewNullNullable returns a Nullable which is null.
+func NewNullNullable[T any]() Nullable[T] {
+	var n Nullable[T]
+	n.SetNull()
+	return n
+}
+
+// Get returns the value, or ErrNullableIsNull or ErrNullableNotSpecified
+// when there's none.
+func (n Nullable[T]) Get() (T, error) {
+	var zero T
+	if n.IsNull() {
+		return zero, ErrNullableIsNull
+	}
+	if !n.IsSpecified() {
+		return zero, ErrNullableNotSpecified
+	}
+	return n[true], nil
+}
+
+// MustGet returns the value, and panics when there's none.
+func (n Nullable[T]) MustGet() T {
+	v, err := n.Get()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Set sets the value.
+func (n *Nullable[T]) Set(value T) {
+	*n = map[bool]T{true: value}
+}
+
+// SetNull makes the value null.
+func (n *Nullable[T]) SetNull() {
+	var zero T
+	*n = map[bool]T{false: zero}
+}
+
+// SetUnspecified makes the value unspecified.
+func (n *Nullable[T]) SetUnspecified() {
+	*n = nil
+}
+
+// IsNull reports whether the value is null.
+func (n Nullable[T]) IsNull() bool {
+	_, null := n[false]
+	return null
+}
+
+// IsSpecified reports whether the value is null or set.
+func (n Nullable[T]) IsSpecified() bool {
+	return len(n) != 0
+}
+
+// MarshalJSON marshals the value, or null when it's null or unspecified.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.IsSpecified() || n.IsNull() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n[true])
+}
+
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Set(v)
+	return nil
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type petPatch struct {
+	Name Nullable[string] `json:"name,omitempty"`
+	Age  Nullable[int]    `json:"age,omitempty"`
+	Tags Nullable[[]string]
+}
+
+func TestNullableJSON(t *testing.T) {
+	var p petPatch
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"Rex","age":null}`), &p))
+
+	assert.True(t, p.Name.IsSpecified())
+	assert.False(t, p.Name.IsNull())
+	assert.Equal(t, "Rex", p.Name.MustGet())
+
+	assert.True(t, p.Age.IsSpecified())
+	assert.True(t, p.Age.IsNull())
+	_, err := p.Age.Get()
+	assert.ErrorIs(t, err, ErrNullableIsNull)
+
+	assert.False(t, p.Tags.IsSpecified())
+	_, err = p.Tags.Get()
+	assert.ErrorIs(t, err, ErrNullableNotSpecified)
+	assert.Panics(t, func() { p.Tags.MustGet() })
+
+	// Unspecified fields with omitempty are left out.
+	b, err := json.Marshal(petPatch{Name: NewNullableWithValue("Rex"), Age: NewNullNullable[int]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Rex","age":null,"Tags":null}`, string(b))
+
+	b, err = json.Marshal(petPatch{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Tags":null}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"age":"old"}`), &p))
+}
+
+func TestNullableSetters(t *testing.T) {
+	var n Nullable[int]
+	assert.False(t, n.IsSpecified())
+
+	n.Set(0)
+	assert.True(t, n.IsSpecified())
+	assert.False(t, n.IsNull())
+	v, err := n.Get()
+	require.NoError(t, err)
+	assert.Equal(t, 0, v)
+
+	n.SetNull()
+	assert.True(t, n.IsNull())
+
+	n.SetUnspecified()
+	assert.False(t, n.IsSpecified())
+	assert.False(t, n.IsNull())
+}
@@ -0,0 +1,44 @@
+package types
+
+// Optional is the value of an optional parameter, which tells apart a
+// parameter which is absent from one which is given with the zero value,
+// without having to bind it through a pointer. The parameter binders of the
+// runtime package bind to Value, and set Set, when the parameter is
+// present:
+//
+//	var limit types.Optional[int]
+//	err := runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &limit)
+//	if limit.Set {
+//		...
+//	}
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// OptionalValue is implemented by *Optional, so that binders can bind to
+// its value, whatever its type, and mark it set.
+type OptionalValue interface {
+	// OptionalValuePtr returns a pointer to the value.
+	OptionalValuePtr() interface{}
+	// MarkSet records that the value was set.
+	MarkSet()
+}
+
+// NewOptional returns an Optional which is set to value.
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{Value: value, Set: true}
+}
+
+// Get returns the value, and whether it's set.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+func (o *Optional[T]) OptionalValuePtr() interface{} {
+	return &o.Value
+}
+
+func (o *Optional[T]) MarkSet() {
+	o.Set = true
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	var o Optional[int]
+	v, ok := o.Get()
+	assert.False(t, ok)
+	assert.Equal(t, 0, v)
+
+	var ov OptionalValue = &o
+	*ov.OptionalValuePtr().(*int) = 0
+	ov.MarkSet()
+	v, ok = o.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 0, v)
+
+	v, ok = NewOptional(7).Get()
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+}
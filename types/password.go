@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// redactedPassword is what a Password prints as.
+const redactedPassword = "********"
+
+// Password is a secret, as used by schemas with format: password, which
+// redacts itself when it's printed with the fmt package, or logged with
+// log/slog, so that it doesn't leak into logs by accident. Its value is
+// given by Reveal, and it marshals as is, as JSON, XML, YAML or text, so
+// that it's sent as is in request and response bodies. The runtime package
+// also styles and binds parameters and form fields of it as is.
+type Password string
+
+// Reveal returns the secret.
+func (p Password) Reveal() string {
+	return string(p)
+}
+
+// String returns a redacted placeholder, rather than the secret.
+func (p Password) String() string {
+	return redactedPassword
+}
+
+// GoString returns a redacted placeholder for the %#v verb.
+func (p Password) GoString() string {
+	return "types.Password(" + redactedPassword + ")"
+}
+
+// Format prints a redacted placeholder for every verb, so that neither %s
+// nor %x, say, print the secret.
+func (p Password) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, p.GoString())
+		return
+	}
+	_, _ = io.WriteString(f, redactedPassword)
+}
+
+// MarshalText returns the secret itself, so that encodings of text, such as
+// XML and YAML, send it in bodies, as MarshalJSON does.
+func (p Password) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+func (p *Password) UnmarshalText(data []byte) error {
+	*p = Password(data)
+	return nil
+}
+
+// MarshalJSON marshals the secret itself, so that it's sent in bodies.
+func (p Password) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(p))
+}
+
+func (p *Password) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*p = Password(s)
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package types
+
+import "log/slog"
+
+// LogValue redacts the password when it's logged with log/slog, including
+// by handlers which marshal values to JSON.
+func (p Password) LogValue() slog.Value {
+	return slog.StringValue(redactedPassword)
+}
@@ -0,0 +1,18 @@
+//go:build go1.21
+
+package types
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("login", "password", Password("hunter2"))
+	assert.Contains(t, buf.String(), `"password":"********"`)
+	assert.NotContains(t, buf.String(), "hunter2")
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPasswordRedaction(t *testing.T) {
+	p := Password("hunter2")
+	assert.Equal(t, "hunter2", p.Reveal())
+
+	for _, format := range []string{"%s", "%v", "%+v", "%q", "%x", "%10s"} {
+		assert.Equal(t, "********", fmt.Sprintf(format, p), format)
+	}
+	assert.Equal(t, "types.Password(********)", fmt.Sprintf("%#v", p))
+	assert.Equal(t, "{********}", fmt.Sprintf("%v", struct{ P Password }{p}))
+}
+
+func TestPasswordJSON(t *testing.T) {
+	type login struct {
+		User     string   `json:"user"`
+		Password Password `json:"password"`
+	}
+
+	data, err := json.Marshal(login{User: "gaben", Password: "hunter2"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user":"gaben","password":"hunter2"}`, string(data))
+
+	var l login
+	require.NoError(t, json.Unmarshal(data, &l))
+	assert.Equal(t, "hunter2", l.Password.Reveal())
+
+	require.NoError(t, l.Password.UnmarshalText([]byte("s3cret")))
+	assert.Equal(t, Password("s3cret"), l.Password)
+}
+
+func TestPasswordBodies(t *testing.T) {
+	type login struct {
+		User     string   `xml:"user" yaml:"user"`
+		Password Password `xml:"pass" yaml:"pass"`
+	}
+	in := login{User: "gaben", Password: "hunter2"}
+
+	text, err := in.Password.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", string(text))
+
+	data, err := xml.Marshal(in)
+	require.NoError(t, err)
+	assert.Equal(t, "<login><user>gaben</user><pass>hunter2</pass></login>", string(data))
+	var fromXML login
+	require.NoError(t, xml.Unmarshal(data, &fromXML))
+	assert.Equal(t, in, fromXML)
+
+	data, err = yaml.Marshal(in)
+	require.NoError(t, err)
+	assert.Equal(t, "user: gaben\npass: hunter2\n", string(data))
+	var fromYAML login
+	require.NoError(t, yaml.Unmarshal(data, &fromYAML))
+	assert.Equal(t, in, fromYAML)
+}
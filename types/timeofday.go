@@ -0,0 +1,114 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TimeOfDayFormat is the layout of a TimeOfDay, to which fractional seconds
+// are added when there are any.
+const TimeOfDayFormat = "15:04:05"
+
+// TimeOfDay is a time of day without a date or time zone, the partial-time
+// of RFC 3339, such as "15:04:05" or "15:04:05.25", as specs use for opening
+// hours and schedules.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// NewTimeOfDay returns the time of day of t, in its location.
+func NewTimeOfDay(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay parses a time of day in the TimeOfDayFormat, optionally
+// followed by fractional seconds.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	parsed, err := time.Parse(TimeOfDayFormat, s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day '%s': %w", s, err)
+	}
+	return NewTimeOfDay(parsed), nil
+}
+
+func (t TimeOfDay) String() string {
+	return t.On(time.Time{}).Format(TimeOfDayFormat + ".999999999")
+}
+
+// On returns the time at this time of day on the date of d, in its location.
+func (t TimeOfDay) On(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), t.Hour, t.Minute, t.Second, t.Nanosecond, d.Location())
+}
+
+// sinceMidnight returns the time elapsed from midnight to t, on a day
+// without daylight saving changes.
+func (t TimeOfDay) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour + time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second + time.Duration(t.Nanosecond)
+}
+
+// Compare returns -1 if t is before u, +1 if it's after, and 0 if they're
+// equal.
+func (t TimeOfDay) Compare(u TimeOfDay) int {
+	switch d := t.sinceMidnight() - u.sinceMidnight(); {
+	case d < 0:
+		return -1
+	case d > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Before reports whether t is before u.
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t.Compare(u) < 0
+}
+
+// After reports whether t is after u.
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t.Compare(u) > 0
+}
+
+// Equal reports whether t and u are the same time of day.
+func (t TimeOfDay) Equal(u TimeOfDay) bool {
+	return t.Compare(u) == 0
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (t *TimeOfDay) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return t.UnmarshalText([]byte(src))
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	tod, err := ParseTimeOfDay("15:04:05")
+	require.NoError(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 15, Minute: 4, Second: 5}, tod)
+	assert.Equal(t, "15:04:05", tod.String())
+
+	tod, err = ParseTimeOfDay("09:30:00.250")
+	require.NoError(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 9, Minute: 30, Nanosecond: 250000000}, tod)
+	assert.Equal(t, "09:30:00.25", tod.String())
+
+	for _, invalid := range []string{"", "15:04", "25:00:00", "15:04:05Z", "3pm"} {
+		_, err := ParseTimeOfDay(invalid)
+		assert.ErrorContains(t, err, "invalid time of day '"+invalid+"'")
+	}
+}
+
+func TestTimeOfDayCompare(t *testing.T) {
+	opens := TimeOfDay{Hour: 9}
+	closes := TimeOfDay{Hour: 17, Minute: 30}
+
+	assert.True(t, opens.Before(closes))
+	assert.False(t, opens.After(closes))
+	assert.True(t, closes.After(opens))
+	assert.True(t, opens.Equal(TimeOfDay{Hour: 9}))
+	assert.Equal(t, -1, opens.Compare(closes))
+	assert.Equal(t, 0, opens.Compare(opens))
+	assert.Equal(t, 1, TimeOfDay{Hour: 9, Nanosecond: 1}.Compare(opens))
+
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC), closes.On(day))
+	assert.Equal(t, TimeOfDay{Hour: 12}, NewTimeOfDay(day))
+}
+
+func TestTimeOfDayJSON(t *testing.T) {
+	var o struct {
+		Opens TimeOfDay `json:"opens"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"opens":"08:15:30.5"}`), &o))
+	assert.Equal(t, TimeOfDay{Hour: 8, Minute: 15, Second: 30, Nanosecond: 500000000}, o.Opens)
+
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"opens":"08:15:30.5"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"opens":"8am"}`), &o))
+}
+
+func TestTimeOfDayBind(t *testing.T) {
+	var tod TimeOfDay
+	require.NoError(t, tod.Bind("23:59:59"))
+	assert.Equal(t, TimeOfDay{Hour: 23, Minute: 59, Second: 59}, tod)
+	require.NoError(t, tod.Bind(""))
+	assert.Equal(t, TimeOfDay{Hour: 23, Minute: 59, Second: 59}, tod)
+	assert.Error(t, tod.Bind("24:00:00"))
+}
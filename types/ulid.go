@@ -0,0 +1,193 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrValidationULID is the sentinel error wrapped by the errors of ULIDs
+// which fail validation.
+var ErrValidationULID = errors.New("ulid: failed validation")
+
+// ULID is a Universally Unique Lexicographically Sortable Identifier: a 48
+// bit Unix time in milliseconds followed by 80 random bits, written as 26
+// characters of Crockford's base32, such as "01ARZ3NDEKTSV4RRFFQ69G5FAV".
+// ULIDs sort in the order they were made, both as bytes and as strings.
+type ULID [16]byte
+
+// ulidAlphabet is Crockford's base32 alphabet, which leaves out I, L, O and
+// U.
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidDecoding maps the characters of ulidAlphabet, of either case, to
+// their values, and all others to 0xFF.
+var ulidDecoding = func() [256]byte {
+	var dec [256]byte
+	for i := range dec {
+		dec[i] = 0xFF
+	}
+	for i := 0; i < len(ulidAlphabet); i++ {
+		c := ulidAlphabet[i]
+		dec[c] = byte(i)
+		dec[c|0x20] = byte(i) // Lowercase, leaving digits as they are.
+	}
+	return dec
+}()
+
+// ParseULID parses a ULID from its 26 characters, of either case.
+func ParseULID(s string) (ULID, error) {
+	var id ULID
+	if len(s) != 26 {
+		return id, fmt.Errorf("%w: '%s' isn't 26 characters long", ErrValidationULID, s)
+	}
+	// 26 characters hold 130 bits, so the first may only hold 3 bits.
+	if ulidDecoding[s[0]] > 7 {
+		return id, fmt.Errorf("%w: '%s' is out of range", ErrValidationULID, s)
+	}
+	// The value is shifted in 5 bits at a time, with hi holding the bits
+	// above the bottom 64 in lo.
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := ulidDecoding[s[i]]
+		if v == 0xFF {
+			return ULID{}, fmt.Errorf("%w: '%s' has an invalid character %q", ErrValidationULID, s, s[i])
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
+
+// String returns the 26 uppercase characters of the ULID.
+func (id ULID) String() string {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	var buf [26]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = ulidAlphabet[lo&0x1F]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// Time returns the time the ULID was made, to the millisecond.
+func (id ULID) Time() time.Time {
+	var ms [8]byte
+	copy(ms[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(ms[:])))
+}
+
+// Compare returns -1, 0 or 1 when the ULID sorts before, as or after other.
+func (id ULID) Compare(other ULID) int {
+	for i := range id {
+		if id[i] != other[i] {
+			if id[i] < other[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsZero reports whether the ULID is the zero value.
+func (id ULID) IsZero() bool {
+	return id == ULID{}
+}
+
+// ulidGenerator makes ULIDs which increase monotonically, even when they're
+// made within the same millisecond.
+var ulidGenerator struct {
+	sync.Mutex
+	last ULID
+}
+
+// NewULID returns a new ULID with the current time. ULIDs made within the
+// same millisecond by a process increment the random bits of the previous
+// one, so that they still sort in the order they were made.
+func NewULID() (ULID, error) {
+	ulidGenerator.Lock()
+	defer ulidGenerator.Unlock()
+
+	id, err := NewULIDAt(time.Now(), rand.Reader)
+	if err != nil {
+		return ULID{}, err
+	}
+	last := ulidGenerator.last
+	if id.Compare(last) <= 0 && id.Time().Equal(last.Time()) {
+		// Increment the 80 random bits of the last ULID.
+		id = last
+		i := len(id) - 1
+		for ; i >= 6; i-- {
+			id[i]++
+			if id[i] != 0 {
+				break
+			}
+		}
+		if i < 6 {
+			return ULID{}, errors.New("ulid: too many ULIDs made in a millisecond")
+		}
+	}
+	ulidGenerator.last = id
+	return id, nil
+}
+
+// NewULIDAt returns a ULID with the time t, and random bits read from
+// entropy, such as crypto/rand.Reader. Unlike NewULID, the ULIDs it makes
+// within the same millisecond are in a random order.
+func NewULIDAt(t time.Time, entropy io.Reader) (ULID, error) {
+	var id ULID
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return id, fmt.Errorf("ulid: time %s is out of range", t)
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(ms))
+	copy(id[:6], buf[2:])
+	if _, err := io.ReadFull(entropy, id[6:]); err != nil {
+		return ULID{}, fmt.Errorf("ulid: error reading entropy: %w", err)
+	}
+	return id, nil
+}
+
+func (id ULID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+func (id *ULID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+func (id ULID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ULID) UnmarshalText(data []byte) error {
+	parsed, err := ParseULID(string(data))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Bind implements runtime.Binder, so that parameters are parsed as ULIDs.
+func (id *ULID) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return id.UnmarshalText([]byte(src))
+}
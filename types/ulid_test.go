@@ -0,0 +1,75 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseULID(t *testing.T) {
+	id, err := ParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.String())
+	assert.Equal(t, int64(1469922850259), id.Time().UnixMilli())
+
+	lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	require.NoError(t, err)
+	assert.Equal(t, id, lower)
+
+	max, err := ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	require.NoError(t, err)
+	assert.Equal(t, ULID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, max)
+
+	for _, s := range []string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"80000000000000000000000000",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+	} {
+		_, err := ParseULID(s)
+		assert.ErrorIs(t, err, ErrValidationULID, s)
+	}
+}
+
+func TestNewULIDAt(t *testing.T) {
+	id, err := NewULIDAt(time.UnixMilli(1469918176385), bytes.NewReader(make([]byte, 10)))
+	require.NoError(t, err)
+	assert.Equal(t, "01ARYZ6S410000000000000000", id.String())
+
+	_, err = NewULIDAt(time.UnixMilli(1469918176385), bytes.NewReader(nil))
+	assert.Error(t, err)
+}
+
+func TestNewULIDMonotonic(t *testing.T) {
+	ids := make([]ULID, 1000)
+	for i := range ids {
+		var err error
+		ids[i], err = NewULID()
+		require.NoError(t, err)
+	}
+	assert.True(t, sort.SliceIsSorted(ids, func(i, j int) bool { return ids[i].Compare(ids[j]) < 0 }))
+	for i := 1; i < len(ids); i++ {
+		assert.Equal(t, 1, ids[i].Compare(ids[i-1]))
+		assert.Less(t, ids[i-1].String(), ids[i].String())
+	}
+	assert.WithinDuration(t, time.Now(), ids[0].Time(), time.Minute)
+}
+
+func TestULIDJSON(t *testing.T) {
+	type order struct {
+		ID ULID `json:"id"`
+	}
+	var o order
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`), &o))
+	data, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`, string(data))
+
+	assert.ErrorIs(t, o.ID.Bind("not-a-ulid"), ErrValidationULID)
+	require.NoError(t, o.ID.Bind(""))
+	assert.False(t, o.ID.IsZero())
+}
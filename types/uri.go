@@ -0,0 +1,119 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// URI is an absolute URI, which has a scheme, such as
+// "https://example.com/pets?limit=10", as used by schemas with format: uri.
+type URI struct {
+	url.URL
+}
+
+// URIReference is a URI which may also be relative, such as "/pets/1" or
+// "#section", as used by schemas with format: uri-reference.
+type URIReference struct {
+	url.URL
+}
+
+// ParseURI parses an absolute URI.
+func ParseURI(s string) (URI, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return URI{}, fmt.Errorf("invalid URI '%s': %w", s, err)
+	}
+	if !u.IsAbs() {
+		return URI{}, fmt.Errorf("invalid URI '%s': it must be absolute", s)
+	}
+	return URI{URL: *u}, nil
+}
+
+// ParseURIReference parses a URI which may be relative.
+func ParseURIReference(s string) (URIReference, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return URIReference{}, fmt.Errorf("invalid URI reference '%s': %w", s, err)
+	}
+	return URIReference{URL: *u}, nil
+}
+
+// String returns the URI in its canonical form, as url.URL.String does.
+func (u URI) String() string {
+	return u.URL.String()
+}
+
+func (u URI) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *URI) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func (u URI) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *URI) UnmarshalText(data []byte) error {
+	parsed, err := ParseURI(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (u *URI) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return u.UnmarshalText([]byte(src))
+}
+
+// String returns the URI reference in its canonical form, as url.URL.String
+// does.
+func (u URIReference) String() string {
+	return u.URL.String()
+}
+
+func (u URIReference) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+func (u *URIReference) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(s))
+}
+
+func (u URIReference) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *URIReference) UnmarshalText(data []byte) error {
+	parsed, err := ParseURIReference(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Bind binds a parameter value, as runtime.Binder does. By its convention,
+// an empty value is left unbound.
+func (u *URIReference) Bind(src string) error {
+	if src == "" {
+		return nil
+	}
+	return u.UnmarshalText([]byte(src))
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURI(t *testing.T) {
+	u, err := ParseURI("HTTPS://example.com/pets?limit=10#top")
+	require.NoError(t, err)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "example.com", u.Host)
+	assert.Equal(t, "https://example.com/pets?limit=10#top", u.String())
+	assert.Equal(t, "https://example.com/pets?limit=10#top", fmt.Sprint(u))
+
+	_, err = ParseURI("/pets/1")
+	assert.EqualError(t, err, "invalid URI '/pets/1': it must be absolute")
+	_, err = ParseURI("http://[::1")
+	assert.ErrorContains(t, err, "invalid URI 'http://[::1'")
+
+	ref, err := ParseURIReference("/pets/1")
+	require.NoError(t, err)
+	assert.Equal(t, "/pets/1", ref.String())
+	_, err = ParseURIReference("%zz")
+	assert.ErrorContains(t, err, "invalid URI reference '%zz'")
+}
+
+func TestURIJSON(t *testing.T) {
+	var o struct {
+		Homepage URI          `json:"homepage"`
+		Next     URIReference `json:"next"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"homepage":"https://example.com","next":"?page=2"}`), &o))
+	assert.Equal(t, "example.com", o.Homepage.Host)
+	assert.Equal(t, "page=2", o.Next.RawQuery)
+
+	b, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"homepage":"https://example.com","next":"?page=2"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"homepage":"example.com"}`), &o))
+}
+
+func TestURIBind(t *testing.T) {
+	var u URI
+	require.NoError(t, u.Bind("mailto:pets@example.com"))
+	assert.Equal(t, "mailto", u.Scheme)
+	require.NoError(t, u.Bind(""))
+	assert.Equal(t, "mailto:pets@example.com", u.String())
+	assert.Error(t, u.Bind("pets"))
+
+	var ref URIReference
+	require.NoError(t, ref.Bind("pets"))
+	assert.Equal(t, "pets", ref.Path)
+}
@@ -1,7 +1,62 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/google/uuid"
 )
 
+// UUID is the type of schemas with format: uuid. It's an alias of
+// uuid.UUID, so that it's interchangeable with the values of that package,
+// and it marshals to and from text and JSON, which is also how parameters
+// are bound to it.
 type UUID = uuid.UUID
+
+// ErrValidationUUID is the sentinel error wrapped by the errors of UUIDs
+// which fail validation.
+var ErrValidationUUID = errors.New("uuid: failed validation")
+
+// UUIDOptions are the options of ParseUUID.
+type UUIDOptions struct {
+	// Version, when it isn't zero, is the version the UUID must have, such
+	// as 4 for random UUIDs or 7 for time ordered ones.
+	Version uuid.Version
+	// RequireRFC4122 requires the UUID to have the variant of RFC 4122,
+	// which all UUIDs of versions 1 to 8 have.
+	RequireRFC4122 bool
+}
+
+// ParseUUID parses a UUID strictly, in its canonical form of 36 hexadecimal
+// digits and hyphens, such as "9cb14230-b640-11ec-b909-0242ac120002", of
+// either case. Unlike uuid.Parse, the URN, braced and unhyphenated forms
+// aren't accepted. Its errors wrap ErrValidationUUID.
+func ParseUUID(s string, opts UUIDOptions) (UUID, error) {
+	if len(s) != 36 {
+		return UUID{}, fmt.Errorf("%w: '%s' isn't 36 characters long", ErrValidationUUID, s)
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return UUID{}, fmt.Errorf("%w: '%s' %v", ErrValidationUUID, s, err)
+	}
+	if opts.Version != 0 && id.Version() != opts.Version {
+		return UUID{}, fmt.Errorf("%w: '%s' is version %d, not %d", ErrValidationUUID, s, id.Version(), opts.Version)
+	}
+	if opts.RequireRFC4122 && id.Variant() != uuid.RFC4122 {
+		return UUID{}, fmt.Errorf("%w: '%s' has the %s variant, not %s", ErrValidationUUID, s, id.Variant(), uuid.RFC4122)
+	}
+	return id, nil
+}
+
+// NewUUIDv4 returns a random UUID, of version 4. It panics if the random
+// source fails, as uuid.New does.
+func NewUUIDv4() UUID {
+	return uuid.New()
+}
+
+// NewUUIDv7 returns a UUID of version 7, which starts with the current Unix
+// time in milliseconds, so that UUIDs made in different milliseconds sort in
+// the order they were made.
+func NewUUIDv7() (UUID, error) {
+	return uuid.NewV7()
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUUID_MarshalJSON_Zero(t *testing.T) {
@@ -51,3 +52,39 @@ func TestUUID_UnmarshalJSON_Pass(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testUUID, b.UUIDField)
 }
+
+func TestParseUUID(t *testing.T) {
+	id, err := ParseUUID("9CB14230-B640-11EC-B909-0242AC120002", UUIDOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, uuid.MustParse("9cb14230-b640-11ec-b909-0242ac120002"), id)
+
+	for _, s := range []string{
+		"urn:uuid:9cb14230-b640-11ec-b909-0242ac120002",
+		"{9cb14230-b640-11ec-b909-0242ac120002}",
+		"9cb14230b64011ecb9090242ac120002",
+		"9cb14230-b640-11ec-b909-0242ac12000g",
+	} {
+		_, err := ParseUUID(s, UUIDOptions{})
+		assert.ErrorIs(t, err, ErrValidationUUID, s)
+	}
+
+	_, err = ParseUUID("9cb14230-b640-11ec-b909-0242ac120002", UUIDOptions{Version: 4})
+	assert.ErrorIs(t, err, ErrValidationUUID)
+	assert.EqualError(t, err, "uuid: failed validation: '9cb14230-b640-11ec-b909-0242ac120002' is version 1, not 4")
+
+	_, err = ParseUUID("9cb14230-b640-11ec-7909-0242ac120002", UUIDOptions{RequireRFC4122: true})
+	assert.ErrorIs(t, err, ErrValidationUUID)
+
+	_, err = ParseUUID(NewUUIDv4().String(), UUIDOptions{Version: 4, RequireRFC4122: true})
+	assert.NoError(t, err)
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	first, err := NewUUIDv7()
+	require.NoError(t, err)
+	second, err := NewUUIDv7()
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), first.Version())
+	// The first 48 bits are the time in milliseconds.
+	assert.LessOrEqual(t, first.String()[:13], second.String()[:13])
+}
@@ -0,0 +1,87 @@
+package runtime
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownParamsError lists the parameters of a request which weren't bound
+// to anything, such as misspelled query parameters.
+type UnknownParamsError struct {
+	Location ParamLocation
+	Names    []string
+}
+
+func (e *UnknownParamsError) Error() string {
+	return fmt.Sprintf("unknown %s parameters: %s", e.Location, strings.Join(e.Names, ", "))
+}
+
+// consumedKeys records the query keys which binding parameters consumed.
+type consumedKeys struct {
+	names map[string]bool
+	// prefixes are those of the keys of deepObject parameters, such as "p[".
+	prefixes []string
+}
+
+// add records that the key name was consumed.
+func (c *consumedKeys) add(name string) {
+	if c.names == nil {
+		c.names = make(map[string]bool)
+	}
+	c.names[name] = true
+}
+
+// addQueryParam records the keys consumed by binding the query parameter
+// paramName to dest, as BindQueryParameter does. Besides the parameter's own
+// key, those are the keys of the properties of exploded objects, which
+// aren't prefixed by the parameter's name, and the keys of deepObject
+// parameters, which are.
+func (c *consumedKeys) addQueryParam(style string, explode bool, paramName string, dest interface{}) {
+	c.add(paramName)
+	if style == "deepObject" {
+		c.prefixes = append(c.prefixes, paramName+"[")
+		return
+	}
+	if !explode || (style != "form" && style != "spaceDelimited" && style != "pipeDelimited") {
+		return
+	}
+	t := reflect.TypeOf(dest)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || !isObjectDestination(t) {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			c.add(jsonFieldName(t.Field(i)))
+		}
+	}
+}
+
+// unknown returns the keys of queryParams which weren't consumed, sorted.
+func (c *consumedKeys) unknown(queryParams url.Values) []string {
+	var names []string
+	for name := range queryParams {
+		if !c.consumed(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *consumedKeys) consumed(name string) bool {
+	if c.names[name] {
+		return true
+	}
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExpandURITemplate expands a URI template, as defined by RFC 6570, up to
+// and including level 4, using the given variables. Variable values may be
+// primitives, which are formatted like any other parameter, slices, which
+// are lists, or maps, which are associative arrays, expanded in sorted key
+// order. Nil values, and empty slices and maps, are undefined.
+func ExpandURITemplate(template string, vars map[string]interface{}) (string, error) {
+	var b strings.Builder
+	for len(template) > 0 {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			if strings.IndexByte(template, '}') >= 0 {
+				return "", fmt.Errorf("unmatched '}' in URI template")
+			}
+			b.WriteString(encodeURITemplateString(template, true))
+			break
+		}
+		literal := template[:start]
+		if strings.IndexByte(literal, '}') >= 0 {
+			return "", fmt.Errorf("unmatched '}' in URI template")
+		}
+		b.WriteString(encodeURITemplateString(literal, true))
+
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated expression in URI template")
+		}
+		expr := template[start+1 : start+end]
+		if err := expandURITemplateExpression(&b, expr, vars); err != nil {
+			return "", fmt.Errorf("error expanding '{%s}': %w", expr, err)
+		}
+		template = template[start+end+1:]
+	}
+	return b.String(), nil
+}
+
+// uriTemplateOperator describes how the expressions of an operator expand,
+// per RFC 6570, Appendix A.
+type uriTemplateOperator struct {
+	first         string
+	sep           string
+	named         bool
+	ifEmpty       string
+	allowReserved bool
+}
+
+var uriTemplateOperators = map[byte]uriTemplateOperator{
+	'+': {first: "", sep: ",", allowReserved: true},
+	'#': {first: "#", sep: ",", allowReserved: true},
+	'.': {first: ".", sep: "."},
+	'/': {first: "/", sep: "/"},
+	';': {first: ";", sep: ";", named: true},
+	'?': {first: "?", sep: "&", named: true, ifEmpty: "="},
+	'&': {first: "&", sep: "&", named: true, ifEmpty: "="},
+}
+
+func expandURITemplateExpression(b *strings.Builder, expr string, vars map[string]interface{}) error {
+	op := uriTemplateOperator{sep: ","}
+	if expr != "" {
+		if o, found := uriTemplateOperators[expr[0]]; found {
+			op = o
+			expr = expr[1:]
+		} else if strings.IndexByte("=,!@|", expr[0]) >= 0 {
+			return fmt.Errorf("reserved operator '%c'", expr[0])
+		}
+	}
+	if expr == "" {
+		return fmt.Errorf("empty expression")
+	}
+
+	first := true
+	for _, varspec := range strings.Split(expr, ",") {
+		name, prefix, explode, err := parseURITemplateVarspec(varspec)
+		if err != nil {
+			return err
+		}
+		value, err := uriTemplateValue(vars[name])
+		if err != nil {
+			return fmt.Errorf("variable '%s': %w", name, err)
+		}
+		if value == nil {
+			continue
+		}
+		if first {
+			b.WriteString(op.first)
+			first = false
+		} else {
+			b.WriteString(op.sep)
+		}
+
+		switch v := value.(type) {
+		case string:
+			if op.named {
+				b.WriteString(name)
+				if v == "" {
+					b.WriteString(op.ifEmpty)
+					continue
+				}
+				b.WriteByte('=')
+			}
+			if prefix > 0 {
+				v = truncateRunes(v, prefix)
+			}
+			b.WriteString(encodeURITemplateString(v, op.allowReserved))
+		case []string, [][2]string:
+			if prefix > 0 {
+				return fmt.Errorf("prefix modifier can't be applied to composite variable '%s'", name)
+			}
+			expandURITemplateComposite(b, op, name, v, explode)
+		}
+	}
+	return nil
+}
+
+func expandURITemplateComposite(b *strings.Builder, op uriTemplateOperator, name string, value interface{}, explode bool) {
+	var parts []string
+	if !explode {
+		if op.named {
+			b.WriteString(name)
+			b.WriteByte('=')
+		}
+		switch v := value.(type) {
+		case []string:
+			for _, item := range v {
+				parts = append(parts, encodeURITemplateString(item, op.allowReserved))
+			}
+		case [][2]string:
+			for _, kv := range v {
+				parts = append(parts,
+					encodeURITemplateString(kv[0], op.allowReserved),
+					encodeURITemplateString(kv[1], op.allowReserved))
+			}
+		}
+		b.WriteString(strings.Join(parts, ","))
+		return
+	}
+
+	// Exploded, each item or pair is a separate member, named by the
+	// variable name for lists, and by the key for associative arrays.
+	pair := func(key, value string) string {
+		if value == "" {
+			return key + op.ifEmpty
+		}
+		return key + "=" + encodeURITemplateString(value, op.allowReserved)
+	}
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			if op.named {
+				parts = append(parts, pair(name, item))
+			} else {
+				parts = append(parts, encodeURITemplateString(item, op.allowReserved))
+			}
+		}
+	case [][2]string:
+		for _, kv := range v {
+			key := encodeURITemplateString(kv[0], op.allowReserved)
+			if op.named {
+				parts = append(parts, pair(key, kv[1]))
+			} else {
+				parts = append(parts, key+"="+encodeURITemplateString(kv[1], op.allowReserved))
+			}
+		}
+	}
+	b.WriteString(strings.Join(parts, op.sep))
+}
+
+// parseURITemplateVarspec splits a varspec into the variable name, and its
+// prefix or explode modifier.
+func parseURITemplateVarspec(varspec string) (string, int, bool, error) {
+	name := varspec
+	prefix := 0
+	explode := false
+	if strings.HasSuffix(varspec, "*") {
+		name = strings.TrimSuffix(varspec, "*")
+		explode = true
+	} else if i := strings.IndexByte(varspec, ':'); i >= 0 {
+		name = varspec[:i]
+		n, err := strconv.Atoi(varspec[i+1:])
+		if err != nil || n <= 0 || n >= 10000 || varspec[i+1] == '0' {
+			return "", 0, false, fmt.Errorf("invalid prefix modifier in '%s'", varspec)
+		}
+		prefix = n
+	}
+	if name == "" {
+		return "", 0, false, fmt.Errorf("empty variable name")
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case isUnreserved(c) && c != '-' && c != '~':
+		case c == '%' && i+2 < len(name) && isHex(name[i+1]) && isHex(name[i+2]):
+			i += 2
+		default:
+			return "", 0, false, fmt.Errorf("invalid variable name '%s'", name)
+		}
+	}
+	return name, prefix, explode, nil
+}
+
+// uriTemplateValue converts a variable into a string, a list ([]string) or
+// an associative array ([][2]string), or nil if it is undefined.
+func uriTemplateValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		list := make([]string, v.Len())
+		for i := range list {
+			s, err := primitiveToString(v.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			list[i] = s
+		}
+		return list, nil
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil, nil
+		}
+		pairs := make([][2]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := primitiveToString(iter.Key().Interface())
+			if err != nil {
+				return nil, err
+			}
+			s, err := primitiveToString(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			pairs = append(pairs, [2]string{k, s})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		return pairs, nil
+	default:
+		return primitiveToString(v.Interface())
+	}
+}
+
+// encodeURITemplateString percent-encodes every character which isn't
+// unreserved, or, when allowReserved is set, reserved or already part of a
+// percent-encoded triplet.
+func encodeURITemplateString(s string, allowReserved bool) string {
+	const upperhex = "0123456789ABCDEF"
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isUnreserved(c):
+			b.WriteByte(c)
+		case allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			b.WriteByte(c)
+		case allowReserved && c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			b.WriteString(s[i : i+3])
+			i += 2
+		default:
+			b.WriteByte('%')
+			b.WriteByte(upperhex[c>>4])
+			b.WriteByte(upperhex[c&15])
+		}
+	}
+	return b.String()
+}
+
+// truncateRunes returns the first n characters of s.
+func truncateRunes(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+	return s
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
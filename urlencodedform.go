@@ -0,0 +1,155 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// urlEncodedContentType is the media type of urlencoded form bodies.
+const urlEncodedContentType = "application/x-www-form-urlencoded"
+
+// BindURLEncodedForm parses the application/x-www-form-urlencoded body of r,
+// unless it's been parsed already, and binds it to the struct dst points to,
+// as BindURLEncodedFormValues does. As with http.Request.ParseForm, only the
+// bodies of POST, PUT and PATCH requests are read, and the query of the URL
+// is left out. The form is transcoded to UTF-8 from the charset of its
+// Content-Type, as ReadTextBody does, unless it's been parsed already.
+func BindURLEncodedForm(r *http.Request, dst interface{}) error {
+	if r.PostForm != nil {
+		return BindURLEncodedFormValues(r.PostForm, dst)
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != urlEncodedContentType {
+			return fmt.Errorf("binding a form requires a %s body, not %s", urlEncodedContentType, contentType)
+		}
+	}
+	enc, err := charsetEncoding(contentType)
+	if err != nil {
+		return err
+	}
+	if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("error parsing form: %w", err)
+	}
+	values := r.PostForm
+	if enc != nil {
+		if values, err = decodeValues(values, enc); err != nil {
+			return fmt.Errorf("error parsing form: %w", err)
+		}
+	}
+	return BindURLEncodedFormValues(values, dst)
+}
+
+// BindURLEncodedFormValues binds the fields of a urlencoded form body to the
+// struct dst points to, following the rules of query parameters. Each field
+// is bound to the values named by its form tag, or else by its json tag or
+// its name, and the form tag may set its style, whether it's exploded and
+// whether it's required, as query tags do for BindQuery:
+//
+//	type CreatePetForm struct {
+//		Name   string            `form:"name,required"`
+//		Tags   []string          `form:"tags"`
+//		Owners []string          `form:"owners,explode=false"`
+//		Filter map[string]string `form:"filter,style=deepObject,explode"`
+//	}
+//
+// Every field is bound, and the errors of those which fail are returned
+// together as ParamErrors. When they all bind, a Validatable struct is
+// validated.
+func BindURLEncodedFormValues(values url.Values, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("binding a form requires a pointer to a struct, not %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	var b Bindings
+	for i := 0; i < t.NumField(); i++ {
+		p, ok, err := formFieldTag(t.Field(i))
+		if err != nil {
+			return err
+		}
+		if !ok || !v.Field(i).CanSet() {
+			continue
+		}
+		bindField(p, v.Field(i), func(p paramTag, dest interface{}) {
+			b.Add(p.name, ParamLocationUndefined, BindQueryParameterWithOptions(p.style, p.name, values, dest,
+				BindQueryParameterOptions{Explode: p.explode, Required: p.required}))
+		})
+	}
+	return bindingsErr(&b, dst)
+}
+
+// MarshalURLEncodedForm returns the fields of the struct v points to as the
+// values of an application/x-www-form-urlencoded body, styled as query
+// parameters following their form tags, as BindURLEncodedFormValues binds
+// them. Nil fields, and zero fields whose json tag has omitempty, are left
+// out.
+func MarshalURLEncodedForm(v interface{}) (url.Values, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("marshaling a form requires a struct, not %T", v)
+	}
+	t := rv.Type()
+
+	result := make(url.Values)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		p, ok, err := formFieldTag(sf)
+		if err != nil {
+			return nil, err
+		}
+		field := rv.Field(i)
+		if !ok || !field.CanInterface() {
+			continue
+		}
+		if (field.Kind() == reflect.Slice || field.Kind() == reflect.Map) && field.IsNil() {
+			continue
+		}
+		if field.IsZero() && hasTagOption(strings.Split(sf.Tag.Get("json"), ","), "omitempty") {
+			continue
+		}
+		styled, err := StyleParamWithOptions(p.style, p.name, field.Interface(), StyleParamOptions{
+			ParamLocation: ParamLocationQuery,
+			Explode:       p.explode,
+			OmitNil:       true,
+		})
+		if errors.Is(err, ErrOmitParam) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling field '%s': %w", p.name, err)
+		}
+		values, err := url.ParseQuery(styled)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling field '%s': %w", p.name, err)
+		}
+		for name, vs := range values {
+			result[name] = append(result[name], vs...)
+		}
+	}
+	return result, nil
+}
+
+// formFieldTag returns the parameter a field of a form body is bound as,
+// named by its form tag, or else by its json tag or its name, and styled as
+// its form tag says. It returns false for fields which are skipped.
+func formFieldTag(sf reflect.StructField) (paramTag, bool, error) {
+	name, ok := multipartFieldName(sf)
+	if !ok {
+		return paramTag{}, false, nil
+	}
+	p, err := parseParamTag(sf.Tag.Get("form"), paramTag{style: "form"})
+	if err != nil {
+		return p, false, fmt.Errorf("error parsing form tag of field '%s': %w", sf.Name, err)
+	}
+	p.name = name
+	return p, true, nil
+}
@@ -0,0 +1,139 @@
+package runtime
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type petFilter struct {
+	Kind   string `json:"kind"`
+	MinAge *int   `json:"minAge,omitempty"`
+}
+
+type createPetForm struct {
+	Name    string     `form:"name,required"`
+	Age     *int       `json:"age,omitempty"`
+	Tags    []string   `form:"tags"`
+	Owners  []string   `form:"owners,explode=false"`
+	Filter  *petFilter `form:"filter,style=deepObject,explode"`
+	Vaccine bool
+	Skip    string `form:"-"`
+}
+
+func (f createPetForm) Validate() error {
+	if f.Age != nil && *f.Age < 0 {
+		return errors.New("age can't be negative")
+	}
+	return nil
+}
+
+func newFormRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/pets?name=query", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	return r
+}
+
+func TestBindURLEncodedForm(t *testing.T) {
+	body := "name=Rex&age=3&tags=a&tags=b&owners=ann,bob&filter[kind]=dog&filter[minAge]=2&Vaccine=true&Skip=x"
+	var form createPetForm
+	require.NoError(t, BindURLEncodedForm(newFormRequest(body), &form))
+	minAge := 2
+	age := 3
+	assert.Equal(t, createPetForm{
+		Name:    "Rex",
+		Age:     &age,
+		Tags:    []string{"a", "b"},
+		Owners:  []string{"ann", "bob"},
+		Filter:  &petFilter{Kind: "dog", MinAge: &minAge},
+		Vaccine: true,
+	}, form)
+
+	form = createPetForm{}
+	require.NoError(t, BindURLEncodedFormValues(url.Values{"name": {"Rex"}}, &form))
+	// As for query parameters, deepObject destinations are always allocated.
+	assert.Equal(t, createPetForm{Name: "Rex", Filter: &petFilter{}}, form)
+}
+
+func TestBindURLEncodedFormErrors(t *testing.T) {
+	var form createPetForm
+	err := BindURLEncodedForm(newFormRequest("age=x&Vaccine=maybe"), &form)
+	var paramErrs ParamErrors
+	require.ErrorAs(t, err, &paramErrs)
+	require.Len(t, paramErrs, 3)
+	assert.Equal(t, "name", paramErrs[0].ParamName)
+	var required *RequiredParamError
+	assert.ErrorAs(t, paramErrs[0], &required)
+	assert.Equal(t, "age", paramErrs[1].ParamName)
+	assert.Contains(t, paramErrs[1].Error(), "invalid parameter 'age'")
+	assert.Equal(t, "Vaccine", paramErrs[2].ParamName)
+
+	err = BindURLEncodedFormValues(url.Values{"name": {"Rex"}, "age": {"-1"}}, &form)
+	assert.EqualError(t, err, "validation failed: age can't be negative")
+
+	r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(`{"name":"Rex"}`))
+	r.Header.Set("Content-Type", "application/json")
+	err = BindURLEncodedForm(r, &form)
+	assert.EqualError(t, err, "binding a form requires a application/x-www-form-urlencoded body, not application/json")
+
+	err = BindURLEncodedFormValues(url.Values{}, form)
+	assert.EqualError(t, err, "binding a form requires a pointer to a struct, not runtime.createPetForm")
+
+	var badTag struct {
+		Name string `form:"name,wat"`
+	}
+	err = BindURLEncodedFormValues(url.Values{}, &badTag)
+	assert.EqualError(t, err, "error parsing form tag of field 'Name': unknown option 'wat'")
+}
+
+func TestMarshalURLEncodedForm(t *testing.T) {
+	minAge := 2
+	age := 3
+	form := createPetForm{
+		Name:    "Rex & Co",
+		Age:     &age,
+		Tags:    []string{"a", "b"},
+		Owners:  []string{"ann", "bob"},
+		Filter:  &petFilter{Kind: "dog", MinAge: &minAge},
+		Vaccine: true,
+		Skip:    "x",
+	}
+	values, err := MarshalURLEncodedForm(&form)
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"name":           {"Rex & Co"},
+		"age":            {"3"},
+		"tags":           {"a", "b"},
+		"owners":         {"ann,bob"},
+		"filter[kind]":   {"dog"},
+		"filter[minAge]": {"2"},
+		"Vaccine":        {"true"},
+	}, values)
+
+	var bound createPetForm
+	require.NoError(t, BindURLEncodedForm(newFormRequest(values.Encode()), &bound))
+	form.Skip = ""
+	assert.Equal(t, form, bound)
+
+	values, err = MarshalURLEncodedForm(createPetForm{Name: "Rex"})
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"name": {"Rex"}, "Vaccine": {"false"}}, values)
+
+	_, err = MarshalURLEncodedForm("Rex")
+	assert.EqualError(t, err, "marshaling a form requires a struct, not string")
+}
+
+func TestBindURLEncodedFormCharset(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader("name=Ren%E9&tags=%80"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=windows-1252")
+	var form createPetForm
+	require.NoError(t, BindURLEncodedForm(r, &form))
+	assert.Equal(t, "René", form.Name)
+	assert.Equal(t, []string{"€"}, form.Tags)
+}
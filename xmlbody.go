@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// BodyOptions defines optional arguments for the body binders, such as
+// BindXMLWithOptions, BindYAMLWithOptions and BindBodyWithOptions.
+type BodyOptions struct {
+	// MaxBodySize, when it's positive, limits the size of the body, which
+	// fails with a *LimitExceededError beyond it.
+	MaxBodySize int64
+	// DisallowUnknownFields fails on object properties which don't match a
+	// field of the destination, as json.Decoder.DisallowUnknownFields does.
+	// It's only supported by YAML bodies.
+	DisallowUnknownFields bool
+}
+
+// BindXML decodes the XML body of r into dst, as BindXMLWithOptions does.
+func BindXML(r *http.Request, dst interface{}) error {
+	return BindXMLWithOptions(r, dst, BodyOptions{})
+}
+
+// BindXMLWithOptions decodes the XML body of r, as declared by
+// application/xml and text/xml media types, into dst. Fields are named by
+// their xml tags, which may be generated alongside their json tags, or else
+// by their names, as encoding/xml does. Bodies in other encodings than
+// UTF-8, declared by their XML declaration, such as
+// <?xml version="1.0" encoding="ISO-8859-1"?>, are transcoded as they're
+// read. When it's decoded, a Validatable dst is validated.
+func BindXMLWithOptions(r *http.Request, dst interface{}, opts BodyOptions) (err error) {
+	defer recoverPanic(&err, "error binding XML body")
+	if err := checkDestination("", dst, false); err != nil {
+		return err
+	}
+	dec := xml.NewDecoder(limitBody(r.Body, opts.MaxBodySize))
+	dec.CharsetReader = charsetReader
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("error decoding XML body: %w", err)
+	}
+	return validate("", dst)
+}
+
+// MarshalXML encodes v as an XML document, after the standard XML
+// declaration, such as for the body of a request.
+func MarshalXML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// limitBody limits the size of a body to max bytes, when it's positive,
+// beyond which it fails with a *LimitExceededError.
+func limitBody(body io.Reader, max int64) io.Reader {
+	if max <= 0 {
+		return body
+	}
+	return &limitedReader{r: body, remaining: max, err: &LimitExceededError{Limit: "MaxBodySize", Max: max}}
+}
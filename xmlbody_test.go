@@ -0,0 +1,55 @@
+package runtime
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlPet struct {
+	ID   int    `json:"id" xml:"id,attr"`
+	Name string `json:"name" xml:"name"`
+}
+
+func (p xmlPet) Validate() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestBindXML(t *testing.T) {
+	var pet xmlPet
+	require.NoError(t, BindXML(newBodyRequest("application/xml", `<pet id="1"><name>Rex</name></pet>`), &pet))
+	assert.Equal(t, xmlPet{ID: 1, Name: "Rex"}, pet)
+
+	latin1 := `<?xml version="1.0" encoding="ISO-8859-1"?><pet id="2"><name>Ren` + "\xe9" + `</name></pet>`
+	require.NoError(t, BindXML(newBodyRequest("application/xml", latin1), &pet))
+	assert.Equal(t, xmlPet{ID: 2, Name: "René"}, pet)
+
+	err := BindXML(newBodyRequest("application/xml", `<pet id="1"></pet>`), &xmlPet{})
+	assert.EqualError(t, err, "validation failed: name is required")
+
+	err = BindXML(newBodyRequest("application/xml", `<pet id="x"></pet>`), &xmlPet{})
+	assert.ErrorContains(t, err, "error decoding XML body: ")
+
+	err = BindXMLWithOptions(newBodyRequest("application/xml", `<pet id="1"><name>`+strings.Repeat("x", 100)+`</name></pet>`), &xmlPet{}, BodyOptions{MaxBodySize: 64})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.EqualError(t, err, "error decoding XML body: request body exceeds the limit MaxBodySize=64")
+
+	assert.Error(t, BindXML(newBodyRequest("application/xml", `<pet/>`), xmlPet{}))
+}
+
+func TestMarshalXML(t *testing.T) {
+	data, err := MarshalXML(xmlPet{ID: 1, Name: "Rex"})
+	require.NoError(t, err)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<xmlPet id="1"><name>Rex</name></xmlPet>`, string(data))
+
+	var pet xmlPet
+	require.NoError(t, BindXML(newBodyRequest("application/xml", string(data)), &pet))
+	assert.Equal(t, xmlPet{ID: 1, Name: "Rex"}, pet)
+}
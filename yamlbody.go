@@ -0,0 +1,148 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlContentType is the media type of YAML bodies.
+const yamlContentType = "application/yaml"
+
+// BindYAML decodes the YAML body of r into dst, as BindYAMLWithOptions does.
+func BindYAML(r *http.Request, dst interface{}) error {
+	return BindYAMLWithOptions(r, dst, BodyOptions{})
+}
+
+// BindYAMLWithOptions decodes the YAML body of r, as declared by
+// application/yaml media types, into dst. The body is decoded as the JSON
+// it's equivalent to, so that fields are named by their json tags, as for
+// JSON bodies, and types which unmarshal themselves from JSON, such as
+// types.Date, are bound as they are from JSON. When it's decoded, a
+// Validatable dst is validated.
+func BindYAMLWithOptions(r *http.Request, dst interface{}, opts BodyOptions) (err error) {
+	defer recoverPanic(&err, "error binding YAML body")
+	if err := checkDestination("", dst, false); err != nil {
+		return err
+	}
+	// The body is read first, as the decoder doesn't wrap the errors of its
+	// reader, such as a *LimitExceededError.
+	body, err := io.ReadAll(limitBody(r.Body, opts.MaxBodySize))
+	if err != nil {
+		return fmt.Errorf("error reading YAML body: %w", err)
+	}
+	var node yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(body)).Decode(&node); err != nil {
+		return fmt.Errorf("error decoding YAML body: %w", err)
+	}
+	// Timestamps are kept as they're written, as they are in JSON, rather
+	// than decoded as time.Time, so that dates bind to types.Date.
+	untagYAMLTimestamps(&node)
+	var doc interface{}
+	if err := node.Decode(&doc); err != nil {
+		return fmt.Errorf("error decoding YAML body: %w", err)
+	}
+	data, err := json.Marshal(yamlToJSON(doc))
+	if err != nil {
+		return fmt.Errorf("error decoding YAML body: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("error decoding YAML body: %w", err)
+	}
+	return validate("", dst)
+}
+
+// untagYAMLTimestamps decodes the timestamps of node and its children as
+// strings.
+func untagYAMLTimestamps(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+	for _, child := range node.Content {
+		untagYAMLTimestamps(child)
+	}
+}
+
+// yamlToJSON converts a decoded YAML value to one which can be encoded as
+// JSON, whose object keys must be strings.
+func yamlToJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = yamlToJSON(value)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = yamlToJSON(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = yamlToJSON(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// WriteYAML writes a response with the given status and headers, whose body
+// is v encoded as YAML, as WriteJSON does for JSON. Like BindYAML, v is
+// encoded as the JSON it's equivalent to, keeping the order of its fields.
+// The Content-Type is application/yaml, unless headers set another.
+func WriteYAML(w http.ResponseWriter, status int, v interface{}, headers http.Header) error {
+	data, err := MarshalYAML(v)
+	if err != nil {
+		return err
+	}
+	if !writeResponseHeader(w, status, headers, yamlContentType) {
+		return nil
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// MarshalYAML encodes v as a YAML document, as the JSON it's equivalent to,
+// such as for the body of a request.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML, so the JSON decodes as a YAML document, whose flow
+	// style is reset to write it in block style.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&doc)
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resetYAMLStyle resets the style of node and its children, so that they're
+// written in block style, and scalars are only quoted when they must be.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,102 @@
+package runtime
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type yamlDeployment struct {
+	Name     string            `json:"name"`
+	Replicas int               `json:"replicas"`
+	Version  string            `json:"version,omitempty"`
+	Since    types.Date        `json:"since"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Ports    []int             `json:"ports,omitempty"`
+}
+
+func (d yamlDeployment) Validate() error {
+	if d.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative")
+	}
+	return nil
+}
+
+func TestBindYAML(t *testing.T) {
+	body := `
+name: web
+replicas: 3
+version: "1.10"
+since: 2024-01-02
+labels:
+  tier: frontend
+  1: one
+ports: [80, 443]
+`
+	var d yamlDeployment
+	require.NoError(t, BindYAML(newBodyRequest("application/yaml", body), &d))
+	assert.Equal(t, "web", d.Name)
+	assert.Equal(t, 3, d.Replicas)
+	assert.Equal(t, "1.10", d.Version)
+	assert.Equal(t, "2024-01-02", d.Since.String())
+	assert.Equal(t, map[string]string{"tier": "frontend", "1": "one"}, d.Labels)
+	assert.Equal(t, []int{80, 443}, d.Ports)
+
+	err := BindYAML(newBodyRequest("application/yaml", "name: web\nreplicas: -1\nsince: 2024-01-02\n"), &yamlDeployment{})
+	assert.EqualError(t, err, "validation failed: replicas must not be negative")
+
+	err = BindYAML(newBodyRequest("application/yaml", "name: [web\n"), &yamlDeployment{})
+	assert.ErrorContains(t, err, "error decoding YAML body: ")
+
+	// Unknown fields are ignored unless they're disallowed.
+	unknown := "name: web\nreplicas: 1\nsince: 2024-01-02\nimage: nginx\n"
+	require.NoError(t, BindYAML(newBodyRequest("application/yaml", unknown), &yamlDeployment{}))
+	err = BindYAMLWithOptions(newBodyRequest("application/yaml", unknown), &yamlDeployment{}, BodyOptions{DisallowUnknownFields: true})
+	assert.EqualError(t, err, `error decoding YAML body: json: unknown field "image"`)
+
+	err = BindYAMLWithOptions(newBodyRequest("application/yaml", "name: "+strings.Repeat("x", 100)+"\n"), &yamlDeployment{}, BodyOptions{MaxBodySize: 64})
+	var limitErr *LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "MaxBodySize", limitErr.Limit)
+
+	assert.Error(t, BindYAML(newBodyRequest("application/yaml", body), yamlDeployment{}))
+}
+
+func TestWriteYAML(t *testing.T) {
+	d := yamlDeployment{
+		Name:     "web",
+		Replicas: 3,
+		Version:  "1.10",
+		Since:    types.Date{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		Ports:    []int{80, 443},
+	}
+	w := httptest.NewRecorder()
+	require.NoError(t, WriteYAML(w, http.StatusCreated, d, nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Equal(t, `name: web
+replicas: 3
+version: "1.10"
+since: "2024-01-02"
+ports:
+  - 80
+  - 443
+`, w.Body.String())
+
+	var bound yamlDeployment
+	require.NoError(t, BindYAML(newBodyRequest("application/yaml", w.Body.String()), &bound))
+	assert.Equal(t, d, bound)
+
+	w = httptest.NewRecorder()
+	require.NoError(t, WriteYAML(w, http.StatusNoContent, d, nil))
+	assert.Empty(t, w.Body.String())
+	assert.Empty(t, w.Header().Get("Content-Type"))
+}